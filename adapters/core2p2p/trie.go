@@ -0,0 +1,39 @@
+package core2p2p
+
+import (
+	"fmt"
+
+	"github.com/NethermindEth/juno/core/trie"
+	"github.com/NethermindEth/juno/p2p/starknet/spec"
+)
+
+func AdaptProof(proof []trie.ProofNode) *spec.PatriciaProof {
+	nodes := make([]*spec.PatriciaNode, 0, len(proof))
+	for _, node := range proof {
+		switch n := node.(type) {
+		case *trie.Binary:
+			nodes = append(nodes, &spec.PatriciaNode{
+				Node: &spec.PatriciaNode_Binary_{
+					Binary: &spec.PatriciaNode_Binary{
+						Left:  AdaptFelt(n.LeftHash),
+						Right: AdaptFelt(n.RightHash),
+					},
+				},
+			})
+		case *trie.Edge:
+			path := n.Path.Felt()
+			nodes = append(nodes, &spec.PatriciaNode{
+				Node: &spec.PatriciaNode_Edge_{
+					Edge: &spec.PatriciaNode_Edge{
+						Length: uint32(n.Path.Len()),
+						Path:   AdaptFelt(&path),
+						Child:  AdaptFelt(n.Child),
+					},
+				},
+			})
+		default:
+			panic(fmt.Errorf("unsupported proof node %T", n))
+		}
+	}
+	return &spec.PatriciaProof{Nodes: nodes}
+}
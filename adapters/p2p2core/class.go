@@ -14,9 +14,21 @@ import (
 	"github.com/NethermindEth/juno/utils"
 )
 
+// AdaptClass adapts class, panicking if it is a Cairo 1 class that doesn't compile. Classes that may not compile,
+// such as those gossiped by peers, are adapted with TryAdaptClass.
 func AdaptClass(class *spec.Class) core.Class {
+	coreClass, err := TryAdaptClass(class)
+	if err != nil {
+		panic(err)
+	}
+	return coreClass
+}
+
+// TryAdaptClass adapts class, returning an error if it is of an unsupported type or a Cairo 1 class that doesn't
+// compile.
+func TryAdaptClass(class *spec.Class) (core.Class, error) {
 	if class == nil {
-		return nil
+		return nil, nil
 	}
 
 	switch cls := class.Class.(type) {
@@ -33,7 +45,7 @@ func AdaptClass(class *spec.Class) core.Class {
 			L1Handlers:   adaptEP(cairo0.L1Handlers),
 			Constructors: adaptEP(cairo0.Constructors),
 			Program:      cairo0.Program,
-		}
+		}, nil
 	case *spec.Class_Cairo1:
 		cairo1 := cls.Cairo1
 		abiHash := crypto.StarknetKeccak([]byte(cairo1.Abi))
@@ -41,7 +53,7 @@ func AdaptClass(class *spec.Class) core.Class {
 		program := utils.Map(cairo1.Program, AdaptFelt)
 		compiled, err := createCompiledClass(cairo1)
 		if err != nil {
-			panic(err)
+			return nil, err
 		}
 
 		adaptEP := func(points []*spec.SierraEntryPoint) []core.SierraEntryPoint {
@@ -66,9 +78,9 @@ func AdaptClass(class *spec.Class) core.Class {
 			ProgramHash:     crypto.PoseidonArray(program...),
 			SemanticVersion: cairo1.ContractClassVersion,
 			Compiled:        compiled,
-		}
+		}, nil
 	default:
-		panic(fmt.Errorf("unsupported class %T", cls))
+		return nil, fmt.Errorf("unsupported class %T", cls)
 	}
 }
 
@@ -0,0 +1,42 @@
+package p2p2core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/core/trie"
+	"github.com/NethermindEth/juno/p2p/starknet/spec"
+)
+
+func AdaptProof(proof *spec.PatriciaProof) ([]trie.ProofNode, error) {
+	if proof == nil {
+		return nil, errors.New("missing proof")
+	}
+
+	nodes := make([]trie.ProofNode, 0, len(proof.Nodes))
+	for _, node := range proof.Nodes {
+		switch n := node.GetNode().(type) {
+		case *spec.PatriciaNode_Binary_:
+			left, right := AdaptFelt(n.Binary.GetLeft()), AdaptFelt(n.Binary.GetRight())
+			if left == nil || right == nil {
+				return nil, errors.New("binary proof node without child hashes")
+			}
+			nodes = append(nodes, &trie.Binary{LeftHash: left, RightHash: right})
+		case *spec.PatriciaNode_Edge_:
+			path, child := AdaptFelt(n.Edge.GetPath()), AdaptFelt(n.Edge.GetChild())
+			if path == nil || child == nil {
+				return nil, errors.New("edge proof node without path or child hash")
+			}
+			if n.Edge.Length > felt.Bits {
+				return nil, fmt.Errorf("edge proof node with path length %d", n.Edge.Length)
+			}
+			pathBytes := path.Bytes()
+			key := trie.NewKey(uint8(n.Edge.Length), pathBytes[:])
+			nodes = append(nodes, &trie.Edge{Child: child, Path: &key})
+		default:
+			return nil, fmt.Errorf("unsupported proof node %T", n)
+		}
+	}
+	return nodes, nil
+}
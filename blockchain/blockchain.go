@@ -13,10 +13,16 @@ import (
 	"github.com/NethermindEth/juno/core/felt"
 	"github.com/NethermindEth/juno/db"
 	"github.com/NethermindEth/juno/encoder"
+	"github.com/NethermindEth/juno/feed"
 	"github.com/NethermindEth/juno/utils"
 	"github.com/ethereum/go-ethereum/common"
 )
 
+// This is a work-around. mockgen chokes when the instantiated generic type is in the interface.
+type L1HeadSubscription struct {
+	*feed.Subscription[*core.L1Head]
+}
+
 //go:generate mockgen -destination=../mocks/mock_blockchain.go -package=mocks github.com/NethermindEth/juno/blockchain Reader
 type Reader interface {
 	Height() (height uint64, err error)
@@ -49,10 +55,13 @@ type Reader interface {
 	Pending() (Pending, error)
 
 	Network() *utils.Network
+
+	SubscribeL1Head() L1HeadSubscription
 }
 
 var (
 	ErrParentDoesNotMatchHead = errors.New("block's parent hash does not match head block hash")
+	ErrNetworkMismatch        = errors.New("database belongs to a different network")
 	SupportedStarknetVersion  = semver.MustParse("0.13.3")
 )
 
@@ -88,15 +97,19 @@ type Blockchain struct {
 
 	listener EventListener
 
+	verifySignatures bool
+
 	cachedPending atomic.Pointer[Pending]
+	l1HeadFeed    *feed.Feed[*core.L1Head]
 }
 
 func New(database db.DB, network *utils.Network) *Blockchain {
 	RegisterCoreTypesToEncoder()
 	return &Blockchain{
-		database: database,
-		network:  network,
-		listener: &SelectiveListener{},
+		database:   database,
+		network:    network,
+		listener:   &SelectiveListener{},
+		l1HeadFeed: feed.New[*core.L1Head](),
 	}
 }
 
@@ -105,6 +118,13 @@ func (b *Blockchain) WithListener(listener EventListener) *Blockchain {
 	return b
 }
 
+// WithSignatureVerification makes [Blockchain.SanityCheckNewHeight] reject blocks that are not signed
+// by the sequencer key of the network.
+func (b *Blockchain) WithSignatureVerification() *Blockchain {
+	b.verifySignatures = true
+	return b
+}
+
 func (b *Blockchain) Network() *utils.Network {
 	return b.network
 }
@@ -161,6 +181,27 @@ func (b *Blockchain) HeadsHeader() (*core.Header, error) {
 	})
 }
 
+// VerifyNetwork checks that the database holds the chain of b's network by verifying the hash of the head block
+// against it. We assume that there is at least one transaction in the block or that it is a pre-0.7 block. An empty
+// database matches any network.
+func (b *Blockchain) VerifyNetwork() error {
+	head, err := b.Head()
+	if err != nil {
+		if errors.Is(err, db.ErrKeyNotFound) {
+			return nil
+		}
+		return fmt.Errorf("get head block: %w", err)
+	}
+	stateUpdate, err := b.StateUpdateByNumber(head.Number)
+	if err != nil {
+		return fmt.Errorf("get state update of head block: %w", err)
+	}
+	if _, err = core.VerifyBlockHash(head, b.network, stateUpdate.StateDiff); err != nil {
+		return fmt.Errorf("%w: head block %d doesn't verify on %s: %v", ErrNetworkMismatch, head.Number, b.network.Name, err)
+	}
+	return nil
+}
+
 func head(txn db.Transaction) (*core.Block, error) {
 	height, err := ChainHeight(txn)
 	if err != nil {
@@ -349,9 +390,18 @@ func (b *Blockchain) SetL1Head(update *core.L1Head) error {
 	if err != nil {
 		return err
 	}
-	return b.database.Update(func(txn db.Transaction) error {
+	if err = b.database.Update(func(txn db.Transaction) error {
 		return txn.Set(db.L1Height.Key(), updateBytes)
-	})
+	}); err != nil {
+		return err
+	}
+	b.l1HeadFeed.Send(update)
+	return nil
+}
+
+// SubscribeL1Head returns a subscription that receives every L1 head stored with SetL1Head.
+func (b *Blockchain) SubscribeL1Head() L1HeadSubscription {
+	return L1HeadSubscription{b.l1HeadFeed.Subscribe()}
 }
 
 // Store takes a block and state update and performs sanity checks before putting in the database.
@@ -366,38 +416,49 @@ func (b *Blockchain) Store(block *core.Block, blockCommitments *core.BlockCommit
 		if err := core.NewState(txn).Update(block.Number, stateUpdate, newClasses); err != nil {
 			return err
 		}
-		if err := StoreBlockHeader(txn, block.Header); err != nil {
-			return err
-		}
+		return b.storeHead(txn, block, blockCommitments, stateUpdate)
+	})
+}
 
-		for i, tx := range block.Transactions {
-			if err := storeTransactionAndReceipt(txn, block.Number, uint64(i), tx,
-				block.Receipts[i]); err != nil {
-				return err
-			}
-		}
+// storeHead stores block, whose state is already applied, as the new head.
+func (b *Blockchain) storeHead(txn db.Transaction, block *core.Block, blockCommitments *core.BlockCommitments,
+	stateUpdate *core.StateUpdate,
+) error {
+	if err := StoreBlockHeader(txn, block.Header); err != nil {
+		return err
+	}
 
-		if err := storeStateUpdate(txn, block.Number, stateUpdate); err != nil {
+	for i, tx := range block.Transactions {
+		if err := storeTransactionAndReceipt(txn, block.Number, uint64(i), tx,
+			block.Receipts[i]); err != nil {
 			return err
 		}
+	}
 
-		if err := StoreBlockCommitments(txn, block.Number, blockCommitments); err != nil {
-			return err
-		}
+	if err := storeStateUpdate(txn, block.Number, stateUpdate); err != nil {
+		return err
+	}
 
-		if err := StoreL1HandlerMsgHashes(txn, block.Transactions); err != nil {
-			return err
-		}
+	if err := StoreBlockCommitments(txn, block.Number, blockCommitments); err != nil {
+		return err
+	}
 
-		if err := b.storeEmptyPending(txn, block.Header); err != nil {
-			return err
-		}
+	if err := StoreL1HandlerMsgHashes(txn, block.Transactions); err != nil {
+		return err
+	}
 
-		// Head of the blockchain is maintained as follows:
-		// [db.ChainHeight]() -> (BlockNumber)
-		heightBin := core.MarshalBlockNumber(block.Number)
-		return txn.Set(db.ChainHeight.Key(), heightBin)
-	})
+	if err := StoreEventIndex(txn, block.Number, block.Receipts); err != nil {
+		return err
+	}
+
+	if err := b.storeEmptyPending(txn, block.Header); err != nil {
+		return err
+	}
+
+	// Head of the blockchain is maintained as follows:
+	// [db.ChainHeight]() -> (BlockNumber)
+	heightBin := core.MarshalBlockNumber(block.Number)
+	return txn.Set(db.ChainHeight.Key(), heightBin)
 }
 
 // VerifyBlock assumes the block has already been sanity-checked.
@@ -683,7 +744,32 @@ func (b *Blockchain) SanityCheckNewHeight(block *core.Block, stateUpdate *core.S
 		return nil, err
 	}
 
-	return core.VerifyBlockHash(block, b.network, stateUpdate.StateDiff)
+	commitments, err := core.VerifyBlockHash(block, b.network, stateUpdate.StateDiff)
+	if err != nil {
+		return nil, err
+	}
+
+	if b.verifySignatures {
+		if b.network.SequencerPublicKey == nil {
+			return nil, errors.New("sequencer public key is not set for the network")
+		}
+		if err = core.VerifyBlockSignature(block, b.network.SequencerPublicKey, stateUpdate.StateDiff); err != nil {
+			return nil, err
+		}
+	}
+	return commitments, nil
+}
+
+// VerifyHeaderSignature verifies the sequencer's signature of a header, given the commitment to the state diff of
+// its block. It is a no-op unless signatures are verified.
+func (b *Blockchain) VerifyHeaderSignature(header *core.Header, stateDiffCommitment *felt.Felt) error {
+	if !b.verifySignatures {
+		return nil
+	}
+	if b.network.SequencerPublicKey == nil {
+		return errors.New("sequencer public key is not set for the network")
+	}
+	return core.VerifyHeaderSignature(header, b.network.SequencerPublicKey, stateDiffCommitment)
 }
 
 type txAndReceiptDBKey struct {
@@ -827,6 +913,9 @@ func (b *Blockchain) StateAtBlockNumber(blockNumber uint64) (core.StateReader, S
 	if err != nil {
 		return nil, nil, utils.RunAndWrapOnError(txn.Discard, err)
 	}
+	if err = checkStateHistory(txn, blockNumber); err != nil {
+		return nil, nil, utils.RunAndWrapOnError(txn.Discard, err)
+	}
 
 	return core.NewStateSnapshot(core.NewState(txn), blockNumber), txn.Discard, nil
 }
@@ -849,6 +938,9 @@ func (b *Blockchain) StateAtBlockHash(blockHash *felt.Felt) (core.StateReader, S
 	if err != nil {
 		return nil, nil, utils.RunAndWrapOnError(txn.Discard, err)
 	}
+	if err = checkStateHistory(txn, header.Number); err != nil {
+		return nil, nil, utils.RunAndWrapOnError(txn.Discard, err)
+	}
 
 	return core.NewStateSnapshot(core.NewState(txn), header.Number), txn.Discard, nil
 }
@@ -898,6 +990,24 @@ func (b *Blockchain) revertHead(txn db.Transaction) error {
 	}
 	numBytes := core.MarshalBlockNumber(blockNumber)
 
+	// Reverting a block reads the values it overwrote from the state history.
+	if err = checkStateHistory(txn, blockNumber); err != nil {
+		return err
+	}
+
+	genesisBlock := blockNumber == 0
+
+	var newHeader *core.Header
+	if !genesisBlock {
+		// The blocks before a state snapshot aren't stored, so it can't be reverted.
+		if newHeader, err = blockHeaderByNumber(txn, blockNumber-1); err != nil {
+			if errors.Is(err, db.ErrKeyNotFound) {
+				return ErrHistoryPruned
+			}
+			return err
+		}
+	}
+
 	stateUpdate, err := stateUpdateByNumber(txn, blockNumber)
 	if err != nil {
 		return err
@@ -914,19 +1024,26 @@ func (b *Blockchain) revertHead(txn db.Transaction) error {
 		return err
 	}
 
-	genesisBlock := blockNumber == 0
-
 	// remove block header
 	for _, key := range [][]byte{
 		db.BlockHeadersByNumber.Key(numBytes),
 		db.BlockHeaderNumbersByHash.Key(header.Hash.Marshal()),
 		db.BlockCommitments.Key(numBytes),
+		db.BlockTraces.Key(header.Hash.Marshal()),
 	} {
 		if err = txn.Delete(key); err != nil {
 			return err
 		}
 	}
 
+	receipts, err := receiptsByBlockNumber(txn, blockNumber)
+	if err != nil {
+		return err
+	}
+	if err = removeEventIndex(txn, blockNumber, receipts); err != nil {
+		return err
+	}
+
 	if err = removeTxsAndReceipts(txn, blockNumber, header.TransactionCount); err != nil {
 		return err
 	}
@@ -944,11 +1061,6 @@ func (b *Blockchain) revertHead(txn db.Transaction) error {
 		return txn.Delete(db.ChainHeight.Key())
 	}
 
-	var newHeader *core.Header
-	newHeader, err = blockHeaderByNumber(txn, blockNumber-1)
-	if err != nil {
-		return err
-	}
 	if err := b.storeEmptyPending(txn, newHeader); err != nil {
 		return err
 	}
@@ -2,6 +2,7 @@ package blockchain_test
 
 import (
 	"context"
+	"crypto/rand"
 	"fmt"
 	"testing"
 	"time"
@@ -9,12 +10,15 @@ import (
 	"github.com/NethermindEth/juno/blockchain"
 	"github.com/NethermindEth/juno/clients/feeder"
 	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/crypto"
 	"github.com/NethermindEth/juno/core/felt"
 	"github.com/NethermindEth/juno/db"
 	"github.com/NethermindEth/juno/db/pebble"
 	"github.com/NethermindEth/juno/mocks"
 	adaptfeeder "github.com/NethermindEth/juno/starknetdata/feeder"
 	"github.com/NethermindEth/juno/utils"
+	"github.com/NethermindEth/juno/vm"
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/ecdsa"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -223,6 +227,85 @@ func TestSanityCheckNewHeight(t *testing.T) {
 			_, err = chain.SanityCheckNewHeight(mainnetBlock1, stateUpdate, nil)
 			assert.EqualError(t, err, "block's GlobalStateRoot does not match state update's NewRoot")
 		})
+
+	t.Run("sequencer signature", func(t *testing.T) {
+		privateKey, err := ecdsa.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		network := utils.Mainnet
+		network.SequencerPublicKey = felt.NewFelt(&privateKey.PublicKey.A.X)
+		chain := blockchain.New(pebble.NewMemTest(t), &network).WithSignatureVerification()
+
+		mainnetStateUpdate1, err := gw.StateUpdate(context.Background(), 1)
+		require.NoError(t, err)
+		sign := func(block *core.Block) []*felt.Felt {
+			msg := crypto.PoseidonArray(block.Hash, mainnetStateUpdate1.StateDiff.Commitment()).Bytes()
+			sig, err := privateKey.Sign(msg[:], nil)
+			require.NoError(t, err)
+			return []*felt.Felt{new(felt.Felt).SetBytes(sig[:felt.Bytes]), new(felt.Felt).SetBytes(sig[felt.Bytes:])}
+		}
+
+		t.Run("valid signature", func(t *testing.T) {
+			mainnetBlock1, err := gw.BlockByNumber(context.Background(), 1)
+			require.NoError(t, err)
+			mainnetBlock1.Signatures = [][]*felt.Felt{sign(mainnetBlock1)}
+
+			_, err = chain.SanityCheckNewHeight(mainnetBlock1, mainnetStateUpdate1, nil)
+			require.NoError(t, err)
+		})
+
+		t.Run("missing signature", func(t *testing.T) {
+			mainnetBlock1, err := gw.BlockByNumber(context.Background(), 1)
+			require.NoError(t, err)
+			mainnetBlock1.Signatures = nil
+
+			_, err = chain.SanityCheckNewHeight(mainnetBlock1, mainnetStateUpdate1, nil)
+			assert.EqualError(t, err, "block is not signed")
+		})
+
+		t.Run("signature by another key", func(t *testing.T) {
+			mainnetBlock1, err := gw.BlockByNumber(context.Background(), 1)
+			require.NoError(t, err)
+			mainnetBlock1.Signatures = [][]*felt.Felt{sign(mainnetBlock1)}
+
+			otherKey, err := ecdsa.GenerateKey(rand.Reader)
+			require.NoError(t, err)
+			otherNetwork := network
+			otherNetwork.SequencerPublicKey = felt.NewFelt(&otherKey.PublicKey.A.X)
+			otherChain := blockchain.New(pebble.NewMemTest(t), &otherNetwork).WithSignatureVerification()
+
+			_, err = otherChain.SanityCheckNewHeight(mainnetBlock1, mainnetStateUpdate1, nil)
+			assert.EqualError(t, err, "can not verify sequencer signature")
+		})
+
+		t.Run("no public key", func(t *testing.T) {
+			mainnetBlock1, err := gw.BlockByNumber(context.Background(), 1)
+			require.NoError(t, err)
+
+			noKeyNetwork := utils.Mainnet
+			noKeyNetwork.SequencerPublicKey = nil
+			chain := blockchain.New(pebble.NewMemTest(t), &noKeyNetwork).WithSignatureVerification()
+			_, err = chain.SanityCheckNewHeight(mainnetBlock1, mainnetStateUpdate1, nil)
+			assert.EqualError(t, err, "sequencer public key is not set for the network")
+		})
+
+		t.Run("signed by the network's sequencer", func(t *testing.T) {
+			mainnetBlock1, err := gw.BlockByNumber(context.Background(), 1)
+			require.NoError(t, err)
+
+			chain := blockchain.New(pebble.NewMemTest(t), &utils.Mainnet).WithSignatureVerification()
+			_, err = chain.SanityCheckNewHeight(mainnetBlock1, mainnetStateUpdate1, nil)
+			require.NoError(t, err)
+		})
+
+		t.Run("signed over the block hash from 0.13.2", func(t *testing.T) {
+			sepoliaGw := adaptfeeder.New(feeder.NewTestClient(t, &utils.Sepolia))
+			block, err := sepoliaGw.BlockByNumber(context.Background(), 284801)
+			require.NoError(t, err)
+
+			require.NoError(t, core.VerifyBlockSignature(block, utils.Sepolia.SequencerPublicKey, nil))
+		})
+	})
 }
 
 func TestStore(t *testing.T) {
@@ -669,6 +752,91 @@ func TestRevert(t *testing.T) {
 	})
 }
 
+func TestRevertEventIndex(t *testing.T) {
+	testdb := pebble.NewMemTest(t)
+	chain := blockchain.New(testdb, &utils.Sepolia)
+
+	client := feeder.NewTestClient(t, &utils.Sepolia)
+	gw := adaptfeeder.New(client)
+
+	dumpEventIndex := func() map[string][]byte {
+		entries := make(map[string][]byte)
+		require.NoError(t, testdb.View(func(txn db.Transaction) error {
+			it, err := txn.NewIterator()
+			if err != nil {
+				return err
+			}
+			prefix := db.EventIndex.Key()
+			for it.Seek(prefix); it.Valid() && it.Key()[0] == prefix[0]; it.Next() {
+				val, err := it.Value()
+				if err != nil {
+					return err
+				}
+				entries[string(it.Key())] = val
+			}
+			return it.Close()
+		}))
+		return entries
+	}
+
+	var before map[string][]byte
+	for i := uint64(0); i < 6; i++ {
+		b, err := gw.BlockByNumber(context.Background(), i)
+		require.NoError(t, err)
+		su, err := gw.StateUpdate(context.Background(), i)
+		require.NoError(t, err)
+
+		before = dumpEventIndex()
+		require.NoError(t, chain.Store(b, &emptyCommitments, su, nil))
+	}
+	require.NotEqual(t, before, dumpEventIndex())
+
+	require.NoError(t, chain.RevertHead())
+	assert.Equal(t, before, dumpEventIndex())
+}
+
+func TestBlockTraces(t *testing.T) {
+	chain := blockchain.New(pebble.NewMemTest(t), &utils.Mainnet)
+	gw := adaptfeeder.New(feeder.NewTestClient(t, &utils.Mainnet))
+
+	var head *core.Block
+	for i := uint64(0); i < 2; i++ {
+		b, err := gw.BlockByNumber(context.Background(), i)
+		require.NoError(t, err)
+		su, err := gw.StateUpdate(context.Background(), i)
+		require.NoError(t, err)
+		require.NoError(t, chain.Store(b, &emptyCommitments, su, nil))
+		head = b
+	}
+
+	traces := []vm.TransactionTrace{{
+		Type: vm.TxnInvoke,
+		ExecuteInvocation: &vm.ExecuteInvocation{
+			FunctionInvocation: &vm.FunctionInvocation{ContractAddress: *head.Transactions[0].Hash()},
+		},
+	}}
+
+	t.Run("block is not traced", func(t *testing.T) {
+		_, err := chain.BlockTraces(head.Hash)
+		require.ErrorIs(t, err, db.ErrKeyNotFound)
+	})
+
+	t.Run("unknown block", func(t *testing.T) {
+		require.ErrorIs(t, chain.StoreBlockTraces(new(felt.Felt).SetUint64(1), traces), db.ErrKeyNotFound)
+	})
+
+	require.NoError(t, chain.StoreBlockTraces(head.Hash, traces))
+	stored, err := chain.BlockTraces(head.Hash)
+	require.NoError(t, err)
+	assert.Equal(t, traces, stored)
+
+	t.Run("traces are deleted on revert", func(t *testing.T) {
+		require.NoError(t, chain.RevertHead())
+		_, err := chain.BlockTraces(head.Hash)
+		require.ErrorIs(t, err, db.ErrKeyNotFound)
+	})
+}
+
 func TestL1Update(t *testing.T) {
 	heads := []*core.L1Head{
 		{
@@ -20,7 +20,7 @@ type EventFilter struct {
 	toBlock         uint64
 	contractAddress *felt.Felt
 	keys            [][]felt.Felt
-	maxScanned      uint // maximum number of scanned blocks in single call.
+	maxScanned      uint // maximum number of blocks whose receipts are read in single call.
 }
 
 type EventFilterRange uint
@@ -121,13 +121,32 @@ func (e *EventFilter) Events(cToken *ContinuationToken, chunkSize uint64) ([]*Fi
 		curBlock = cToken.fromBlock
 	}
 
+	var firstKeys map[felt.Felt]struct{}
+	if len(filterKeysMaps) > 0 {
+		firstKeys = filterKeysMaps[0]
+	}
+
 	var (
 		remainingScannedBlocks = e.maxScanned
 		rToken                 *ContinuationToken
+		candidates             []byte
+		candidatesRange        uint64 = math.MaxUint64
 	)
-	for ; curBlock <= e.toBlock && remainingScannedBlocks > 0; curBlock, remainingScannedBlocks = curBlock+1, remainingScannedBlocks-1 {
+	for ; curBlock <= e.toBlock && remainingScannedBlocks > 0; curBlock++ {
 		var header *core.Header
 		if curBlock != latest+1 {
+			// blocks ruled out by the event index are skipped without reading their header
+			if rangeNumber := curBlock / eventIndexRangeSize; rangeNumber != candidatesRange {
+				candidates, err = eventIndexCandidates(e.txn, rangeNumber, e.contractAddress, firstKeys)
+				if err != nil {
+					return nil, nil, err
+				}
+				candidatesRange = rangeNumber
+			}
+			if bit := curBlock % eventIndexRangeSize; candidates != nil && candidates[bit/8]&(1<<(bit%8)) == 0 {
+				continue
+			}
+
 			header, err = blockHeaderByNumber(e.txn, curBlock)
 			if err != nil {
 				return nil, nil, err
@@ -141,6 +160,7 @@ func (e *EventFilter) Events(cToken *ContinuationToken, chunkSize uint64) ([]*Fi
 			continue
 		}
 
+		// only the blocks whose receipts are read count towards the scan limit
 		var receipts []*core.TransactionReceipt
 		if curBlock != latest+1 {
 			receipts, err = receiptsByBlockNumber(e.txn, header.Number)
@@ -150,6 +170,7 @@ func (e *EventFilter) Events(cToken *ContinuationToken, chunkSize uint64) ([]*Fi
 		} else {
 			receipts = pending.Block.Receipts
 		}
+		remainingScannedBlocks--
 
 		var processedEvents uint64
 		matchedEvents, processedEvents, err = e.appendBlockEvents(matchedEvents, header, receipts, filterKeysMaps, cToken, chunkSize)
@@ -0,0 +1,152 @@
+package blockchain
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db"
+)
+
+// The event index maps every contract that emitted an event, and every value seen as the first key of an event,
+// to a bitmap of the blocks they appear in. Bitmaps are split into ranges of eventIndexRangeSize blocks so that a
+// block can be added or removed by rewriting a single small entry:
+//
+//	[db.EventIndex] + eventIndexKind + felt + range number -> bitmap of eventIndexRangeSize bits
+//
+// Only canonical blocks are indexed, the pending block is always scanned.
+const eventIndexRangeSize = 1024
+
+type eventIndexKind byte
+
+const (
+	eventIndexByAddress eventIndexKind = iota
+	eventIndexByFirstKey
+)
+
+func eventIndexKey(kind eventIndexKind, value *felt.Felt, rangeNumber uint64) []byte {
+	valueBytes := value.Bytes()
+	return db.EventIndex.Key([]byte{byte(kind)}, valueBytes[:], binary.BigEndian.AppendUint64(nil, rangeNumber))
+}
+
+// eventIndexKeys returns the index keys of the range containing blockNumber for every address and first key in receipts.
+func eventIndexKeys(blockNumber uint64, receipts []*core.TransactionReceipt) [][]byte {
+	rangeNumber := blockNumber / eventIndexRangeSize
+	seen := make(map[string]struct{})
+	var keys [][]byte
+	add := func(kind eventIndexKind, value *felt.Felt) {
+		key := eventIndexKey(kind, value, rangeNumber)
+		if _, ok := seen[string(key)]; !ok {
+			seen[string(key)] = struct{}{}
+			keys = append(keys, key)
+		}
+	}
+
+	for _, receipt := range receipts {
+		for _, event := range receipt.Events {
+			add(eventIndexByAddress, event.From)
+			if len(event.Keys) > 0 {
+				add(eventIndexByFirstKey, event.Keys[0])
+			}
+		}
+	}
+	return keys
+}
+
+// StoreEventIndex marks the block as containing the events in receipts in the event index.
+func StoreEventIndex(txn db.Transaction, blockNumber uint64, receipts []*core.TransactionReceipt) error {
+	bit := blockNumber % eventIndexRangeSize
+	for _, key := range eventIndexKeys(blockNumber, receipts) {
+		bitmap, err := eventIndexBitmap(txn, key)
+		if err != nil {
+			return err
+		}
+		bitmap[bit/8] |= 1 << (bit % 8)
+		if err = txn.Set(key, bitmap); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeEventIndex undoes [StoreEventIndex] for a reverted block.
+func removeEventIndex(txn db.Transaction, blockNumber uint64, receipts []*core.TransactionReceipt) error {
+	bit := blockNumber % eventIndexRangeSize
+	for _, key := range eventIndexKeys(blockNumber, receipts) {
+		bitmap, err := eventIndexBitmap(txn, key)
+		if err != nil {
+			return err
+		}
+		bitmap[bit/8] &^= 1 << (bit % 8)
+
+		if isZero(bitmap) {
+			err = txn.Delete(key)
+		} else {
+			err = txn.Set(key, bitmap)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// eventIndexBitmap returns the bitmap stored at key, or an empty one if there is none.
+func eventIndexBitmap(txn db.Transaction, key []byte) ([]byte, error) {
+	bitmap := make([]byte, eventIndexRangeSize/8)
+	err := txn.Get(key, func(val []byte) error {
+		copy(bitmap, val)
+		return nil
+	})
+	if err != nil && !errors.Is(err, db.ErrKeyNotFound) {
+		return nil, err
+	}
+	return bitmap, nil
+}
+
+func isZero(bitmap []byte) bool {
+	for _, b := range bitmap {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// eventIndexCandidates returns a bitmap of the blocks in the given range that may have events matching the filter.
+// It returns nil if the filter has neither an address nor first keys, in which case the index can't narrow it down.
+func eventIndexCandidates(txn db.Transaction, rangeNumber uint64, contractAddress *felt.Felt,
+	firstKeys map[felt.Felt]struct{},
+) ([]byte, error) {
+	var candidates []byte
+	if contractAddress != nil {
+		var err error
+		candidates, err = eventIndexBitmap(txn, eventIndexKey(eventIndexByAddress, contractAddress, rangeNumber))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(firstKeys) > 0 {
+		keysBitmap := make([]byte, eventIndexRangeSize/8)
+		for key := range firstKeys {
+			bitmap, err := eventIndexBitmap(txn, eventIndexKey(eventIndexByFirstKey, &key, rangeNumber))
+			if err != nil {
+				return nil, err
+			}
+			for i := range keysBitmap {
+				keysBitmap[i] |= bitmap[i]
+			}
+		}
+
+		if candidates == nil {
+			candidates = keysBitmap
+		} else {
+			for i := range candidates {
+				candidates[i] &= keysBitmap[i]
+			}
+		}
+	}
+	return candidates, nil
+}
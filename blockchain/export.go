@@ -0,0 +1,221 @@
+package blockchain
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/encoder"
+	"github.com/NethermindEth/juno/utils"
+)
+
+// An export file is made of a fixed magic and format version, followed by a gzip stream of length-prefixed
+// CBOR records: one [ExportHeader] and then one [ExportedBlock] per block, in ascending order.
+//
+//	"JUNOEXPORT" + version byte + gzip(uvarint(len) + cbor(ExportHeader) + {uvarint(len) + cbor(ExportedBlock)})
+const ExportFormatVersion byte = 1
+
+// maxExportRecordSize bounds the length prefix of a record, so that a corrupt file can't make the import allocate
+// an arbitrary amount of memory. Records hold a single block with its state update and classes.
+const maxExportRecordSize = 512 * utils.Megabyte
+
+var (
+	exportMagic = []byte("JUNOEXPORT")
+
+	ErrUnsupportedExportVersion = errors.New("unsupported export format version")
+	ErrExportNetworkMismatch    = errors.New("export file was created for a different network")
+)
+
+type ExportHeader struct {
+	Network string
+	From    uint64
+	To      uint64
+}
+
+type ExportedBlock struct {
+	Block       *core.Block
+	StateUpdate *core.StateUpdate
+	NewClasses  map[felt.Felt]core.Class
+}
+
+// Export writes the blocks in [from, to], together with their state updates and declared classes, to w.
+func (b *Blockchain) Export(w io.Writer, from, to uint64) error {
+	if from > to {
+		return fmt.Errorf("invalid block range [%d, %d]", from, to)
+	}
+	// The file is stamped with b's network, so it has to be the network of the blocks.
+	if err := b.VerifyNetwork(); err != nil {
+		return err
+	}
+
+	if _, err := w.Write(exportMagic); err != nil {
+		return err
+	}
+	if _, err := w.Write([]byte{ExportFormatVersion}); err != nil {
+		return err
+	}
+	zw := gzip.NewWriter(w)
+	if err := writeExportRecord(zw, &ExportHeader{Network: b.network.Name, From: from, To: to}); err != nil {
+		return err
+	}
+
+	err := b.database.View(func(txn db.Transaction) error {
+		for number := from; number <= to; number++ {
+			exported, err := exportedBlock(txn, number)
+			if err != nil {
+				return fmt.Errorf("export block %d: %w", number, err)
+			}
+			if err = writeExportRecord(zw, exported); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+func exportedBlock(txn db.Transaction, number uint64) (*ExportedBlock, error) {
+	block, err := BlockByNumber(txn, number)
+	if err != nil {
+		return nil, err
+	}
+	stateUpdate, err := stateUpdateByNumber(txn, number)
+	if err != nil {
+		return nil, err
+	}
+
+	// The classes that were first seen in this block, which is what the synchroniser passes to Store.
+	state := core.NewState(txn)
+	newClasses := make(map[felt.Felt]core.Class)
+	addIfNew := func(classHash *felt.Felt) error {
+		declaredClass, err := state.Class(classHash)
+		if err != nil {
+			return err
+		}
+		if declaredClass.At == number {
+			newClasses[*classHash] = declaredClass.Class
+		}
+		return nil
+	}
+
+	for _, classHash := range stateUpdate.StateDiff.DeployedContracts {
+		if err = addIfNew(classHash); err != nil {
+			return nil, err
+		}
+	}
+	for _, classHash := range stateUpdate.StateDiff.DeclaredV0Classes {
+		if err = addIfNew(classHash); err != nil {
+			return nil, err
+		}
+	}
+	for classHash := range stateUpdate.StateDiff.DeclaredV1Classes {
+		if err = addIfNew(&classHash); err != nil {
+			return nil, err
+		}
+	}
+
+	return &ExportedBlock{
+		Block:       block,
+		StateUpdate: stateUpdate,
+		NewClasses:  newClasses,
+	}, nil
+}
+
+// Import reads a file written by [Blockchain.Export] and stores its blocks on top of the current head, running
+// the same checks as blocks synced from the network. It returns the number of the last block stored.
+func (b *Blockchain) Import(r io.Reader) (uint64, error) {
+	prefix := make([]byte, len(exportMagic)+1)
+	if _, err := io.ReadFull(r, prefix); err != nil {
+		return 0, fmt.Errorf("read export file prefix: %w", err)
+	}
+	if !bytes.Equal(prefix[:len(exportMagic)], exportMagic) {
+		return 0, errors.New("not a juno export file")
+	}
+	if version := prefix[len(exportMagic)]; version != ExportFormatVersion {
+		return 0, fmt.Errorf("%w: %d", ErrUnsupportedExportVersion, version)
+	}
+
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return 0, err
+	}
+	defer zr.Close()
+	br := bufio.NewReader(zr)
+
+	var header ExportHeader
+	if err = readExportRecord(br, &header); err != nil {
+		return 0, fmt.Errorf("read export header: %w", err)
+	}
+	if header.Network != b.network.Name {
+		return 0, fmt.Errorf("%w: file has %q, node is on %q", ErrExportNetworkMismatch, header.Network, b.network.Name)
+	}
+
+	var last uint64
+	for number := header.From; number <= header.To; number++ {
+		var exported ExportedBlock
+		if err = readExportRecord(br, &exported); err != nil {
+			return last, fmt.Errorf("read block %d: %w", number, err)
+		}
+		if exported.Block.Number != number {
+			return last, fmt.Errorf("expected block %d, got %d", number, exported.Block.Number)
+		}
+
+		// Blocks the node already has are skipped, so that an interrupted import can be run again.
+		if stored, err := b.BlockHeaderByNumber(number); err == nil {
+			if !stored.Hash.Equal(exported.Block.Hash) {
+				return last, fmt.Errorf("block %d in the export file does not match the stored block", number)
+			}
+			last = number
+			continue
+		} else if !errors.Is(err, db.ErrKeyNotFound) {
+			return last, err
+		}
+
+		commitments, err := b.SanityCheckNewHeight(exported.Block, exported.StateUpdate, exported.NewClasses)
+		if err != nil {
+			return last, fmt.Errorf("verify block %d: %w", number, err)
+		}
+		if err = b.Store(exported.Block, commitments, exported.StateUpdate, exported.NewClasses); err != nil {
+			return last, fmt.Errorf("store block %d: %w", number, err)
+		}
+		last = number
+	}
+	return last, nil
+}
+
+func writeExportRecord(w io.Writer, v any) error {
+	record, err := encoder.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if _, err = w.Write(binary.AppendUvarint(nil, uint64(len(record)))); err != nil {
+		return err
+	}
+	_, err = w.Write(record)
+	return err
+}
+
+func readExportRecord(r *bufio.Reader, v any) error {
+	length, err := binary.ReadUvarint(r)
+	if err != nil {
+		return err
+	}
+	if length > maxExportRecordSize {
+		return fmt.Errorf("export record of %d bytes exceeds the maximum of %d bytes", length, maxExportRecordSize)
+	}
+	record := make([]byte, length)
+	if _, err = io.ReadFull(r, record); err != nil {
+		return err
+	}
+	return encoder.Unmarshal(record, v)
+}
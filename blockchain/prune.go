@@ -0,0 +1,151 @@
+package blockchain
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/service"
+	"github.com/NethermindEth/juno/utils"
+)
+
+// ErrHistoryPruned is returned when reading the state of, or reverting, a block whose state history was pruned.
+var ErrHistoryPruned = errors.New("historical state pruned")
+
+const (
+	// pruneBatchSize is the number of logs scanned per transaction, so that pruning doesn't hold large batches.
+	pruneBatchSize = 100_000
+
+	defaultPruneInterval = 10 * time.Minute
+)
+
+var historyBuckets = []db.Bucket{db.ContractStorageHistory, db.ContractNonceHistory, db.ContractClassHashHistory}
+
+// stateHistoryStart returns the number of the oldest block whose state can be read, which is 0 unless state
+// history was pruned.
+func stateHistoryStart(txn db.Transaction) (uint64, error) {
+	var start uint64
+	err := txn.Get(db.StateHistoryStart.Key(), func(val []byte) error {
+		start = binary.BigEndian.Uint64(val)
+		return nil
+	})
+	if errors.Is(err, db.ErrKeyNotFound) {
+		return 0, nil
+	}
+	return start, err
+}
+
+// checkStateHistory returns ErrHistoryPruned if the state history of the block was pruned.
+func checkStateHistory(txn db.Transaction, blockNumber uint64) error {
+	start, err := stateHistoryStart(txn)
+	if err != nil {
+		return err
+	}
+	if blockNumber < start {
+		return ErrHistoryPruned
+	}
+	return nil
+}
+
+// PruneHistory deletes the state history that is only needed to read the state of the blocks before the last
+// keep blocks. Reading the state of those blocks, or reverting them, fails with ErrHistoryPruned afterwards. It
+// returns the number of the oldest block whose state can still be read.
+func (b *Blockchain) PruneHistory(ctx context.Context, keep uint64) (uint64, error) {
+	if keep == 0 {
+		return 0, errors.New("at least one block of state history must be kept")
+	}
+
+	// The start of the history is moved before the logs are deleted, so that readers never see partial history.
+	// It never moves back, so that an interrupted run is completed by the next one.
+	var start uint64
+	err := b.database.Update(func(txn db.Transaction) error {
+		height, err := ChainHeight(txn)
+		if err != nil {
+			return err
+		}
+		if start, err = stateHistoryStart(txn); err != nil {
+			return err
+		}
+		if height < keep || height+1-keep <= start {
+			return nil
+		}
+		start = height + 1 - keep
+		return txn.Set(db.StateHistoryStart.Key(), core.MarshalBlockNumber(start))
+	})
+	if err != nil || start == 0 {
+		return start, err
+	}
+
+	for _, bucket := range historyBuckets {
+		var from []byte
+		for {
+			if err = ctx.Err(); err != nil {
+				return start, err
+			}
+			err = b.database.Update(func(txn db.Transaction) error {
+				from, err = core.PruneHistoryLogs(txn, bucket, from, start, pruneBatchSize)
+				return err
+			})
+			if err != nil {
+				return start, err
+			}
+			if from == nil {
+				break
+			}
+		}
+	}
+	return start, nil
+}
+
+// HistoryPruner keeps the state history of a blockchain to its last blocks, pruning it periodically.
+type HistoryPruner struct {
+	chain    *Blockchain
+	keep     uint64
+	interval time.Duration
+	log      utils.SimpleLogger
+}
+
+var _ service.Service = (*HistoryPruner)(nil)
+
+func NewHistoryPruner(chain *Blockchain, keep uint64, log utils.SimpleLogger) *HistoryPruner {
+	return &HistoryPruner{
+		chain:    chain,
+		keep:     keep,
+		interval: defaultPruneInterval,
+		log:      log,
+	}
+}
+
+// WithInterval sets how often the state history is pruned.
+func (p *HistoryPruner) WithInterval(interval time.Duration) *HistoryPruner {
+	p.interval = interval
+	return p
+}
+
+func (p *HistoryPruner) Run(ctx context.Context) error {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		p.prune(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *HistoryPruner) prune(ctx context.Context) {
+	start, err := p.chain.PruneHistory(ctx, p.keep)
+	switch {
+	case err == nil:
+		p.log.Debugw("Pruned state history", "start", start)
+	case errors.Is(err, db.ErrKeyNotFound), errors.Is(err, context.Canceled):
+		// There are no blocks yet, or the node is shutting down.
+	default:
+		p.log.Errorw("Failed to prune state history", "err", err)
+	}
+}
@@ -0,0 +1,120 @@
+package blockchain
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db"
+)
+
+// ErrNotEmpty is returned when applying a state snapshot to a blockchain that already has blocks.
+var ErrNotEmpty = errors.New("blockchain is not empty")
+
+// snapshotBuckets hold the state written by [Blockchain.ApplySnapshot].
+var snapshotBuckets = []db.Bucket{
+	db.StateTrie, db.ContractClassHash, db.ContractStorage, db.Class, db.ContractNonce, db.ClassesTrie,
+	db.ContractDeploymentHeight,
+}
+
+// checkEmpty returns ErrNotEmpty if a block was stored already.
+func checkEmpty(txn db.Transaction) error {
+	_, err := ChainHeight(txn)
+	if err == nil {
+		return ErrNotEmpty
+	} else if !errors.Is(err, db.ErrKeyNotFound) {
+		return err
+	}
+	return nil
+}
+
+// ApplySnapshot applies a part of the state at blockNumber, e.g. downloaded by snap sync, to an empty blockchain.
+// The state commitment is only verified once the whole state is applied, by [Blockchain.StoreSnapshotHead].
+func (b *Blockchain) ApplySnapshot(blockNumber uint64, diff *core.StateDiff, declaredClasses map[felt.Felt]core.Class) error {
+	return b.database.Update(func(txn db.Transaction) error {
+		if err := checkEmpty(txn); err != nil {
+			return err
+		}
+		return core.NewState(txn).Apply(blockNumber, diff, declaredClasses)
+	})
+}
+
+// StoreSnapshotHead stores block as the head of an empty blockchain whose state was applied with
+// [Blockchain.ApplySnapshot]. The state must match the state root of block. The blocks before it aren't stored, and
+// reading the state before it fails with ErrHistoryPruned.
+func (b *Blockchain) StoreSnapshotHead(block *core.Block, blockCommitments *core.BlockCommitments,
+	stateUpdate *core.StateUpdate,
+) error {
+	return b.database.Update(func(txn db.Transaction) error {
+		if err := checkBlockVersion(block.ProtocolVersion); err != nil {
+			return err
+		}
+		if err := checkEmpty(txn); err != nil {
+			return err
+		}
+
+		root, err := core.NewState(txn).Root()
+		if err != nil {
+			return err
+		}
+		if !root.Equal(block.GlobalStateRoot) {
+			return fmt.Errorf("state root %s does not match the root of block #%d: %s", root, block.Number,
+				block.GlobalStateRoot)
+		}
+
+		if err = txn.Set(db.StateHistoryStart.Key(), core.MarshalBlockNumber(block.Number)); err != nil {
+			return err
+		}
+		return b.storeHead(txn, block, blockCommitments, stateUpdate)
+	})
+}
+
+// ResetSnapshot deletes the state applied with [Blockchain.ApplySnapshot] to an empty blockchain, e.g. because it
+// doesn't match the state root of the block it was downloaded for, so that it can be applied again.
+func (b *Blockchain) ResetSnapshot() error {
+	for _, bucket := range snapshotBuckets {
+		for done := false; !done; {
+			err := b.database.Update(func(txn db.Transaction) error {
+				if err := checkEmpty(txn); err != nil {
+					return err
+				}
+				var err error
+				done, err = deleteKeys(txn, bucket.Key(), pruneBatchSize)
+				return err
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// deleteKeys deletes up to limit keys with the given prefix. It returns whether no such keys are left.
+func deleteKeys(txn db.Transaction, prefix []byte, limit int) (bool, error) {
+	it, err := txn.NewIterator()
+	if err != nil {
+		return false, err
+	}
+
+	var keys [][]byte
+	for it.Seek(prefix); it.Valid() && len(keys) < limit; it.Next() {
+		key := it.Key()
+		if !bytes.HasPrefix(key, prefix) {
+			break
+		}
+		keys = append(keys, bytes.Clone(key))
+	}
+	if err = it.Close(); err != nil {
+		return false, err
+	}
+
+	for _, key := range keys {
+		if err = txn.Delete(key); err != nil {
+			return false, err
+		}
+	}
+	return len(keys) < limit, nil
+}
@@ -0,0 +1,77 @@
+package blockchain_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/clients/feeder"
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/db/pebble"
+	adaptfeeder "github.com/NethermindEth/juno/starknetdata/feeder"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshot(t *testing.T) {
+	gw := adaptfeeder.New(feeder.NewTestClient(t, &utils.Mainnet))
+	var (
+		blocks       []*core.Block
+		stateUpdates []*core.StateUpdate
+	)
+	for i := range uint64(3) {
+		stateUpdate, block, err := gw.StateUpdateWithBlock(context.Background(), i)
+		require.NoError(t, err)
+		blocks = append(blocks, block)
+		stateUpdates = append(stateUpdates, stateUpdate)
+	}
+	head, headUpdate := blocks[2], stateUpdates[2]
+
+	t.Run("store head over the applied state", func(t *testing.T) {
+		chain := blockchain.New(pebble.NewMemTest(t), &utils.Mainnet)
+		for i, stateUpdate := range stateUpdates {
+			require.NoError(t, chain.ApplySnapshot(uint64(i), stateUpdate.StateDiff, nil))
+		}
+		require.NoError(t, chain.StoreSnapshotHead(head, &emptyCommitments, headUpdate))
+
+		header, err := chain.HeadsHeader()
+		require.NoError(t, err)
+		assert.Equal(t, head.Header, header)
+		root, err := chain.StateCommitment()
+		require.NoError(t, err)
+		assert.Equal(t, head.GlobalStateRoot, root)
+
+		assert.ErrorIs(t, chain.ApplySnapshot(3, core.EmptyStateDiff(), nil), blockchain.ErrNotEmpty)
+		assert.ErrorIs(t, chain.StoreSnapshotHead(head, &emptyCommitments, headUpdate), blockchain.ErrNotEmpty)
+		assert.ErrorIs(t, chain.RevertHead(), blockchain.ErrHistoryPruned)
+	})
+
+	t.Run("state root mismatch", func(t *testing.T) {
+		chain := blockchain.New(pebble.NewMemTest(t), &utils.Mainnet)
+		// The state diff of block 1 is missing.
+		require.NoError(t, chain.ApplySnapshot(0, stateUpdates[0].StateDiff, nil))
+		require.NoError(t, chain.ApplySnapshot(2, stateUpdates[2].StateDiff, nil))
+		require.ErrorContains(t, chain.StoreSnapshotHead(head, &emptyCommitments, headUpdate), "does not match the root of block #2")
+
+		_, err := chain.HeadsHeader()
+		assert.Error(t, err)
+
+		// The state is downloaded again after it is reset.
+		require.NoError(t, chain.ResetSnapshot())
+		root, err := chain.StateCommitment()
+		require.NoError(t, err)
+		assert.True(t, root.IsZero())
+		for i, stateUpdate := range stateUpdates {
+			require.NoError(t, chain.ApplySnapshot(uint64(i), stateUpdate.StateDiff, nil))
+		}
+		require.NoError(t, chain.StoreSnapshotHead(head, &emptyCommitments, headUpdate))
+	})
+
+	t.Run("not empty", func(t *testing.T) {
+		chain := blockchain.New(pebble.NewMemTest(t), &utils.Mainnet)
+		require.NoError(t, chain.Store(blocks[0], &emptyCommitments, stateUpdates[0], nil))
+		assert.ErrorIs(t, chain.ApplySnapshot(1, stateUpdates[1].StateDiff, nil), blockchain.ErrNotEmpty)
+		assert.ErrorIs(t, chain.ResetSnapshot(), blockchain.ErrNotEmpty)
+	})
+}
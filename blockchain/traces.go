@@ -0,0 +1,34 @@
+package blockchain
+
+import (
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/encoder"
+	"github.com/NethermindEth/juno/vm"
+)
+
+// BlockTraces returns the traces stored for the transactions of the block with the given hash, in the order of
+// the transactions. It returns [db.ErrKeyNotFound] if the block hasn't been traced.
+func (b *Blockchain) BlockTraces(blockHash *felt.Felt) ([]vm.TransactionTrace, error) {
+	var traces []vm.TransactionTrace
+	return traces, b.database.View(func(txn db.Transaction) error {
+		return txn.Get(db.BlockTraces.Key(blockHash.Marshal()), func(val []byte) error {
+			return encoder.Unmarshal(val, &traces)
+		})
+	})
+}
+
+// StoreBlockTraces stores the traces of the transactions of the block with the given hash. They are deleted when
+// the block is reverted, and the block must still be stored, so that traces of a reverted block aren't left behind.
+func (b *Blockchain) StoreBlockTraces(blockHash *felt.Felt, traces []vm.TransactionTrace) error {
+	encoded, err := encoder.Marshal(traces)
+	if err != nil {
+		return err
+	}
+	return b.database.Update(func(txn db.Transaction) error {
+		if _, err := blockHeaderByHash(txn, blockHash); err != nil {
+			return err
+		}
+		return txn.Set(db.BlockTraces.Key(blockHash.Marshal()), encoded)
+	})
+}
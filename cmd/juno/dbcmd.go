@@ -1,15 +1,23 @@
 package main
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
+	"path/filepath"
 
 	"github.com/NethermindEth/juno/blockchain"
 	"github.com/NethermindEth/juno/core"
 	"github.com/NethermindEth/juno/core/felt"
 	"github.com/NethermindEth/juno/db"
 	"github.com/NethermindEth/juno/db/pebble"
+	"github.com/NethermindEth/juno/db/snapshot"
+	"github.com/NethermindEth/juno/jsonrpc"
+	"github.com/NethermindEth/juno/migration"
 	"github.com/NethermindEth/juno/utils"
 	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
@@ -17,6 +25,10 @@ import (
 
 const (
 	dbRevertToBlockF = "to-block"
+	dbExportFromF    = "from"
+	dbExportToF      = "to"
+	dbNodeURLF       = "node-url"
+	dbPruneKeepF     = "keep-blocks"
 )
 
 type DBInfo struct {
@@ -37,7 +49,8 @@ func DBCmd(defaultDBPath string) *cobra.Command {
 	}
 
 	dbCmd.PersistentFlags().String(dbPathF, defaultDBPath, dbPathUsage)
-	dbCmd.AddCommand(DBInfoCmd(), DBSizeCmd(), DBRevertCmd())
+	dbCmd.AddCommand(DBInfoCmd(), DBSizeCmd(), DBRevertCmd(), DBExportCmd(), DBImportCmd(), DBSnapshotCmd(), DBRestoreCmd(),
+		DBPruneCmd())
 	return dbCmd
 }
 
@@ -71,6 +84,84 @@ func DBRevertCmd() *cobra.Command {
 	return cmd
 }
 
+func DBExportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export <file>",
+		Short: "Export a range of blocks to a file",
+		Long: `This subcommand writes the blocks in the given range, together with their state updates and declared classes,
+to a compressed file that can be loaded into another node with the import subcommand.`,
+		Args: cobra.ExactArgs(1),
+		RunE: dbExport,
+	}
+	defaultNetwork := utils.Mainnet
+	cmd.Flags().Var(&defaultNetwork, networkF, networkUsage)
+	cmd.Flags().Uint64(dbExportFromF, 0, "First block to export")
+	cmd.Flags().Uint64(dbExportToF, 0, "Last block to export (defaults to the head)")
+
+	return cmd
+}
+
+func DBImportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: "Import blocks from an export file",
+		Long: `This subcommand stores the blocks of a file written by the export subcommand on top of the current head,
+verifying them like blocks synced from the network.`,
+		Args: cobra.ExactArgs(1),
+		RunE: dbImport,
+	}
+	defaultNetwork := utils.Mainnet
+	cmd.Flags().Var(&defaultNetwork, networkF, networkUsage)
+
+	return cmd
+}
+
+func DBSnapshotCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot <dir>",
+		Short: "Create a consistent snapshot of the database",
+		Long: `This subcommand writes a point-in-time copy of the database, together with a manifest describing it, to the
+given directory. With --node-url the snapshot is taken by a running node, which must have been started with
+--db-snapshot-dir and is reached on its --db-snapshot-host and --db-snapshot-port, otherwise the database at
+--db-path is opened directly.`,
+		Args: cobra.ExactArgs(1),
+		RunE: dbSnapshot,
+	}
+	defaultNetwork := utils.Mainnet
+	cmd.Flags().Var(&defaultNetwork, networkF, networkUsage)
+	cmd.Flags().String(dbNodeURLF, "", "Snapshot endpoint of a running node to take the snapshot from")
+
+	return cmd
+}
+
+func DBRestoreCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restore <dir>",
+		Short: "Restore the database from a snapshot",
+		Long: `This subcommand copies the database of a snapshot created with the snapshot subcommand to --db-path,
+which must be empty. Snapshots created for a different network than --network are refused.`,
+		Args: cobra.ExactArgs(1),
+		RunE: dbRestore,
+	}
+	defaultNetwork := utils.Mainnet
+	cmd.Flags().Var(&defaultNetwork, networkF, networkUsage)
+
+	return cmd
+}
+
+func DBPruneCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Prune old state history",
+		Long: `This subcommand deletes the state history of all but the last --keep-blocks blocks. The state of older
+blocks can't be read afterwards, and they can't be reverted.`,
+		RunE: dbPrune,
+	}
+	cmd.Flags().Uint64(dbPruneKeepF, 0, "Number of most recent blocks whose state history is kept")
+
+	return cmd
+}
+
 func dbInfo(cmd *cobra.Command, args []string) error {
 	dbPath, err := cmd.Flags().GetString(dbPathF)
 	if err != nil {
@@ -166,6 +257,224 @@ func dbRevert(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func dbExport(cmd *cobra.Command, args []string) error {
+	dbPath, err := cmd.Flags().GetString(dbPathF)
+	if err != nil {
+		return err
+	}
+	network, ok := cmd.Flags().Lookup(networkF).Value.(*utils.Network)
+	if !ok {
+		return fmt.Errorf("invalid --%v", networkF)
+	}
+	from, err := cmd.Flags().GetUint64(dbExportFromF)
+	if err != nil {
+		return err
+	}
+	to, err := cmd.Flags().GetUint64(dbExportToF)
+	if err != nil {
+		return err
+	}
+
+	database, err := openDB(dbPath)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	chain := blockchain.New(database, network)
+	if !cmd.Flags().Changed(dbExportToF) {
+		if to, err = chain.Height(); err != nil {
+			return fmt.Errorf("failed to get the latest block information: %v", err)
+		}
+	}
+
+	file, err := os.Create(args[0])
+	if err != nil {
+		return err
+	}
+	if err = exportToFile(chain, file, from, to); err != nil {
+		// a partial export can't be imported, don't leave it behind
+		return errors.Join(err, os.Remove(args[0]))
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Exported blocks %d to %d to %s\n", from, to, args[0])
+	return nil
+}
+
+// exportToFile exports the blocks in [from, to] to file and closes it.
+func exportToFile(chain *blockchain.Blockchain, file *os.File, from, to uint64) error {
+	if err := chain.Export(file, from, to); err != nil {
+		return errors.Join(err, file.Close())
+	}
+	if err := file.Sync(); err != nil {
+		return errors.Join(err, file.Close())
+	}
+	return file.Close()
+}
+
+func dbImport(cmd *cobra.Command, args []string) error {
+	dbPath, err := cmd.Flags().GetString(dbPathF)
+	if err != nil {
+		return err
+	}
+	network, ok := cmd.Flags().Lookup(networkF).Value.(*utils.Network)
+	if !ok {
+		return fmt.Errorf("invalid --%v", networkF)
+	}
+
+	file, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	database, err := pebble.New(dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to open db: %w", err)
+	}
+	defer database.Close()
+
+	// A fresh database has to carry the schema version of the blocks it's given, or the node would migrate them
+	// again on start. An existing one is brought up to date before blocks in the current format are added to it.
+	if err = migration.MigrateIfNeeded(cmd.Context(), database, network, utils.NewNopZapLogger()); err != nil {
+		return fmt.Errorf("failed to migrate db: %w", err)
+	}
+
+	last, err := blockchain.New(database, network).Import(file)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Imported blocks up to %d\n", last)
+	return nil
+}
+
+func dbSnapshot(cmd *cobra.Command, args []string) error {
+	dbPath, err := cmd.Flags().GetString(dbPathF)
+	if err != nil {
+		return err
+	}
+	network, ok := cmd.Flags().Lookup(networkF).Value.(*utils.Network)
+	if !ok {
+		return fmt.Errorf("invalid --%v", networkF)
+	}
+	nodeURL, err := cmd.Flags().GetString(dbNodeURLF)
+	if err != nil {
+		return err
+	}
+
+	var manifest *snapshot.Manifest
+	if nodeURL != "" {
+		dir, err := filepath.Abs(args[0])
+		if err != nil {
+			return err
+		}
+		if manifest, err = createSnapshotOnNode(cmd.Context(), nodeURL, dir, network); err != nil {
+			return err
+		}
+	} else {
+		database, err := openDB(dbPath)
+		if err != nil {
+			return err
+		}
+		defer database.Close()
+
+		if manifest, err = snapshot.Create(database.(*pebble.DB), network, args[0]); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Created snapshot of block %d (%s) in %s\n", manifest.HeadNumber, manifest.HeadHash, args[0])
+	return nil
+}
+
+// createSnapshotOnNode asks the node at nodeURL to write a snapshot of its database to dir. The node refuses to
+// write anything if it isn't on network.
+func createSnapshotOnNode(ctx context.Context, nodeURL, dir string, network *utils.Network) (*snapshot.Manifest, error) {
+	body, err := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "juno_createSnapshot",
+		"params":  map[string]string{"dir": dir, "network": network.Name},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, nodeURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request snapshot from node: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var response struct {
+		Result *snapshot.Manifest `json:"result"`
+		Error  *jsonrpc.Error     `json:"error"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("decode node response: %w", err)
+	}
+	if response.Error != nil {
+		return nil, fmt.Errorf("node failed to create snapshot: %s: %v", response.Error.Message, response.Error.Data)
+	}
+	if response.Result == nil {
+		return nil, errors.New("node returned an empty response")
+	}
+	return response.Result, nil
+}
+
+func dbRestore(cmd *cobra.Command, args []string) error {
+	dbPath, err := cmd.Flags().GetString(dbPathF)
+	if err != nil {
+		return err
+	}
+	network, ok := cmd.Flags().Lookup(networkF).Value.(*utils.Network)
+	if !ok {
+		return fmt.Errorf("invalid --%v", networkF)
+	}
+
+	manifest, err := snapshot.Restore(args[0], dbPath, network)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Restored snapshot of block %d (%s) to %s\n", manifest.HeadNumber, manifest.HeadHash, dbPath)
+	return nil
+}
+
+func dbPrune(cmd *cobra.Command, args []string) error {
+	dbPath, err := cmd.Flags().GetString(dbPathF)
+	if err != nil {
+		return err
+	}
+	keep, err := cmd.Flags().GetUint64(dbPruneKeepF)
+	if err != nil {
+		return err
+	}
+	if keep == 0 {
+		return fmt.Errorf("--%v cannot be 0", dbPruneKeepF)
+	}
+
+	database, err := openDB(dbPath)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	start, err := blockchain.New(database, nil).PruneHistory(cmd.Context(), keep)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Pruned state history before block %d\n", start)
+	return nil
+}
+
 func dbSize(cmd *cobra.Command, args []string) error {
 	dbPath, err := cmd.Flags().GetString(dbPathF)
 	if err != nil {
@@ -1,7 +1,12 @@
 package main_test
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/binary"
+	"os"
+	"path/filepath"
 	"strconv"
 	"testing"
 
@@ -9,7 +14,10 @@ import (
 	"github.com/NethermindEth/juno/clients/feeder"
 	juno "github.com/NethermindEth/juno/cmd/juno"
 	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
 	"github.com/NethermindEth/juno/db/pebble"
+	"github.com/NethermindEth/juno/db/snapshot"
+	"github.com/NethermindEth/juno/migration"
 	adaptfeeder "github.com/NethermindEth/juno/starknetdata/feeder"
 	"github.com/NethermindEth/juno/utils"
 	"github.com/spf13/cobra"
@@ -61,6 +69,217 @@ func TestDBCmd(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, revertToBlock, block.Number)
 	})
+
+	t.Run("export and import blocks", func(t *testing.T) {
+		network := utils.Mainnet
+		const syncToBlock = uint64(2)
+
+		srcPath := t.TempDir()
+		srcDB, err := pebble.New(srcPath)
+		require.NoError(t, err)
+		gw := adaptfeeder.New(feeder.NewTestClient(t, &network))
+		srcChain := blockchain.New(srcDB, &network)
+		for blockNumber := uint64(0); blockNumber <= syncToBlock; blockNumber++ {
+			block, err := gw.BlockByNumber(context.Background(), blockNumber)
+			require.NoError(t, err)
+			stateUpdate, err := gw.StateUpdate(context.Background(), blockNumber)
+			require.NoError(t, err)
+
+			newClasses := make(map[felt.Felt]core.Class)
+			for _, classHash := range stateUpdate.StateDiff.DeployedContracts {
+				newClasses[*classHash], err = gw.Class(context.Background(), classHash)
+				require.NoError(t, err)
+			}
+			require.NoError(t, srcChain.Store(block, &emptyCommitments, stateUpdate, newClasses))
+		}
+		require.NoError(t, srcDB.Close())
+
+		exportFile := filepath.Join(t.TempDir(), "blocks.export")
+		exportCmd := juno.DBExportCmd()
+		exportCmd.Flags().String("db-path", "", "")
+		require.NoError(t, exportCmd.Flags().Set("db-path", srcPath))
+		require.NoError(t, exportCmd.Flags().Set("network", network.Name))
+		exportCmd.SetArgs([]string{exportFile})
+		require.NoError(t, exportCmd.Execute())
+
+		t.Run("failed export leaves no file behind", func(t *testing.T) {
+			failedFile := filepath.Join(t.TempDir(), "failed.export")
+			failedCmd := juno.DBExportCmd()
+			failedCmd.Flags().String("db-path", "", "")
+			require.NoError(t, failedCmd.Flags().Set("db-path", srcPath))
+			require.NoError(t, failedCmd.Flags().Set("network", network.Name))
+			require.NoError(t, failedCmd.Flags().Set("to", "5"))
+			failedCmd.SetArgs([]string{failedFile})
+			require.Error(t, failedCmd.Execute())
+			assert.NoFileExists(t, failedFile)
+		})
+
+		t.Run("database of a different network", func(t *testing.T) {
+			failedFile := filepath.Join(t.TempDir(), "failed.export")
+			failedCmd := juno.DBExportCmd()
+			failedCmd.Flags().String("db-path", "", "")
+			require.NoError(t, failedCmd.Flags().Set("db-path", srcPath))
+			require.NoError(t, failedCmd.Flags().Set("network", utils.Sepolia.Name))
+			failedCmd.SetArgs([]string{failedFile})
+			require.ErrorIs(t, failedCmd.Execute(), blockchain.ErrNetworkMismatch)
+			assert.NoFileExists(t, failedFile)
+		})
+
+		t.Run("oversized record", func(t *testing.T) {
+			var compressed bytes.Buffer
+			zw := gzip.NewWriter(&compressed)
+			_, err := zw.Write(binary.AppendUvarint(nil, 1<<40))
+			require.NoError(t, err)
+			require.NoError(t, zw.Close())
+
+			corruptFile := filepath.Join(t.TempDir(), "corrupt.export")
+			content := append([]byte("JUNOEXPORT"), blockchain.ExportFormatVersion)
+			require.NoError(t, os.WriteFile(corruptFile, append(content, compressed.Bytes()...), 0o600))
+
+			importCmd := juno.DBImportCmd()
+			importCmd.Flags().String("db-path", "", "")
+			require.NoError(t, importCmd.Flags().Set("db-path", t.TempDir()))
+			require.NoError(t, importCmd.Flags().Set("network", network.Name))
+			importCmd.SetArgs([]string{corruptFile})
+			require.ErrorContains(t, importCmd.Execute(), "exceeds the maximum")
+		})
+
+		t.Run("network mismatch", func(t *testing.T) {
+			importCmd := juno.DBImportCmd()
+			importCmd.Flags().String("db-path", "", "")
+			require.NoError(t, importCmd.Flags().Set("db-path", t.TempDir()))
+			require.NoError(t, importCmd.Flags().Set("network", utils.Sepolia.Name))
+			importCmd.SetArgs([]string{exportFile})
+			require.ErrorIs(t, importCmd.Execute(), blockchain.ErrExportNetworkMismatch)
+		})
+
+		dstPath := t.TempDir()
+		importCmd := juno.DBImportCmd()
+		importCmd.Flags().String("db-path", "", "")
+		require.NoError(t, importCmd.Flags().Set("db-path", dstPath))
+		require.NoError(t, importCmd.Flags().Set("network", network.Name))
+		importCmd.SetArgs([]string{exportFile})
+		require.NoError(t, importCmd.Execute())
+
+		dstDB, err := pebble.New(dstPath)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			require.NoError(t, dstDB.Close())
+		})
+
+		head, err := blockchain.New(dstDB, &network).Head()
+		require.NoError(t, err)
+		want, err := gw.BlockByNumber(context.Background(), syncToBlock)
+		require.NoError(t, err)
+		assert.Equal(t, want.Hash, head.Hash)
+
+		// the imported database is up to date, the node doesn't migrate it on start
+		metadata, err := migration.SchemaMetadata(dstDB)
+		require.NoError(t, err)
+		assert.NotZero(t, metadata.Version)
+	})
+
+	t.Run("prune history", func(t *testing.T) {
+		network := utils.Mainnet
+		const syncToBlock = uint64(2)
+
+		cmd := juno.DBPruneCmd()
+		cmd.Flags().String("db-path", "", "")
+
+		dbPath := prepareDB(t, &network, syncToBlock)
+		require.NoError(t, cmd.Flags().Set("db-path", dbPath))
+		require.Error(t, cmd.Execute())
+
+		require.NoError(t, cmd.Flags().Set("keep-blocks", "1"))
+		require.NoError(t, cmd.Execute())
+
+		db, err := pebble.New(dbPath)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			require.NoError(t, db.Close())
+		})
+
+		chain := blockchain.New(db, &network)
+		_, _, err = chain.StateAtBlockNumber(syncToBlock - 1)
+		require.ErrorIs(t, err, blockchain.ErrHistoryPruned)
+		_, closer, err := chain.StateAtBlockNumber(syncToBlock)
+		require.NoError(t, err)
+		require.NoError(t, closer())
+
+		// Only the blocks whose state history is kept can be reverted.
+		require.NoError(t, chain.RevertHead())
+		require.ErrorIs(t, chain.RevertHead(), blockchain.ErrHistoryPruned)
+	})
+
+	t.Run("snapshot and restore", func(t *testing.T) {
+		network := utils.Mainnet
+		const syncToBlock = uint64(2)
+		srcPath := prepareDB(t, &network, syncToBlock)
+
+		snapshotDir := filepath.Join(t.TempDir(), "snapshot")
+		snapshotCmd := juno.DBSnapshotCmd()
+		snapshotCmd.Flags().String("db-path", "", "")
+		require.NoError(t, snapshotCmd.Flags().Set("db-path", srcPath))
+		require.NoError(t, snapshotCmd.Flags().Set("network", network.Name))
+		snapshotCmd.SetArgs([]string{snapshotDir})
+		require.NoError(t, snapshotCmd.Execute())
+
+		manifest, err := snapshot.ReadManifest(snapshotDir)
+		require.NoError(t, err)
+		want, err := adaptfeeder.New(feeder.NewTestClient(t, &network)).BlockByNumber(context.Background(), syncToBlock)
+		require.NoError(t, err)
+		assert.Equal(t, network.Name, manifest.Network)
+		assert.Equal(t, syncToBlock, manifest.HeadNumber)
+		assert.Equal(t, want.Hash, manifest.HeadHash)
+		assert.Equal(t, want.GlobalStateRoot, manifest.StateRoot)
+
+		t.Run("database of a different network", func(t *testing.T) {
+			failedDir := filepath.Join(t.TempDir(), "failed")
+			failedCmd := juno.DBSnapshotCmd()
+			failedCmd.Flags().String("db-path", "", "")
+			require.NoError(t, failedCmd.Flags().Set("db-path", srcPath))
+			require.NoError(t, failedCmd.Flags().Set("network", utils.Sepolia.Name))
+			failedCmd.SetArgs([]string{failedDir})
+			require.ErrorIs(t, failedCmd.Execute(), blockchain.ErrNetworkMismatch)
+			assert.NoDirExists(t, failedDir)
+		})
+
+		t.Run("network mismatch", func(t *testing.T) {
+			restoreCmd := juno.DBRestoreCmd()
+			restoreCmd.Flags().String("db-path", "", "")
+			require.NoError(t, restoreCmd.Flags().Set("db-path", t.TempDir()))
+			require.NoError(t, restoreCmd.Flags().Set("network", utils.Sepolia.Name))
+			restoreCmd.SetArgs([]string{snapshotDir})
+			require.ErrorIs(t, restoreCmd.Execute(), snapshot.ErrNetworkMismatch)
+		})
+
+		t.Run("database path is not empty", func(t *testing.T) {
+			restoreCmd := juno.DBRestoreCmd()
+			restoreCmd.Flags().String("db-path", "", "")
+			require.NoError(t, restoreCmd.Flags().Set("db-path", srcPath))
+			require.NoError(t, restoreCmd.Flags().Set("network", network.Name))
+			restoreCmd.SetArgs([]string{snapshotDir})
+			require.Error(t, restoreCmd.Execute())
+		})
+
+		dstPath := filepath.Join(t.TempDir(), "restored")
+		restoreCmd := juno.DBRestoreCmd()
+		restoreCmd.Flags().String("db-path", "", "")
+		require.NoError(t, restoreCmd.Flags().Set("db-path", dstPath))
+		require.NoError(t, restoreCmd.Flags().Set("network", network.Name))
+		restoreCmd.SetArgs([]string{snapshotDir})
+		require.NoError(t, restoreCmd.Execute())
+
+		dstDB, err := pebble.New(dstPath)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			require.NoError(t, dstDB.Close())
+		})
+
+		head, err := blockchain.New(dstDB, &network).Head()
+		require.NoError(t, err)
+		assert.Equal(t, want.Hash, head.Hash)
+	})
 }
 
 func executeCmdInDB(t *testing.T, cmd *cobra.Command) {
@@ -13,6 +13,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/NethermindEth/juno/core/felt"
 	_ "github.com/NethermindEth/juno/jemalloc"
 	"github.com/NethermindEth/juno/node"
 	"github.com/NethermindEth/juno/utils"
@@ -48,6 +49,7 @@ const (
 	networkF                = "network"
 	ethNodeF                = "eth-node"
 	disableL1VerificationF  = "disable-l1-verification"
+	verifyBlockSignaturesF  = "verify-block-signatures"
 	pprofF                  = "pprof"
 	pprofHostF              = "pprof-host"
 	pprofPortF              = "pprof-port"
@@ -59,6 +61,7 @@ const (
 	p2pPeersF               = "p2p-peers"
 	p2pFeederNodeF          = "p2p-feeder-node"
 	p2pPrivateKey           = "p2p-private-key"
+	p2pSnapSyncF            = "p2p-snap-sync"
 	metricsF                = "metrics"
 	metricsHostF            = "metrics-host"
 	metricsPortF            = "metrics-port"
@@ -69,6 +72,7 @@ const (
 	maxVMQueueF             = "max-vm-queue"
 	remoteDBF               = "remote-db"
 	rpcMaxBlockScanF        = "rpc-max-block-scan"
+	rpcMaxTracedBlocksF     = "rpc-max-traced-blocks"
 	dbCacheSizeF            = "db-cache-size"
 	dbMaxHandlesF           = "db-max-handles"
 	gwAPIKeyF               = "gw-api-key" //nolint: gosec
@@ -80,10 +84,23 @@ const (
 	cnL2ChainIDF            = "cn-l2-chain-id"
 	cnCoreContractAddressF  = "cn-core-contract-address"
 	cnUnverifiableRangeF    = "cn-unverifiable-range"
+	cnSequencerPublicKeyF   = "cn-sequencer-public-key"
 	callMaxStepsF           = "rpc-call-max-steps"
 	corsEnableF             = "rpc-cors-enable"
 	versionedConstantsFileF = "versioned-constants-file"
 	pluginPathF             = "plugin-path"
+	mempoolF                = "mempool"
+	dbSnapshotDirF          = "db-snapshot-dir"
+	dbSnapshotHostF         = "db-snapshot-host"
+	dbSnapshotPortF         = "db-snapshot-port"
+	traceStoreF             = "trace-store"
+	traceStoreBackfillFromF = "trace-store-backfill-from"
+	traceStoreBackfillToF   = "trace-store-backfill-to"
+	pruneHistoryBlocksF     = "prune-history-blocks"
+	rpcAPIKeysF             = "rpc-api-keys"
+	rpcRateLimitF           = "rpc-rate-limit"
+	rpcExecutionRateLimitF  = "rpc-execution-rate-limit"
+	rpcResponseCacheSizeF   = "rpc-response-cache-size"
 
 	defaultConfig                   = ""
 	defaulHost                      = "localhost"
@@ -93,6 +110,7 @@ const (
 	defaultWSPort                   = 6061
 	defaultEthNode                  = ""
 	defaultDisableL1Verification    = false
+	defaultVerifyBlockSignatures    = false
 	defaultPprof                    = false
 	defaultPprofPort                = 6062
 	defaultColour                   = true
@@ -103,12 +121,14 @@ const (
 	defaultP2pPeers                 = ""
 	defaultP2pFeederNode            = false
 	defaultP2pPrivateKey            = ""
+	defaultP2pSnapSync              = false
 	defaultMetrics                  = false
 	defaultMetricsPort              = 9090
 	defaultGRPC                     = false
 	defaultGRPCPort                 = 6064
 	defaultRemoteDB                 = ""
 	defaultRPCMaxBlockScan          = math.MaxUint
+	defaultRPCMaxTracedBlocks       = 100
 	defaultCacheSizeMb              = 1024
 	defaultMaxHandles               = 1024
 	defaultGwAPIKey                 = ""
@@ -118,11 +138,21 @@ const (
 	defaultCNL1ChainID              = ""
 	defaultCNL2ChainID              = ""
 	defaultCNCoreContractAddressStr = ""
+	defaultCNSequencerPublicKey     = ""
 	defaultCallMaxSteps             = 4_000_000
 	defaultGwTimeout                = 5 * time.Second
 	defaultCorsEnable               = false
 	defaultVersionedConstantsFile   = ""
 	defaultPluginPath               = ""
+	defaultMempool                  = false
+	defaultDBSnapshotDir            = ""
+	defaultDBSnapshotPort           = 6065
+	defaultTraceStore               = false
+	defaultTraceStoreBackfill       = 0
+	defaultPruneHistoryBlocks       = 0
+	defaultRPCAPIKeys               = ""
+	defaultRPCRateLimit             = 0
+	defaultRPCResponseCacheSizeMb   = 64
 
 	configFlagUsage                       = "The YAML configuration file."
 	logLevelFlagUsage                     = "Options: trace, debug, info, warn, error."
@@ -141,6 +171,7 @@ const (
 	networkCustomL2ChainIDUsage           = "Custom network L2 chain id."
 	networkCustomCoreContractAddressUsage = "Custom network core contract address."
 	networkCustomUnverifiableRange        = "Custom network range of blocks to skip hash verifications (e.g. `0,100`)."
+	networkCustomSequencerPublicKeyUsage  = "Custom network public key of the sequencer, used to verify block signatures."
 	pprofUsage                            = "Enables the pprof endpoint on the default port."
 	pprofHostUsage                        = "The interface on which the pprof HTTP server will listen for requests."
 	pprofPortUsage                        = "The port on which the pprof HTTP server will listen for requests."
@@ -148,6 +179,7 @@ const (
 	ethNodeUsage                          = "WebSocket endpoint of the Ethereum node. To verify the correctness of the L2 chain, " +
 		"Juno must connect to an Ethereum node and parse events in the Starknet contract."
 	disableL1VerificationUsage = "Disables L1 verification since an Ethereum node is not provided."
+	verifyBlockSignaturesUsage = "Rejects blocks not signed by the sequencer, whose key is fetched from the feeder unless set by the network."
 	pendingPollIntervalUsage   = "Sets how frequently pending block will be updated (0s will disable fetching of pending block)."
 	p2pUsage                   = "EXPERIMENTAL: Enables p2p server."
 	p2pAddrUsage               = "EXPERIMENTAL: Specify p2p listening source address as multiaddr.  Example: /ip4/0.0.0.0/tcp/7777"
@@ -156,7 +188,9 @@ const (
 		"These peers can be either Feeder or regular nodes."
 	p2pFeederNodeUsage = "EXPERIMENTAL: Run juno as a feeder node which will only sync from feeder gateway and gossip the new" +
 		" blocks to the network."
-	p2pPrivateKeyUsage   = "EXPERIMENTAL: Hexadecimal representation of a private key on the Ed25519 elliptic curve."
+	p2pPrivateKeyUsage = "EXPERIMENTAL: Hexadecimal representation of a private key on the Ed25519 elliptic curve."
+	p2pSnapSyncUsage   = "EXPERIMENTAL: Download the state of a recent block from p2p peers when the database is empty, " +
+		"instead of syncing every block from genesis."
 	metricsUsage         = "Enables the Prometheus metrics endpoint on the default port."
 	metricsHostUsage     = "The interface on which the Prometheus endpoint will listen for requests."
 	metricsPortUsage     = "The port on which the Prometheus endpoint will listen for requests."
@@ -165,7 +199,7 @@ const (
 	grpcPortUsage        = "The port on which the gRPC server will listen for requests."
 	maxVMsUsage          = "Maximum number for VM instances to be used for RPC calls concurrently"
 	maxVMQueueUsage      = "Maximum number for requests to queue after reaching max-vms before starting to reject incoming requests"
-	remoteDBUsage        = "gRPC URL of a remote Juno node"
+	remoteDBUsage        = "gRPC URL of a remote Juno node, whose database is read and whose head is followed"
 	rpcMaxBlockScanUsage = "Maximum number of blocks scanned in single starknet_getEvents call"
 	dbCacheSizeUsage     = "Determines the amount of memory (in megabytes) allocated for caching data in the database."
 	dbMaxHandlesUsage    = "A soft limit on the number of open files that can be used by the DB"
@@ -176,6 +210,26 @@ const (
 	corsEnableUsage             = "Enable CORS on RPC endpoints"
 	versionedConstantsFileUsage = "Use custom versioned constants from provided file"
 	pluginPathUsage             = "Path to the plugin .so file"
+	mempoolUsage                = "Keeps submitted transactions in a local mempool that validates and relays them to the gateway"
+	dbSnapshotHostUsage         = "The interface on which the juno_createSnapshot RPC method is served."
+	dbSnapshotPortUsage         = "The port on which the juno_createSnapshot RPC method is served."
+	dbSnapshotDirUsage          = "Serves juno_createSnapshot on its own listener, which checkpoints the database into this directory"
+	traceStoreUsage             = "Traces new blocks in the background and stores the traces in the database, " +
+		"so that trace requests survive restarts"
+	traceStoreBackfillFromUsage = "First block of the range of older blocks that the trace store backfills"
+	traceStoreBackfillToUsage   = "Last block of the range of older blocks that the trace store backfills. 0 disables backfilling"
+	rpcMaxTracedBlocksUsage     = "Maximum number of blocks traced in single juno_traceFilter call, stored traces don't count"
+	pruneHistoryBlocksUsage     = "Keeps the state history of only the last N blocks, so that older state can't be read " +
+		"and reorgs deeper than N blocks can't be reverted. 0 keeps all history"
+	rpcAPIKeysUsage = "Comma-separated API keys, one of which JSON-RPC clients must send in the X-Api-Key header " +
+		"or append to the URL path. Empty lets all clients in"
+	rpcRateLimitUsage = "Requests per second that each API key, or each IP address if no API keys are set, " +
+		"can make to the JSON-RPC methods that don't execute or add transactions. 0 disables the limit"
+	rpcExecutionRateLimitUsage = "Requests per second that each API key, or each IP address if no API keys are set, " +
+		"can make to starknet_call, starknet_estimateFee, starknet_estimateMessageFee, the trace and simulate methods " +
+		"and the methods that add transactions. 0 disables the limit"
+	rpcResponseCacheSizeUsage = "Determines the amount of memory (in megabytes) allocated for caching the JSON-RPC " +
+		"responses about blocks verified on L1. 0 disables the cache"
 )
 
 var Version string
@@ -291,6 +345,14 @@ func NewCmd(config *node.Config, run func(*cobra.Command, []string) error) *cobr
 					UnverifiableRange: []uint64{uint64(unverifRange[0]), uint64(unverifRange[1])},
 				},
 			}
+
+			if v.IsSet(cnSequencerPublicKeyF) {
+				publicKey, err := new(felt.Felt).SetString(v.GetString(cnSequencerPublicKeyF))
+				if err != nil {
+					return fmt.Errorf("invalid %s: %w", cnSequencerPublicKeyF, err)
+				}
+				config.Network.SequencerPublicKey = publicKey
+			}
 		}
 
 		return nil
@@ -329,9 +391,11 @@ func NewCmd(config *node.Config, run func(*cobra.Command, []string) error) *cobr
 	junoCmd.Flags().String(cnL2ChainIDF, defaultCNL2ChainID, networkCustomL2ChainIDUsage)
 	junoCmd.Flags().String(cnCoreContractAddressF, defaultCNCoreContractAddressStr, networkCustomCoreContractAddressUsage)
 	junoCmd.Flags().IntSlice(cnUnverifiableRangeF, defaultCNUnverifiableRange, networkCustomUnverifiableRange)
+	junoCmd.Flags().String(cnSequencerPublicKeyF, defaultCNSequencerPublicKey, networkCustomSequencerPublicKeyUsage)
 	junoCmd.Flags().String(ethNodeF, defaultEthNode, ethNodeUsage)
 	junoCmd.Flags().Bool(disableL1VerificationF, defaultDisableL1Verification, disableL1VerificationUsage)
 	junoCmd.MarkFlagsMutuallyExclusive(ethNodeF, disableL1VerificationF)
+	junoCmd.Flags().Bool(verifyBlockSignaturesF, defaultVerifyBlockSignatures, verifyBlockSignaturesUsage)
 	junoCmd.Flags().Bool(pprofF, defaultPprof, pprofUsage)
 	junoCmd.Flags().String(pprofHostF, defaulHost, pprofHostUsage)
 	junoCmd.Flags().Uint16(pprofPortF, defaultPprofPort, pprofPortUsage)
@@ -343,6 +407,7 @@ func NewCmd(config *node.Config, run func(*cobra.Command, []string) error) *cobr
 	junoCmd.Flags().String(p2pPeersF, defaultP2pPeers, p2pPeersUsage)
 	junoCmd.Flags().Bool(p2pFeederNodeF, defaultP2pFeederNode, p2pFeederNodeUsage)
 	junoCmd.Flags().String(p2pPrivateKey, defaultP2pPrivateKey, p2pPrivateKeyUsage)
+	junoCmd.Flags().Bool(p2pSnapSyncF, defaultP2pSnapSync, p2pSnapSyncUsage)
 	junoCmd.Flags().Bool(metricsF, defaultMetrics, metricsUsage)
 	junoCmd.Flags().String(metricsHostF, defaulHost, metricsHostUsage)
 	junoCmd.Flags().Uint16(metricsPortF, defaultMetricsPort, metricsPortUsage)
@@ -353,6 +418,7 @@ func NewCmd(config *node.Config, run func(*cobra.Command, []string) error) *cobr
 	junoCmd.Flags().Uint(maxVMQueueF, 2*uint(defaultMaxVMs), maxVMQueueUsage)
 	junoCmd.Flags().String(remoteDBF, defaultRemoteDB, remoteDBUsage)
 	junoCmd.Flags().Uint(rpcMaxBlockScanF, defaultRPCMaxBlockScan, rpcMaxBlockScanUsage)
+	junoCmd.Flags().Uint(rpcMaxTracedBlocksF, defaultRPCMaxTracedBlocks, rpcMaxTracedBlocksUsage)
 	junoCmd.Flags().Uint(dbCacheSizeF, defaultCacheSizeMb, dbCacheSizeUsage)
 	junoCmd.Flags().String(gwAPIKeyF, defaultGwAPIKey, gwAPIKeyUsage)
 	junoCmd.Flags().Int(dbMaxHandlesF, defaultMaxHandles, dbMaxHandlesUsage)
@@ -364,6 +430,18 @@ func NewCmd(config *node.Config, run func(*cobra.Command, []string) error) *cobr
 	junoCmd.Flags().String(versionedConstantsFileF, defaultVersionedConstantsFile, versionedConstantsFileUsage)
 	junoCmd.MarkFlagsMutuallyExclusive(p2pFeederNodeF, p2pPeersF)
 	junoCmd.Flags().String(pluginPathF, defaultPluginPath, pluginPathUsage)
+	junoCmd.Flags().Bool(mempoolF, defaultMempool, mempoolUsage)
+	junoCmd.Flags().String(dbSnapshotDirF, defaultDBSnapshotDir, dbSnapshotDirUsage)
+	junoCmd.Flags().String(dbSnapshotHostF, defaulHost, dbSnapshotHostUsage)
+	junoCmd.Flags().Uint16(dbSnapshotPortF, defaultDBSnapshotPort, dbSnapshotPortUsage)
+	junoCmd.Flags().Bool(traceStoreF, defaultTraceStore, traceStoreUsage)
+	junoCmd.Flags().Uint64(traceStoreBackfillFromF, defaultTraceStoreBackfill, traceStoreBackfillFromUsage)
+	junoCmd.Flags().Uint64(traceStoreBackfillToF, defaultTraceStoreBackfill, traceStoreBackfillToUsage)
+	junoCmd.Flags().Uint64(pruneHistoryBlocksF, defaultPruneHistoryBlocks, pruneHistoryBlocksUsage)
+	junoCmd.Flags().String(rpcAPIKeysF, defaultRPCAPIKeys, rpcAPIKeysUsage)
+	junoCmd.Flags().Float64(rpcRateLimitF, defaultRPCRateLimit, rpcRateLimitUsage)
+	junoCmd.Flags().Float64(rpcExecutionRateLimitF, defaultRPCRateLimit, rpcExecutionRateLimitUsage)
+	junoCmd.Flags().Uint(rpcResponseCacheSizeF, defaultRPCResponseCacheSizeMb, rpcResponseCacheSizeUsage)
 
 	junoCmd.AddCommand(GenP2PKeyPair(), DBCmd(defaultDBPath))
 
@@ -56,10 +56,13 @@ func TestConfigPrecedence(t *testing.T) {
 	defaultMetricsPort := uint16(9090)
 	defaultGRPC := false
 	defaultGRPCPort := uint16(6064)
+	defaultDBSnapshotPort := uint16(6065)
 	defaultColour := true
 	defaultPendingPollInterval := 5 * time.Second
 	defaultMaxVMs := uint(3 * runtime.GOMAXPROCS(0))
 	defaultRPCMaxBlockScan := uint(math.MaxUint)
+	defaultRPCMaxTracedBlocks := uint(100)
+	defaultRPCResponseCacheSize := uint(64)
 	defaultMaxCacheSize := uint(1024)
 	defaultMaxHandles := 1024
 	defaultCallMaxSteps := uint(4_000_000)
@@ -83,33 +86,37 @@ func TestConfigPrecedence(t *testing.T) {
 				"--cn-core-contract-address", "0xc662c410C0ECf747543f5bA90660f6ABeBD9C8c4",
 			},
 			expectedConfig: &node.Config{
-				LogLevel:            utils.DEBUG,
-				HTTP:                defaultHTTP,
-				HTTPHost:            "0.0.0.0",
-				HTTPPort:            4576,
-				Websocket:           defaultWS,
-				WebsocketHost:       defaultHost,
-				WebsocketPort:       defaultWSPort,
-				GRPC:                defaultGRPC,
-				GRPCHost:            defaultHost,
-				GRPCPort:            defaultGRPCPort,
-				Metrics:             defaultMetrics,
-				MetricsHost:         defaultHost,
-				MetricsPort:         defaultMetricsPort,
-				DatabasePath:        "/home/.juno",
-				Network:             defaultCustomNetwork,
-				Pprof:               true,
-				PprofHost:           defaultHost,
-				PprofPort:           defaultPprofPort,
-				Colour:              defaultColour,
-				PendingPollInterval: defaultPendingPollInterval,
-				MaxVMs:              defaultMaxVMs,
-				MaxVMQueue:          2 * defaultMaxVMs,
-				RPCMaxBlockScan:     defaultRPCMaxBlockScan,
-				DBCacheSize:         defaultMaxCacheSize,
-				DBMaxHandles:        defaultMaxHandles,
-				RPCCallMaxSteps:     defaultCallMaxSteps,
-				GatewayTimeout:      defaultGwTimeout,
+				LogLevel:             utils.DEBUG,
+				HTTP:                 defaultHTTP,
+				HTTPHost:             "0.0.0.0",
+				HTTPPort:             4576,
+				Websocket:            defaultWS,
+				WebsocketHost:        defaultHost,
+				WebsocketPort:        defaultWSPort,
+				GRPC:                 defaultGRPC,
+				GRPCHost:             defaultHost,
+				GRPCPort:             defaultGRPCPort,
+				Metrics:              defaultMetrics,
+				MetricsHost:          defaultHost,
+				MetricsPort:          defaultMetricsPort,
+				DatabasePath:         "/home/.juno",
+				Network:              defaultCustomNetwork,
+				Pprof:                true,
+				PprofHost:            defaultHost,
+				PprofPort:            defaultPprofPort,
+				DBSnapshotHost:       defaultHost,
+				DBSnapshotPort:       defaultDBSnapshotPort,
+				Colour:               defaultColour,
+				PendingPollInterval:  defaultPendingPollInterval,
+				MaxVMs:               defaultMaxVMs,
+				MaxVMQueue:           2 * defaultMaxVMs,
+				RPCMaxBlockScan:      defaultRPCMaxBlockScan,
+				RPCMaxTracedBlocks:   defaultRPCMaxTracedBlocks,
+				RPCResponseCacheSize: defaultRPCResponseCacheSize,
+				DBCacheSize:          defaultMaxCacheSize,
+				DBMaxHandles:         defaultMaxHandles,
+				RPCCallMaxSteps:      defaultCallMaxSteps,
+				GatewayTimeout:       defaultGwTimeout,
 			},
 		},
 		"custom network config file": {
@@ -128,97 +135,109 @@ cn-core-contract-address: 0xc662c410C0ECf747543f5bA90660f6ABeBD9C8c4
 cn-unverifiable-range: [0,10]
 `,
 			expectedConfig: &node.Config{
-				LogLevel:            utils.DEBUG,
-				HTTP:                defaultHTTP,
-				HTTPHost:            "0.0.0.0",
-				HTTPPort:            4576,
-				Websocket:           defaultWS,
-				WebsocketHost:       defaultHost,
-				WebsocketPort:       defaultWSPort,
-				GRPC:                defaultGRPC,
-				GRPCHost:            defaultHost,
-				GRPCPort:            defaultGRPCPort,
-				Metrics:             defaultMetrics,
-				MetricsHost:         defaultHost,
-				MetricsPort:         defaultMetricsPort,
-				DatabasePath:        "/home/.juno",
-				Network:             defaultCustomNetwork,
-				Pprof:               true,
-				PprofHost:           defaultHost,
-				PprofPort:           defaultPprofPort,
-				Colour:              defaultColour,
-				PendingPollInterval: defaultPendingPollInterval,
-				MaxVMs:              defaultMaxVMs,
-				MaxVMQueue:          2 * defaultMaxVMs,
-				RPCMaxBlockScan:     defaultRPCMaxBlockScan,
-				DBCacheSize:         defaultMaxCacheSize,
-				DBMaxHandles:        defaultMaxHandles,
-				RPCCallMaxSteps:     defaultCallMaxSteps,
-				GatewayTimeout:      defaultGwTimeout,
+				LogLevel:             utils.DEBUG,
+				HTTP:                 defaultHTTP,
+				HTTPHost:             "0.0.0.0",
+				HTTPPort:             4576,
+				Websocket:            defaultWS,
+				WebsocketHost:        defaultHost,
+				WebsocketPort:        defaultWSPort,
+				GRPC:                 defaultGRPC,
+				GRPCHost:             defaultHost,
+				GRPCPort:             defaultGRPCPort,
+				Metrics:              defaultMetrics,
+				MetricsHost:          defaultHost,
+				MetricsPort:          defaultMetricsPort,
+				DatabasePath:         "/home/.juno",
+				Network:              defaultCustomNetwork,
+				Pprof:                true,
+				PprofHost:            defaultHost,
+				PprofPort:            defaultPprofPort,
+				DBSnapshotHost:       defaultHost,
+				DBSnapshotPort:       defaultDBSnapshotPort,
+				Colour:               defaultColour,
+				PendingPollInterval:  defaultPendingPollInterval,
+				MaxVMs:               defaultMaxVMs,
+				MaxVMQueue:           2 * defaultMaxVMs,
+				RPCMaxBlockScan:      defaultRPCMaxBlockScan,
+				RPCMaxTracedBlocks:   defaultRPCMaxTracedBlocks,
+				RPCResponseCacheSize: defaultRPCResponseCacheSize,
+				DBCacheSize:          defaultMaxCacheSize,
+				DBMaxHandles:         defaultMaxHandles,
+				RPCCallMaxSteps:      defaultCallMaxSteps,
+				GatewayTimeout:       defaultGwTimeout,
 			},
 		},
 		"default config with no flags": {
 			inputArgs: []string{""},
 			expectedConfig: &node.Config{
-				LogLevel:            defaultLogLevel,
-				HTTP:                defaultHTTP,
-				HTTPHost:            defaultHost,
-				HTTPPort:            defaultHTTPPort,
-				Websocket:           defaultWS,
-				WebsocketHost:       defaultHost,
-				WebsocketPort:       defaultWSPort,
-				DatabasePath:        defaultDBPath,
-				Network:             defaultNetwork,
-				Pprof:               defaultPprof,
-				PprofHost:           defaultHost,
-				PprofPort:           defaultPprofPort,
-				GRPC:                defaultGRPC,
-				GRPCHost:            defaultHost,
-				GRPCPort:            defaultGRPCPort,
-				Metrics:             defaultMetrics,
-				MetricsHost:         defaultHost,
-				MetricsPort:         defaultMetricsPort,
-				Colour:              defaultColour,
-				PendingPollInterval: defaultPendingPollInterval,
-				MaxVMs:              defaultMaxVMs,
-				MaxVMQueue:          2 * defaultMaxVMs,
-				RPCMaxBlockScan:     defaultRPCMaxBlockScan,
-				DBCacheSize:         defaultMaxCacheSize,
-				DBMaxHandles:        defaultMaxHandles,
-				RPCCallMaxSteps:     defaultCallMaxSteps,
-				GatewayTimeout:      defaultGwTimeout,
+				LogLevel:             defaultLogLevel,
+				HTTP:                 defaultHTTP,
+				HTTPHost:             defaultHost,
+				HTTPPort:             defaultHTTPPort,
+				Websocket:            defaultWS,
+				WebsocketHost:        defaultHost,
+				WebsocketPort:        defaultWSPort,
+				DatabasePath:         defaultDBPath,
+				Network:              defaultNetwork,
+				Pprof:                defaultPprof,
+				PprofHost:            defaultHost,
+				PprofPort:            defaultPprofPort,
+				DBSnapshotHost:       defaultHost,
+				DBSnapshotPort:       defaultDBSnapshotPort,
+				GRPC:                 defaultGRPC,
+				GRPCHost:             defaultHost,
+				GRPCPort:             defaultGRPCPort,
+				Metrics:              defaultMetrics,
+				MetricsHost:          defaultHost,
+				MetricsPort:          defaultMetricsPort,
+				Colour:               defaultColour,
+				PendingPollInterval:  defaultPendingPollInterval,
+				MaxVMs:               defaultMaxVMs,
+				MaxVMQueue:           2 * defaultMaxVMs,
+				RPCMaxBlockScan:      defaultRPCMaxBlockScan,
+				RPCMaxTracedBlocks:   defaultRPCMaxTracedBlocks,
+				RPCResponseCacheSize: defaultRPCResponseCacheSize,
+				DBCacheSize:          defaultMaxCacheSize,
+				DBMaxHandles:         defaultMaxHandles,
+				RPCCallMaxSteps:      defaultCallMaxSteps,
+				GatewayTimeout:       defaultGwTimeout,
 			},
 		},
 		"config file path is empty string": {
 			inputArgs: []string{"--config", ""},
 			expectedConfig: &node.Config{
-				LogLevel:            defaultLogLevel,
-				HTTP:                defaultHTTP,
-				HTTPHost:            defaultHost,
-				HTTPPort:            defaultHTTPPort,
-				Websocket:           defaultWS,
-				WebsocketHost:       defaultHost,
-				WebsocketPort:       defaultWSPort,
-				GRPC:                defaultGRPC,
-				GRPCHost:            defaultHost,
-				GRPCPort:            defaultGRPCPort,
-				Metrics:             defaultMetrics,
-				MetricsHost:         defaultHost,
-				MetricsPort:         defaultMetricsPort,
-				DatabasePath:        defaultDBPath,
-				Network:             defaultNetwork,
-				Pprof:               defaultPprof,
-				PprofHost:           defaultHost,
-				PprofPort:           defaultPprofPort,
-				Colour:              defaultColour,
-				PendingPollInterval: defaultPendingPollInterval,
-				MaxVMs:              defaultMaxVMs,
-				MaxVMQueue:          2 * defaultMaxVMs,
-				RPCMaxBlockScan:     defaultRPCMaxBlockScan,
-				DBCacheSize:         defaultMaxCacheSize,
-				DBMaxHandles:        defaultMaxHandles,
-				RPCCallMaxSteps:     defaultCallMaxSteps,
-				GatewayTimeout:      defaultGwTimeout,
+				LogLevel:             defaultLogLevel,
+				HTTP:                 defaultHTTP,
+				HTTPHost:             defaultHost,
+				HTTPPort:             defaultHTTPPort,
+				Websocket:            defaultWS,
+				WebsocketHost:        defaultHost,
+				WebsocketPort:        defaultWSPort,
+				GRPC:                 defaultGRPC,
+				GRPCHost:             defaultHost,
+				GRPCPort:             defaultGRPCPort,
+				Metrics:              defaultMetrics,
+				MetricsHost:          defaultHost,
+				MetricsPort:          defaultMetricsPort,
+				DatabasePath:         defaultDBPath,
+				Network:              defaultNetwork,
+				Pprof:                defaultPprof,
+				PprofHost:            defaultHost,
+				PprofPort:            defaultPprofPort,
+				DBSnapshotHost:       defaultHost,
+				DBSnapshotPort:       defaultDBSnapshotPort,
+				Colour:               defaultColour,
+				PendingPollInterval:  defaultPendingPollInterval,
+				MaxVMs:               defaultMaxVMs,
+				MaxVMQueue:           2 * defaultMaxVMs,
+				RPCMaxBlockScan:      defaultRPCMaxBlockScan,
+				RPCMaxTracedBlocks:   defaultRPCMaxTracedBlocks,
+				RPCResponseCacheSize: defaultRPCResponseCacheSize,
+				DBCacheSize:          defaultMaxCacheSize,
+				DBMaxHandles:         defaultMaxHandles,
+				RPCCallMaxSteps:      defaultCallMaxSteps,
+				GatewayTimeout:       defaultGwTimeout,
 			},
 		},
 		"config file doesn't exist": {
@@ -229,33 +248,37 @@ cn-unverifiable-range: [0,10]
 			cfgFile:         true,
 			cfgFileContents: "\n",
 			expectedConfig: &node.Config{
-				LogLevel:            defaultLogLevel,
-				HTTP:                defaultHTTP,
-				HTTPHost:            defaultHost,
-				HTTPPort:            defaultHTTPPort,
-				Websocket:           defaultWS,
-				WebsocketHost:       defaultHost,
-				WebsocketPort:       defaultWSPort,
-				GRPC:                defaultGRPC,
-				GRPCHost:            defaultHost,
-				GRPCPort:            defaultGRPCPort,
-				Metrics:             defaultMetrics,
-				MetricsHost:         defaultHost,
-				MetricsPort:         defaultMetricsPort,
-				Network:             defaultNetwork,
-				Colour:              defaultColour,
-				PendingPollInterval: defaultPendingPollInterval,
-				Pprof:               defaultPprof,
-				PprofHost:           defaultHost,
-				PprofPort:           defaultPprofPort,
-				DatabasePath:        defaultDBPath,
-				MaxVMs:              defaultMaxVMs,
-				MaxVMQueue:          2 * defaultMaxVMs,
-				RPCMaxBlockScan:     defaultRPCMaxBlockScan,
-				DBCacheSize:         defaultMaxCacheSize,
-				DBMaxHandles:        defaultMaxHandles,
-				RPCCallMaxSteps:     defaultCallMaxSteps,
-				GatewayTimeout:      defaultGwTimeout,
+				LogLevel:             defaultLogLevel,
+				HTTP:                 defaultHTTP,
+				HTTPHost:             defaultHost,
+				HTTPPort:             defaultHTTPPort,
+				Websocket:            defaultWS,
+				WebsocketHost:        defaultHost,
+				WebsocketPort:        defaultWSPort,
+				GRPC:                 defaultGRPC,
+				GRPCHost:             defaultHost,
+				GRPCPort:             defaultGRPCPort,
+				Metrics:              defaultMetrics,
+				MetricsHost:          defaultHost,
+				MetricsPort:          defaultMetricsPort,
+				Network:              defaultNetwork,
+				Colour:               defaultColour,
+				PendingPollInterval:  defaultPendingPollInterval,
+				Pprof:                defaultPprof,
+				PprofHost:            defaultHost,
+				PprofPort:            defaultPprofPort,
+				DBSnapshotHost:       defaultHost,
+				DBSnapshotPort:       defaultDBSnapshotPort,
+				DatabasePath:         defaultDBPath,
+				MaxVMs:               defaultMaxVMs,
+				MaxVMQueue:           2 * defaultMaxVMs,
+				RPCMaxBlockScan:      defaultRPCMaxBlockScan,
+				RPCMaxTracedBlocks:   defaultRPCMaxTracedBlocks,
+				RPCResponseCacheSize: defaultRPCResponseCacheSize,
+				DBCacheSize:          defaultMaxCacheSize,
+				DBMaxHandles:         defaultMaxHandles,
+				RPCCallMaxSteps:      defaultCallMaxSteps,
+				GatewayTimeout:       defaultGwTimeout,
 			},
 		},
 		"config file with all settings but without any other flags": {
@@ -268,33 +291,37 @@ network: sepolia
 pprof: true
 `,
 			expectedConfig: &node.Config{
-				LogLevel:            utils.DEBUG,
-				HTTP:                defaultHTTP,
-				HTTPHost:            "0.0.0.0",
-				HTTPPort:            4576,
-				Websocket:           defaultWS,
-				WebsocketHost:       defaultHost,
-				WebsocketPort:       defaultWSPort,
-				GRPC:                defaultGRPC,
-				GRPCHost:            defaultHost,
-				GRPCPort:            defaultGRPCPort,
-				Metrics:             defaultMetrics,
-				MetricsHost:         defaultHost,
-				MetricsPort:         defaultMetricsPort,
-				DatabasePath:        "/home/.juno",
-				Network:             utils.Sepolia,
-				Pprof:               true,
-				PprofHost:           defaultHost,
-				PprofPort:           defaultPprofPort,
-				Colour:              defaultColour,
-				PendingPollInterval: defaultPendingPollInterval,
-				MaxVMs:              defaultMaxVMs,
-				MaxVMQueue:          2 * defaultMaxVMs,
-				RPCMaxBlockScan:     defaultRPCMaxBlockScan,
-				DBCacheSize:         defaultMaxCacheSize,
-				DBMaxHandles:        defaultMaxHandles,
-				RPCCallMaxSteps:     defaultCallMaxSteps,
-				GatewayTimeout:      defaultGwTimeout,
+				LogLevel:             utils.DEBUG,
+				HTTP:                 defaultHTTP,
+				HTTPHost:             "0.0.0.0",
+				HTTPPort:             4576,
+				Websocket:            defaultWS,
+				WebsocketHost:        defaultHost,
+				WebsocketPort:        defaultWSPort,
+				GRPC:                 defaultGRPC,
+				GRPCHost:             defaultHost,
+				GRPCPort:             defaultGRPCPort,
+				Metrics:              defaultMetrics,
+				MetricsHost:          defaultHost,
+				MetricsPort:          defaultMetricsPort,
+				DatabasePath:         "/home/.juno",
+				Network:              utils.Sepolia,
+				Pprof:                true,
+				PprofHost:            defaultHost,
+				PprofPort:            defaultPprofPort,
+				DBSnapshotHost:       defaultHost,
+				DBSnapshotPort:       defaultDBSnapshotPort,
+				Colour:               defaultColour,
+				PendingPollInterval:  defaultPendingPollInterval,
+				MaxVMs:               defaultMaxVMs,
+				MaxVMQueue:           2 * defaultMaxVMs,
+				RPCMaxBlockScan:      defaultRPCMaxBlockScan,
+				RPCMaxTracedBlocks:   defaultRPCMaxTracedBlocks,
+				RPCResponseCacheSize: defaultRPCResponseCacheSize,
+				DBCacheSize:          defaultMaxCacheSize,
+				DBMaxHandles:         defaultMaxHandles,
+				RPCCallMaxSteps:      defaultCallMaxSteps,
+				GatewayTimeout:       defaultGwTimeout,
 			},
 		},
 		"config file with some settings but without any other flags": {
@@ -304,33 +331,37 @@ http-host: 0.0.0.0
 http-port: 4576
 `,
 			expectedConfig: &node.Config{
-				LogLevel:            utils.DEBUG,
-				HTTP:                defaultHTTP,
-				HTTPHost:            "0.0.0.0",
-				HTTPPort:            4576,
-				Websocket:           defaultWS,
-				WebsocketHost:       defaultHost,
-				WebsocketPort:       defaultWSPort,
-				GRPC:                defaultGRPC,
-				GRPCHost:            defaultHost,
-				GRPCPort:            defaultGRPCPort,
-				Metrics:             defaultMetrics,
-				MetricsHost:         defaultHost,
-				MetricsPort:         defaultMetricsPort,
-				DatabasePath:        defaultDBPath,
-				Network:             defaultNetwork,
-				Pprof:               defaultPprof,
-				PprofHost:           defaultHost,
-				PprofPort:           defaultPprofPort,
-				Colour:              defaultColour,
-				PendingPollInterval: defaultPendingPollInterval,
-				MaxVMs:              defaultMaxVMs,
-				MaxVMQueue:          2 * defaultMaxVMs,
-				RPCMaxBlockScan:     defaultRPCMaxBlockScan,
-				DBCacheSize:         defaultMaxCacheSize,
-				DBMaxHandles:        defaultMaxHandles,
-				RPCCallMaxSteps:     defaultCallMaxSteps,
-				GatewayTimeout:      defaultGwTimeout,
+				LogLevel:             utils.DEBUG,
+				HTTP:                 defaultHTTP,
+				HTTPHost:             "0.0.0.0",
+				HTTPPort:             4576,
+				Websocket:            defaultWS,
+				WebsocketHost:        defaultHost,
+				WebsocketPort:        defaultWSPort,
+				GRPC:                 defaultGRPC,
+				GRPCHost:             defaultHost,
+				GRPCPort:             defaultGRPCPort,
+				Metrics:              defaultMetrics,
+				MetricsHost:          defaultHost,
+				MetricsPort:          defaultMetricsPort,
+				DatabasePath:         defaultDBPath,
+				Network:              defaultNetwork,
+				Pprof:                defaultPprof,
+				PprofHost:            defaultHost,
+				PprofPort:            defaultPprofPort,
+				DBSnapshotHost:       defaultHost,
+				DBSnapshotPort:       defaultDBSnapshotPort,
+				Colour:               defaultColour,
+				PendingPollInterval:  defaultPendingPollInterval,
+				MaxVMs:               defaultMaxVMs,
+				MaxVMQueue:           2 * defaultMaxVMs,
+				RPCMaxBlockScan:      defaultRPCMaxBlockScan,
+				RPCMaxTracedBlocks:   defaultRPCMaxTracedBlocks,
+				RPCResponseCacheSize: defaultRPCResponseCacheSize,
+				DBCacheSize:          defaultMaxCacheSize,
+				DBMaxHandles:         defaultMaxHandles,
+				RPCCallMaxSteps:      defaultCallMaxSteps,
+				GatewayTimeout:       defaultGwTimeout,
 			},
 		},
 		"all flags without config file": {
@@ -339,33 +370,37 @@ http-port: 4576
 				"--db-path", "/home/.juno", "--network", "sepolia-integration", "--pprof", "--db-cache-size", "1024",
 			},
 			expectedConfig: &node.Config{
-				LogLevel:            utils.DEBUG,
-				HTTP:                defaultHTTP,
-				HTTPHost:            "0.0.0.0",
-				HTTPPort:            4576,
-				Websocket:           defaultWS,
-				WebsocketHost:       defaultHost,
-				WebsocketPort:       defaultWSPort,
-				GRPC:                defaultGRPC,
-				GRPCHost:            defaultHost,
-				GRPCPort:            defaultGRPCPort,
-				Metrics:             defaultMetrics,
-				MetricsHost:         defaultHost,
-				MetricsPort:         defaultMetricsPort,
-				DatabasePath:        "/home/.juno",
-				Network:             utils.SepoliaIntegration,
-				Pprof:               true,
-				PprofHost:           defaultHost,
-				PprofPort:           defaultPprofPort,
-				Colour:              defaultColour,
-				MaxVMs:              defaultMaxVMs,
-				MaxVMQueue:          2 * defaultMaxVMs,
-				RPCMaxBlockScan:     defaultRPCMaxBlockScan,
-				DBCacheSize:         defaultMaxCacheSize,
-				DBMaxHandles:        defaultMaxHandles,
-				RPCCallMaxSteps:     defaultCallMaxSteps,
-				GatewayTimeout:      defaultGwTimeout,
-				PendingPollInterval: defaultPendingPollInterval,
+				LogLevel:             utils.DEBUG,
+				HTTP:                 defaultHTTP,
+				HTTPHost:             "0.0.0.0",
+				HTTPPort:             4576,
+				Websocket:            defaultWS,
+				WebsocketHost:        defaultHost,
+				WebsocketPort:        defaultWSPort,
+				GRPC:                 defaultGRPC,
+				GRPCHost:             defaultHost,
+				GRPCPort:             defaultGRPCPort,
+				Metrics:              defaultMetrics,
+				MetricsHost:          defaultHost,
+				MetricsPort:          defaultMetricsPort,
+				DatabasePath:         "/home/.juno",
+				Network:              utils.SepoliaIntegration,
+				Pprof:                true,
+				PprofHost:            defaultHost,
+				PprofPort:            defaultPprofPort,
+				DBSnapshotHost:       defaultHost,
+				DBSnapshotPort:       defaultDBSnapshotPort,
+				Colour:               defaultColour,
+				MaxVMs:               defaultMaxVMs,
+				MaxVMQueue:           2 * defaultMaxVMs,
+				RPCMaxBlockScan:      defaultRPCMaxBlockScan,
+				RPCMaxTracedBlocks:   defaultRPCMaxTracedBlocks,
+				RPCResponseCacheSize: defaultRPCResponseCacheSize,
+				DBCacheSize:          defaultMaxCacheSize,
+				DBMaxHandles:         defaultMaxHandles,
+				RPCCallMaxSteps:      defaultCallMaxSteps,
+				GatewayTimeout:       defaultGwTimeout,
+				PendingPollInterval:  defaultPendingPollInterval,
 			},
 		},
 		"some flags without config file": {
@@ -374,33 +409,37 @@ http-port: 4576
 				"--network", "sepolia",
 			},
 			expectedConfig: &node.Config{
-				LogLevel:            utils.DEBUG,
-				HTTP:                defaultHTTP,
-				HTTPHost:            "0.0.0.0",
-				HTTPPort:            4576,
-				Websocket:           defaultWS,
-				WebsocketHost:       defaultHost,
-				WebsocketPort:       defaultWSPort,
-				GRPC:                defaultGRPC,
-				GRPCHost:            defaultHost,
-				GRPCPort:            defaultGRPCPort,
-				Metrics:             defaultMetrics,
-				MetricsHost:         defaultHost,
-				MetricsPort:         defaultMetricsPort,
-				DatabasePath:        "/home/.juno",
-				Network:             utils.Sepolia,
-				Pprof:               defaultPprof,
-				PprofHost:           defaultHost,
-				PprofPort:           defaultPprofPort,
-				Colour:              defaultColour,
-				PendingPollInterval: defaultPendingPollInterval,
-				MaxVMs:              defaultMaxVMs,
-				MaxVMQueue:          2 * defaultMaxVMs,
-				RPCMaxBlockScan:     defaultRPCMaxBlockScan,
-				DBCacheSize:         defaultMaxCacheSize,
-				DBMaxHandles:        defaultMaxHandles,
-				RPCCallMaxSteps:     defaultCallMaxSteps,
-				GatewayTimeout:      defaultGwTimeout,
+				LogLevel:             utils.DEBUG,
+				HTTP:                 defaultHTTP,
+				HTTPHost:             "0.0.0.0",
+				HTTPPort:             4576,
+				Websocket:            defaultWS,
+				WebsocketHost:        defaultHost,
+				WebsocketPort:        defaultWSPort,
+				GRPC:                 defaultGRPC,
+				GRPCHost:             defaultHost,
+				GRPCPort:             defaultGRPCPort,
+				Metrics:              defaultMetrics,
+				MetricsHost:          defaultHost,
+				MetricsPort:          defaultMetricsPort,
+				DatabasePath:         "/home/.juno",
+				Network:              utils.Sepolia,
+				Pprof:                defaultPprof,
+				PprofHost:            defaultHost,
+				PprofPort:            defaultPprofPort,
+				DBSnapshotHost:       defaultHost,
+				DBSnapshotPort:       defaultDBSnapshotPort,
+				Colour:               defaultColour,
+				PendingPollInterval:  defaultPendingPollInterval,
+				MaxVMs:               defaultMaxVMs,
+				MaxVMQueue:           2 * defaultMaxVMs,
+				RPCMaxBlockScan:      defaultRPCMaxBlockScan,
+				RPCMaxTracedBlocks:   defaultRPCMaxTracedBlocks,
+				RPCResponseCacheSize: defaultRPCResponseCacheSize,
+				DBCacheSize:          defaultMaxCacheSize,
+				DBMaxHandles:         defaultMaxHandles,
+				RPCCallMaxSteps:      defaultCallMaxSteps,
+				GatewayTimeout:       defaultGwTimeout,
 			},
 		},
 		"all setting set in both config file and flags": {
@@ -433,33 +472,37 @@ db-cache-size: 1024
 				"--db-cache-size", "9",
 			},
 			expectedConfig: &node.Config{
-				LogLevel:            utils.ERROR,
-				HTTP:                true,
-				HTTPHost:            "127.0.0.1",
-				HTTPPort:            4577,
-				Websocket:           true,
-				WebsocketHost:       "127.0.0.1",
-				WebsocketPort:       4577,
-				Metrics:             true,
-				MetricsHost:         "127.0.0.1",
-				MetricsPort:         4577,
-				GRPC:                true,
-				GRPCHost:            "127.0.0.1",
-				GRPCPort:            4577,
-				DatabasePath:        "/home/flag/.juno",
-				Network:             utils.Mainnet,
-				Pprof:               true,
-				PprofHost:           "0.0.0.0",
-				PprofPort:           6064,
-				Colour:              defaultColour,
-				PendingPollInterval: time.Millisecond,
-				MaxVMs:              defaultMaxVMs,
-				MaxVMQueue:          2 * defaultMaxVMs,
-				RPCMaxBlockScan:     defaultRPCMaxBlockScan,
-				DBCacheSize:         9,
-				DBMaxHandles:        defaultMaxHandles,
-				RPCCallMaxSteps:     defaultCallMaxSteps,
-				GatewayTimeout:      defaultGwTimeout,
+				LogLevel:             utils.ERROR,
+				HTTP:                 true,
+				HTTPHost:             "127.0.0.1",
+				HTTPPort:             4577,
+				Websocket:            true,
+				WebsocketHost:        "127.0.0.1",
+				WebsocketPort:        4577,
+				Metrics:              true,
+				MetricsHost:          "127.0.0.1",
+				MetricsPort:          4577,
+				GRPC:                 true,
+				GRPCHost:             "127.0.0.1",
+				GRPCPort:             4577,
+				DatabasePath:         "/home/flag/.juno",
+				Network:              utils.Mainnet,
+				Pprof:                true,
+				PprofHost:            "0.0.0.0",
+				PprofPort:            6064,
+				DBSnapshotHost:       defaultHost,
+				DBSnapshotPort:       defaultDBSnapshotPort,
+				Colour:               defaultColour,
+				PendingPollInterval:  time.Millisecond,
+				MaxVMs:               defaultMaxVMs,
+				MaxVMQueue:           2 * defaultMaxVMs,
+				RPCMaxBlockScan:      defaultRPCMaxBlockScan,
+				RPCMaxTracedBlocks:   defaultRPCMaxTracedBlocks,
+				RPCResponseCacheSize: defaultRPCResponseCacheSize,
+				DBCacheSize:          9,
+				DBMaxHandles:         defaultMaxHandles,
+				RPCCallMaxSteps:      defaultCallMaxSteps,
+				GatewayTimeout:       defaultGwTimeout,
 			},
 		},
 		"some setting set in both config file and flags": {
@@ -471,33 +514,37 @@ network: sepolia
 `,
 			inputArgs: []string{"--db-path", "/home/flag/.juno"},
 			expectedConfig: &node.Config{
-				LogLevel:            utils.WARN,
-				HTTP:                defaultHTTP,
-				HTTPHost:            "0.0.0.0",
-				HTTPPort:            4576,
-				Websocket:           defaultWS,
-				WebsocketHost:       defaultHost,
-				WebsocketPort:       defaultWSPort,
-				GRPC:                defaultGRPC,
-				GRPCHost:            defaultHost,
-				GRPCPort:            defaultGRPCPort,
-				Metrics:             defaultMetrics,
-				MetricsHost:         defaultHost,
-				MetricsPort:         defaultMetricsPort,
-				DatabasePath:        "/home/flag/.juno",
-				Network:             utils.Sepolia,
-				Pprof:               defaultPprof,
-				PprofHost:           defaultHost,
-				PprofPort:           defaultPprofPort,
-				Colour:              defaultColour,
-				PendingPollInterval: defaultPendingPollInterval,
-				MaxVMs:              defaultMaxVMs,
-				MaxVMQueue:          2 * defaultMaxVMs,
-				RPCMaxBlockScan:     defaultRPCMaxBlockScan,
-				DBCacheSize:         defaultMaxCacheSize,
-				DBMaxHandles:        defaultMaxHandles,
-				RPCCallMaxSteps:     defaultCallMaxSteps,
-				GatewayTimeout:      defaultGwTimeout,
+				LogLevel:             utils.WARN,
+				HTTP:                 defaultHTTP,
+				HTTPHost:             "0.0.0.0",
+				HTTPPort:             4576,
+				Websocket:            defaultWS,
+				WebsocketHost:        defaultHost,
+				WebsocketPort:        defaultWSPort,
+				GRPC:                 defaultGRPC,
+				GRPCHost:             defaultHost,
+				GRPCPort:             defaultGRPCPort,
+				Metrics:              defaultMetrics,
+				MetricsHost:          defaultHost,
+				MetricsPort:          defaultMetricsPort,
+				DatabasePath:         "/home/flag/.juno",
+				Network:              utils.Sepolia,
+				Pprof:                defaultPprof,
+				PprofHost:            defaultHost,
+				PprofPort:            defaultPprofPort,
+				DBSnapshotHost:       defaultHost,
+				DBSnapshotPort:       defaultDBSnapshotPort,
+				Colour:               defaultColour,
+				PendingPollInterval:  defaultPendingPollInterval,
+				MaxVMs:               defaultMaxVMs,
+				MaxVMQueue:           2 * defaultMaxVMs,
+				RPCMaxBlockScan:      defaultRPCMaxBlockScan,
+				RPCMaxTracedBlocks:   defaultRPCMaxTracedBlocks,
+				RPCResponseCacheSize: defaultRPCResponseCacheSize,
+				DBCacheSize:          defaultMaxCacheSize,
+				DBMaxHandles:         defaultMaxHandles,
+				RPCCallMaxSteps:      defaultCallMaxSteps,
+				GatewayTimeout:       defaultGwTimeout,
 			},
 		},
 		"some setting set in default, config file and flags": {
@@ -505,132 +552,148 @@ network: sepolia
 			cfgFileContents: `network: sepolia-integration`,
 			inputArgs:       []string{"--db-path", "/home/flag/.juno", "--pprof"},
 			expectedConfig: &node.Config{
-				LogLevel:            defaultLogLevel,
-				HTTP:                defaultHTTP,
-				HTTPHost:            defaultHost,
-				HTTPPort:            defaultHTTPPort,
-				Websocket:           defaultWS,
-				WebsocketHost:       defaultHost,
-				WebsocketPort:       defaultWSPort,
-				GRPC:                defaultGRPC,
-				GRPCHost:            defaultHost,
-				GRPCPort:            defaultGRPCPort,
-				Metrics:             defaultMetrics,
-				MetricsHost:         defaultHost,
-				MetricsPort:         defaultMetricsPort,
-				DatabasePath:        "/home/flag/.juno",
-				Network:             utils.SepoliaIntegration,
-				Pprof:               true,
-				PprofHost:           defaultHost,
-				PprofPort:           defaultPprofPort,
-				Colour:              defaultColour,
-				PendingPollInterval: defaultPendingPollInterval,
-				MaxVMs:              defaultMaxVMs,
-				MaxVMQueue:          2 * defaultMaxVMs,
-				RPCMaxBlockScan:     defaultRPCMaxBlockScan,
-				DBCacheSize:         defaultMaxCacheSize,
-				DBMaxHandles:        defaultMaxHandles,
-				RPCCallMaxSteps:     defaultCallMaxSteps,
-				GatewayTimeout:      defaultGwTimeout,
+				LogLevel:             defaultLogLevel,
+				HTTP:                 defaultHTTP,
+				HTTPHost:             defaultHost,
+				HTTPPort:             defaultHTTPPort,
+				Websocket:            defaultWS,
+				WebsocketHost:        defaultHost,
+				WebsocketPort:        defaultWSPort,
+				GRPC:                 defaultGRPC,
+				GRPCHost:             defaultHost,
+				GRPCPort:             defaultGRPCPort,
+				Metrics:              defaultMetrics,
+				MetricsHost:          defaultHost,
+				MetricsPort:          defaultMetricsPort,
+				DatabasePath:         "/home/flag/.juno",
+				Network:              utils.SepoliaIntegration,
+				Pprof:                true,
+				PprofHost:            defaultHost,
+				PprofPort:            defaultPprofPort,
+				DBSnapshotHost:       defaultHost,
+				DBSnapshotPort:       defaultDBSnapshotPort,
+				Colour:               defaultColour,
+				PendingPollInterval:  defaultPendingPollInterval,
+				MaxVMs:               defaultMaxVMs,
+				MaxVMQueue:           2 * defaultMaxVMs,
+				RPCMaxBlockScan:      defaultRPCMaxBlockScan,
+				RPCMaxTracedBlocks:   defaultRPCMaxTracedBlocks,
+				RPCResponseCacheSize: defaultRPCResponseCacheSize,
+				DBCacheSize:          defaultMaxCacheSize,
+				DBMaxHandles:         defaultMaxHandles,
+				RPCCallMaxSteps:      defaultCallMaxSteps,
+				GatewayTimeout:       defaultGwTimeout,
 			},
 		},
 		"only set env variables": {
 			env: []string{"JUNO_HTTP_PORT", "8080", "JUNO_WS", "true", "JUNO_HTTP_HOST", "0.0.0.0"},
 			expectedConfig: &node.Config{
-				LogLevel:            defaultLogLevel,
-				HTTP:                defaultHTTP,
-				HTTPHost:            "0.0.0.0",
-				HTTPPort:            8080,
-				Websocket:           true,
-				WebsocketHost:       defaultHost,
-				WebsocketPort:       defaultWSPort,
-				GRPC:                defaultGRPC,
-				GRPCHost:            defaultHost,
-				GRPCPort:            defaultGRPCPort,
-				Metrics:             defaultMetrics,
-				MetricsHost:         defaultHost,
-				MetricsPort:         defaultMetricsPort,
-				DatabasePath:        defaultDBPath,
-				Network:             defaultNetwork,
-				Pprof:               defaultPprof,
-				PprofHost:           defaultHost,
-				PprofPort:           defaultPprofPort,
-				Colour:              defaultColour,
-				PendingPollInterval: defaultPendingPollInterval,
-				MaxVMs:              defaultMaxVMs,
-				MaxVMQueue:          2 * defaultMaxVMs,
-				RPCMaxBlockScan:     defaultRPCMaxBlockScan,
-				DBCacheSize:         defaultMaxCacheSize,
-				DBMaxHandles:        defaultMaxHandles,
-				RPCCallMaxSteps:     defaultCallMaxSteps,
-				GatewayTimeout:      defaultGwTimeout,
+				LogLevel:             defaultLogLevel,
+				HTTP:                 defaultHTTP,
+				HTTPHost:             "0.0.0.0",
+				HTTPPort:             8080,
+				Websocket:            true,
+				WebsocketHost:        defaultHost,
+				WebsocketPort:        defaultWSPort,
+				GRPC:                 defaultGRPC,
+				GRPCHost:             defaultHost,
+				GRPCPort:             defaultGRPCPort,
+				Metrics:              defaultMetrics,
+				MetricsHost:          defaultHost,
+				MetricsPort:          defaultMetricsPort,
+				DatabasePath:         defaultDBPath,
+				Network:              defaultNetwork,
+				Pprof:                defaultPprof,
+				PprofHost:            defaultHost,
+				PprofPort:            defaultPprofPort,
+				DBSnapshotHost:       defaultHost,
+				DBSnapshotPort:       defaultDBSnapshotPort,
+				Colour:               defaultColour,
+				PendingPollInterval:  defaultPendingPollInterval,
+				MaxVMs:               defaultMaxVMs,
+				MaxVMQueue:           2 * defaultMaxVMs,
+				RPCMaxBlockScan:      defaultRPCMaxBlockScan,
+				RPCMaxTracedBlocks:   defaultRPCMaxTracedBlocks,
+				RPCResponseCacheSize: defaultRPCResponseCacheSize,
+				DBCacheSize:          defaultMaxCacheSize,
+				DBMaxHandles:         defaultMaxHandles,
+				RPCCallMaxSteps:      defaultCallMaxSteps,
+				GatewayTimeout:       defaultGwTimeout,
 			},
 		},
 		"some setting set in both env variables and flags": {
 			env:       []string{"JUNO_DB_PATH", "/home/env/.juno"},
 			inputArgs: []string{"--db-path", "/home/flag/.juno"},
 			expectedConfig: &node.Config{
-				LogLevel:            defaultLogLevel,
-				HTTP:                defaultHTTP,
-				HTTPHost:            defaultHost,
-				HTTPPort:            defaultHTTPPort,
-				Websocket:           defaultWS,
-				WebsocketHost:       defaultHost,
-				WebsocketPort:       defaultWSPort,
-				GRPC:                defaultGRPC,
-				GRPCHost:            defaultHost,
-				GRPCPort:            defaultGRPCPort,
-				Metrics:             defaultMetrics,
-				MetricsHost:         defaultHost,
-				MetricsPort:         defaultMetricsPort,
-				DatabasePath:        "/home/flag/.juno",
-				Network:             defaultNetwork,
-				Pprof:               defaultPprof,
-				PprofHost:           defaultHost,
-				PprofPort:           defaultPprofPort,
-				Colour:              defaultColour,
-				PendingPollInterval: defaultPendingPollInterval,
-				MaxVMs:              defaultMaxVMs,
-				MaxVMQueue:          2 * defaultMaxVMs,
-				RPCMaxBlockScan:     defaultRPCMaxBlockScan,
-				DBCacheSize:         defaultMaxCacheSize,
-				DBMaxHandles:        defaultMaxHandles,
-				RPCCallMaxSteps:     defaultCallMaxSteps,
-				GatewayTimeout:      defaultGwTimeout,
+				LogLevel:             defaultLogLevel,
+				HTTP:                 defaultHTTP,
+				HTTPHost:             defaultHost,
+				HTTPPort:             defaultHTTPPort,
+				Websocket:            defaultWS,
+				WebsocketHost:        defaultHost,
+				WebsocketPort:        defaultWSPort,
+				GRPC:                 defaultGRPC,
+				GRPCHost:             defaultHost,
+				GRPCPort:             defaultGRPCPort,
+				Metrics:              defaultMetrics,
+				MetricsHost:          defaultHost,
+				MetricsPort:          defaultMetricsPort,
+				DatabasePath:         "/home/flag/.juno",
+				Network:              defaultNetwork,
+				Pprof:                defaultPprof,
+				PprofHost:            defaultHost,
+				PprofPort:            defaultPprofPort,
+				DBSnapshotHost:       defaultHost,
+				DBSnapshotPort:       defaultDBSnapshotPort,
+				Colour:               defaultColour,
+				PendingPollInterval:  defaultPendingPollInterval,
+				MaxVMs:               defaultMaxVMs,
+				MaxVMQueue:           2 * defaultMaxVMs,
+				RPCMaxBlockScan:      defaultRPCMaxBlockScan,
+				RPCMaxTracedBlocks:   defaultRPCMaxTracedBlocks,
+				RPCResponseCacheSize: defaultRPCResponseCacheSize,
+				DBCacheSize:          defaultMaxCacheSize,
+				DBMaxHandles:         defaultMaxHandles,
+				RPCCallMaxSteps:      defaultCallMaxSteps,
+				GatewayTimeout:       defaultGwTimeout,
 			},
 		},
 		"some setting set in both env variables and config file": {
 			cfgFileContents: `db-path: /home/file/.juno`,
 			env:             []string{"JUNO_DB_PATH", "/home/env/.juno", "JUNO_GW_API_KEY", "apikey"},
 			expectedConfig: &node.Config{
-				LogLevel:            defaultLogLevel,
-				HTTP:                defaultHTTP,
-				HTTPHost:            defaultHost,
-				HTTPPort:            defaultHTTPPort,
-				Websocket:           defaultWS,
-				WebsocketHost:       defaultHost,
-				WebsocketPort:       defaultWSPort,
-				GRPC:                defaultGRPC,
-				GRPCHost:            defaultHost,
-				GRPCPort:            defaultGRPCPort,
-				Metrics:             defaultMetrics,
-				MetricsHost:         defaultHost,
-				MetricsPort:         defaultMetricsPort,
-				DatabasePath:        "/home/env/.juno",
-				Network:             defaultNetwork,
-				Pprof:               defaultPprof,
-				PprofHost:           defaultHost,
-				PprofPort:           defaultPprofPort,
-				Colour:              defaultColour,
-				PendingPollInterval: defaultPendingPollInterval,
-				MaxVMs:              defaultMaxVMs,
-				MaxVMQueue:          2 * defaultMaxVMs,
-				RPCMaxBlockScan:     defaultRPCMaxBlockScan,
-				DBCacheSize:         defaultMaxCacheSize,
-				GatewayAPIKey:       "apikey",
-				DBMaxHandles:        defaultMaxHandles,
-				RPCCallMaxSteps:     defaultCallMaxSteps,
-				GatewayTimeout:      defaultGwTimeout,
+				LogLevel:             defaultLogLevel,
+				HTTP:                 defaultHTTP,
+				HTTPHost:             defaultHost,
+				HTTPPort:             defaultHTTPPort,
+				Websocket:            defaultWS,
+				WebsocketHost:        defaultHost,
+				WebsocketPort:        defaultWSPort,
+				GRPC:                 defaultGRPC,
+				GRPCHost:             defaultHost,
+				GRPCPort:             defaultGRPCPort,
+				Metrics:              defaultMetrics,
+				MetricsHost:          defaultHost,
+				MetricsPort:          defaultMetricsPort,
+				DatabasePath:         "/home/env/.juno",
+				Network:              defaultNetwork,
+				Pprof:                defaultPprof,
+				PprofHost:            defaultHost,
+				PprofPort:            defaultPprofPort,
+				DBSnapshotHost:       defaultHost,
+				DBSnapshotPort:       defaultDBSnapshotPort,
+				Colour:               defaultColour,
+				PendingPollInterval:  defaultPendingPollInterval,
+				MaxVMs:               defaultMaxVMs,
+				MaxVMQueue:           2 * defaultMaxVMs,
+				RPCMaxBlockScan:      defaultRPCMaxBlockScan,
+				RPCMaxTracedBlocks:   defaultRPCMaxTracedBlocks,
+				RPCResponseCacheSize: defaultRPCResponseCacheSize,
+				DBCacheSize:          defaultMaxCacheSize,
+				GatewayAPIKey:        "apikey",
+				DBMaxHandles:         defaultMaxHandles,
+				RPCCallMaxSteps:      defaultCallMaxSteps,
+				GatewayTimeout:       defaultGwTimeout,
 			},
 		},
 	}
@@ -127,6 +127,99 @@ func VerifyBlockHash(b *Block, network *utils.Network, stateDiff *StateDiff) (*B
 	return nil, errors.New("can not verify hash in block header")
 }
 
+// VerifyBlockSignature checks that the block carries a sequencer signature that verifies against publicKey.
+// Blocks from 0.13.2 on are signed over their hash, older blocks over poseidon(block hash, state diff commitment).
+func VerifyBlockSignature(b *Block, publicKey *felt.Felt, stateDiff *StateDiff) error {
+	var stateDiffCommitment *felt.Felt
+	// Blocks from 0.13.2 on are signed over their hash alone.
+	if blockVer, err := ParseBlockVersion(b.ProtocolVersion); err == nil && blockVer.LessThan(semver.MustParse("0.13.2")) {
+		stateDiffCommitment = stateDiff.Commitment()
+	}
+	return VerifyHeaderSignature(b.Header, publicKey, stateDiffCommitment)
+}
+
+// VerifyHeaderSignature is [VerifyBlockSignature] for a header, given the commitment to the state diff of the block.
+func VerifyHeaderSignature(h *Header, publicKey, stateDiffCommitment *felt.Felt) error {
+	if len(h.Signatures) == 0 {
+		return errors.New("block is not signed")
+	}
+
+	blockVer, err := ParseBlockVersion(h.ProtocolVersion)
+	if err != nil {
+		return err
+	}
+	msg := h.Hash
+	if blockVer.LessThan(semver.MustParse("0.13.2")) {
+		if stateDiffCommitment == nil {
+			return errors.New("missing state diff commitment")
+		}
+		msg = crypto.PoseidonArray(h.Hash, stateDiffCommitment)
+	}
+	key := crypto.NewPublicKey(publicKey)
+	for _, sig := range h.Signatures {
+		if len(sig) != 2 { //nolint:mnd
+			return fmt.Errorf("malformed signature: expected 2 elements, got %d", len(sig))
+		}
+
+		verified, err := key.Verify(&crypto.Signature{R: *sig[0], S: *sig[1]}, msg)
+		if err != nil {
+			return err
+		}
+		if verified {
+			return nil
+		}
+	}
+	return errors.New("can not verify sequencer signature")
+}
+
+// VerifyHeaderHash verifies the hash in a header from the commitments to the transactions, events, receipts and state
+// diff of the block, before they are downloaded. Like [VerifyBlockHash], it skips the blocks whose hashes can't be
+// verified.
+func VerifyHeaderHash(h *Header, commitments *BlockCommitments, stateDiffLength uint64, network *utils.Network) error {
+	metaInfo := network.BlockHashMetaInfo
+	unverifiableRange := metaInfo.UnverifiableRange
+	if unverifiableRange != nil && h.Number >= unverifiableRange[0] && h.Number <= unverifiableRange[1] {
+		return nil
+	}
+
+	blockVer, err := ParseBlockVersion(h.ProtocolVersion)
+	if err != nil {
+		return err
+	}
+
+	if commitments.TransactionCommitment == nil {
+		return errors.New("missing transaction commitment")
+	}
+	if h.Number >= metaInfo.First07Block && commitments.EventCommitment == nil {
+		return errors.New("missing event commitment")
+	}
+
+	var hashes []*felt.Felt
+	switch {
+	case !blockVer.LessThan(semver.MustParse("0.13.2")):
+		if h.SequencerAddress == nil || commitments.ReceiptCommitment == nil || commitments.StateDiffCommitment == nil {
+			return errors.New("missing sequencer address, receipt or state diff commitment")
+		}
+		hashes = append(hashes, post0132HeaderHash(h, commitments, stateDiffLength))
+	case h.Number < metaInfo.First07Block:
+		hashes = append(hashes, pre07HeaderHash(h, commitments.TransactionCommitment, network.L2ChainIDFelt()))
+	case h.SequencerAddress != nil:
+		hashes = append(hashes, post07HeaderHash(h, commitments, h.SequencerAddress))
+	default:
+		hashes = append(hashes, post07HeaderHash(h, commitments, &felt.Zero))
+		if metaInfo.FallBackSequencerAddress != nil {
+			hashes = append(hashes, post07HeaderHash(h, commitments, metaInfo.FallBackSequencerAddress))
+		}
+	}
+
+	for _, hash := range hashes {
+		if hash.Equal(h.Hash) {
+			return nil
+		}
+	}
+	return errors.New("can not verify hash in block header")
+}
+
 // blockHash computes the block hash, with option to override sequence address
 func blockHash(b *Block, stateDiff *StateDiff, network *utils.Network, overrideSeqAddr *felt.Felt) (*felt.Felt,
 	*BlockCommitments, error,
@@ -156,20 +249,25 @@ func pre07Hash(b *Block, chain *felt.Felt) (*felt.Felt, *BlockCommitments, error
 		return nil, nil, err
 	}
 
+	return pre07HeaderHash(b.Header, txCommitment, chain), &BlockCommitments{TransactionCommitment: txCommitment}, nil
+}
+
+// pre07HeaderHash computes the block hash for blocks generated before Cairo 0.7.0 from the transaction commitment
+func pre07HeaderHash(h *Header, txCommitment, chain *felt.Felt) *felt.Felt {
 	return crypto.PedersenArray(
-		new(felt.Felt).SetUint64(b.Number), // block number
-		b.GlobalStateRoot,                  // global state root
+		new(felt.Felt).SetUint64(h.Number), // block number
+		h.GlobalStateRoot,                  // global state root
 		&felt.Zero,                         // reserved: sequencer address
 		&felt.Zero,                         // reserved: block timestamp
-		new(felt.Felt).SetUint64(b.TransactionCount), // number of transactions
+		new(felt.Felt).SetUint64(h.TransactionCount), // number of transactions
 		txCommitment, // transaction commitment
 		&felt.Zero,   // reserved: number of events
 		&felt.Zero,   // reserved: event commitment
 		&felt.Zero,   // reserved: protocol version
 		&felt.Zero,   // reserved: extra data
 		chain,        // extra data: chain id
-		b.ParentHash, // parent hash
-	), &BlockCommitments{TransactionCommitment: txCommitment}, nil
+		h.ParentHash, // parent hash
+	)
 }
 
 func Post0132Hash(b *Block, stateDiff *StateDiff) (*felt.Felt, *BlockCommitments, error) {
@@ -205,32 +303,38 @@ func Post0132Hash(b *Block, stateDiff *StateDiff) (*felt.Felt, *BlockCommitments
 		return nil, nil, rErr
 	}
 
-	concatCounts := concatCounts(b.TransactionCount, b.EventCount, sdLength, b.L1DAMode)
+	commitments := &BlockCommitments{
+		TransactionCommitment: txCommitment,
+		EventCommitment:       eCommitment,
+		ReceiptCommitment:     rCommitment,
+		StateDiffCommitment:   sdCommitment,
+	}
+	return post0132HeaderHash(b.Header, commitments, sdLength), commitments, nil
+}
+
+// post0132HeaderHash computes the block hash for blocks generated after Cairo 0.13.2 from the block commitments
+func post0132HeaderHash(h *Header, commitments *BlockCommitments, stateDiffLength uint64) *felt.Felt {
+	concatCounts := concatCounts(h.TransactionCount, h.EventCount, stateDiffLength, h.L1DAMode)
 
 	return crypto.PoseidonArray(
-			new(felt.Felt).SetBytes([]byte("STARKNET_BLOCK_HASH0")),
-			new(felt.Felt).SetUint64(b.Number),    // block number
-			b.GlobalStateRoot,                     // global state root
-			b.SequencerAddress,                    // sequencer address
-			new(felt.Felt).SetUint64(b.Timestamp), // block timestamp
-			concatCounts,
-			sdCommitment,
-			txCommitment,   // transaction commitment
-			eCommitment,    // event commitment
-			rCommitment,    // receipt commitment
-			b.GasPrice,     // gas price in wei
-			b.GasPriceSTRK, // gas price in fri
-			b.L1DataGasPrice.PriceInWei,
-			b.L1DataGasPrice.PriceInFri,
-			new(felt.Felt).SetBytes([]byte(b.ProtocolVersion)),
-			&felt.Zero,   // reserved: extra data
-			b.ParentHash, // parent block hash
-		), &BlockCommitments{
-			TransactionCommitment: txCommitment,
-			EventCommitment:       eCommitment,
-			ReceiptCommitment:     rCommitment,
-			StateDiffCommitment:   sdCommitment,
-		}, nil
+		new(felt.Felt).SetBytes([]byte("STARKNET_BLOCK_HASH0")),
+		new(felt.Felt).SetUint64(h.Number),    // block number
+		h.GlobalStateRoot,                     // global state root
+		h.SequencerAddress,                    // sequencer address
+		new(felt.Felt).SetUint64(h.Timestamp), // block timestamp
+		concatCounts,
+		commitments.StateDiffCommitment,
+		commitments.TransactionCommitment, // transaction commitment
+		commitments.EventCommitment,       // event commitment
+		commitments.ReceiptCommitment,     // receipt commitment
+		h.GasPrice,                        // gas price in wei
+		h.GasPriceSTRK,                    // gas price in fri
+		h.L1DataGasPrice.PriceInWei,
+		h.L1DataGasPrice.PriceInFri,
+		new(felt.Felt).SetBytes([]byte(h.ProtocolVersion)),
+		&felt.Zero,   // reserved: extra data
+		h.ParentHash, // parent block hash
+	)
 }
 
 // post07Hash computes the block hash for blocks generated after Cairo 0.7.0
@@ -267,6 +371,12 @@ func post07Hash(b *Block, overrideSeqAddr *felt.Felt) (*felt.Felt, *BlockCommitm
 		return nil, nil, rErr
 	}
 
+	commitments := &BlockCommitments{TransactionCommitment: txCommitment, EventCommitment: eCommitment, ReceiptCommitment: rCommitment}
+	return post07HeaderHash(b.Header, commitments, seqAddr), commitments, nil
+}
+
+// post07HeaderHash computes the block hash for blocks generated after Cairo 0.7.0 from the block commitments
+func post07HeaderHash(h *Header, commitments *BlockCommitments, seqAddr *felt.Felt) *felt.Felt {
 	// Unlike the pre07Hash computation, we exclude the chain
 	// id and replace the zero felt with the actual values for:
 	// - sequencer address
@@ -274,18 +384,18 @@ func post07Hash(b *Block, overrideSeqAddr *felt.Felt) (*felt.Felt, *BlockCommitm
 	// - number of events
 	// - event commitment
 	return crypto.PedersenArray(
-		new(felt.Felt).SetUint64(b.Number),           // block number
-		b.GlobalStateRoot,                            // global state root
+		new(felt.Felt).SetUint64(h.Number),           // block number
+		h.GlobalStateRoot,                            // global state root
 		seqAddr,                                      // sequencer address
-		new(felt.Felt).SetUint64(b.Timestamp),        // block timestamp
-		new(felt.Felt).SetUint64(b.TransactionCount), // number of transactions
-		txCommitment,                                 // transaction commitment
-		new(felt.Felt).SetUint64(b.EventCount),       // number of events
-		eCommitment,                                  // event commitment
+		new(felt.Felt).SetUint64(h.Timestamp),        // block timestamp
+		new(felt.Felt).SetUint64(h.TransactionCount), // number of transactions
+		commitments.TransactionCommitment,            // transaction commitment
+		new(felt.Felt).SetUint64(h.EventCount),       // number of events
+		commitments.EventCommitment,                  // event commitment
 		&felt.Zero,                                   // reserved: protocol version
 		&felt.Zero,                                   // reserved: extra data
-		b.ParentHash,                                 // parent block hash
-	), &BlockCommitments{TransactionCommitment: txCommitment, EventCommitment: eCommitment, ReceiptCommitment: rCommitment}, nil
+		h.ParentHash,                                 // parent block hash
+	)
 }
 
 func MarshalBlockNumber(blockNumber uint64) []byte {
@@ -267,3 +267,43 @@ func Test0132BlockHash(t *testing.T) {
 		})
 	}
 }
+
+func TestVerifyHeaderHash(t *testing.T) {
+	t.Parallel()
+	for _, test := range []struct {
+		number  uint64
+		network utils.Network
+		name    string
+		// Only the hashes of blocks from 0.13.2 on commit to their state diffs.
+		hasStateDiff bool
+	}{
+		{number: 0, network: utils.Mainnet, name: "pre 0.7.0"},
+		{number: 833, network: utils.Mainnet, name: "post 0.7.0 without sequencer address"},
+		{number: 16789, network: utils.Mainnet, name: "post 0.7.0 with sequencer address"},
+		{number: 35748, network: utils.SepoliaIntegration, name: "post 0.13.2", hasStateDiff: true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			gw := adaptfeeder.New(feeder.NewTestClient(t, &test.network))
+			b, err := gw.BlockByNumber(context.Background(), test.number)
+			require.NoError(t, err)
+			var stateDiff *core.StateDiff
+			if test.hasStateDiff {
+				su, err := gw.StateUpdate(context.Background(), test.number)
+				require.NoError(t, err)
+				stateDiff = su.StateDiff
+			}
+			commitments, err := core.VerifyBlockHash(b, &test.network, stateDiff)
+			require.NoError(t, err)
+
+			var stateDiffLength uint64
+			if stateDiff != nil {
+				stateDiffLength = stateDiff.Length()
+			}
+			require.NoError(t, core.VerifyHeaderHash(b.Header, commitments, stateDiffLength, &test.network))
+
+			b.GlobalStateRoot = new(felt.Felt).Add(b.GlobalStateRoot, new(felt.Felt).SetUint64(1))
+			assert.Error(t, core.VerifyHeaderHash(b.Header, commitments, stateDiffLength, &test.network))
+		})
+	}
+}
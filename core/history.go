@@ -28,6 +28,53 @@ func (h *history) deleteLog(key []byte, height uint64) error {
 	return h.txn.Delete(logDBKey(key, height))
 }
 
+// PruneHistoryLogs deletes the logs in the history bucket of changes made before block number `before`, which are
+// only needed to read the state of earlier blocks. It scans at most limit logs, starting at key from, and returns
+// the key to continue from, or nil once the end of the bucket is reached.
+func PruneHistoryLogs(txn db.Transaction, bucket db.Bucket, from []byte, before uint64, limit int) ([]byte, error) {
+	prefix := bucket.Key()
+	if from == nil {
+		from = prefix
+	}
+
+	it, err := txn.NewIterator()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		next    []byte
+		scanned int
+		logs    [][]byte
+	)
+	for it.Seek(from); it.Valid(); it.Next() {
+		key := it.Key()
+		if !bytes.HasPrefix(key, prefix) {
+			break
+		}
+		if scanned == limit {
+			next = bytes.Clone(key)
+			break
+		}
+		scanned++
+
+		if len(key) >= len(prefix)+8 && binary.BigEndian.Uint64(key[len(key)-8:]) < before {
+			logs = append(logs, bytes.Clone(key))
+		}
+	}
+	if err = it.Close(); err != nil {
+		return nil, err
+	}
+
+	h := history{txn: txn}
+	for _, log := range logs {
+		if err = h.deleteLog(log[:len(log)-8], binary.BigEndian.Uint64(log[len(log)-8:])); err != nil {
+			return nil, err
+		}
+	}
+	return next, nil
+}
+
 func (h *history) valueAt(key []byte, height uint64) ([]byte, error) {
 	it, err := h.txn.NewIterator()
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db"
 	"github.com/NethermindEth/juno/db/pebble"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -102,3 +103,44 @@ func TestHistory(t *testing.T) {
 		})
 	}
 }
+
+func TestPruneHistoryLogs(t *testing.T) {
+	testDB := pebble.NewMemTest(t)
+	txn, err := testDB.NewTransaction(true)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, txn.Discard())
+	})
+
+	history := &history{txn: txn}
+	location := new(felt.Felt).SetUint64(1)
+	for _, address := range []uint64{1, 2} {
+		for _, height := range []uint64{5, 10, 15} {
+			require.NoError(t, history.LogContractStorage(new(felt.Felt).SetUint64(address), location,
+				new(felt.Felt).SetUint64(height), height))
+		}
+	}
+
+	// With a limit of 2, each call scans at most two of the six logs.
+	var from []byte
+	for calls := 1; ; calls++ {
+		from, err = PruneHistoryLogs(txn, db.ContractStorageHistory, from, 11, 2)
+		require.NoError(t, err)
+		if from == nil {
+			assert.Equal(t, 3, calls)
+			break
+		}
+	}
+
+	for _, address := range []uint64{1, 2} {
+		key := storageLogKey(new(felt.Felt).SetUint64(address), location)
+		for _, height := range []uint64{5, 10} {
+			assert.ErrorIs(t, txn.Get(logDBKey(key, height), func([]byte) error { return nil }), db.ErrKeyNotFound)
+		}
+
+		// The value at the heights that aren't pruned is still known.
+		value, err := history.ContractStorageAt(new(felt.Felt).SetUint64(address), location, 11)
+		require.NoError(t, err)
+		assert.Equal(t, new(felt.Felt).SetUint64(15), value)
+	}
+}
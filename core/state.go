@@ -25,7 +25,10 @@ var (
 	leafVersion  = new(felt.Felt).SetBytes([]byte(`CONTRACT_CLASS_LEAF_V0`))
 )
 
-var _ StateHistoryReader = (*State)(nil)
+var (
+	_ StateHistoryReader = (*State)(nil)
+	_ TrieReader         = (*State)(nil)
+)
 
 //go:generate mockgen -destination=../mocks/mock_state.go -package=mocks github.com/NethermindEth/juno/core StateHistoryReader
 type StateHistoryReader interface {
@@ -44,6 +47,14 @@ type StateReader interface {
 	Class(classHash *felt.Felt) (*DeclaredClass, error)
 }
 
+// TrieReader gives access to the Merkle tries the state commitment is computed from, e.g. to build proofs.
+// The returned tries must not be modified.
+type TrieReader interface {
+	ClassTrie() (*trie.Trie, error)
+	ContractTrie() (*trie.Trie, error)
+	ContractStorageTrie(addr *felt.Felt) (*trie.Trie, error)
+}
+
 type State struct {
 	*history
 	txn db.Transaction
@@ -117,11 +128,33 @@ func (s *State) Root() (*felt.Felt, error) {
 		return nil, err
 	}
 
+	return StateCommitment(storageRoot, classesRoot), nil
+}
+
+// StateCommitment returns the state commitment given the roots of the contracts and the classes tries.
+func StateCommitment(contractsRoot, classesRoot *felt.Felt) *felt.Felt {
 	if classesRoot.IsZero() {
-		return storageRoot, nil
+		return contractsRoot
 	}
+	return crypto.PoseidonArray(stateVersion, contractsRoot, classesRoot)
+}
+
+// ClassTrie returns the trie of declared Cairo 1 classes.
+func (s *State) ClassTrie() (*trie.Trie, error) {
+	// We don't need to call the closer function here because we are only reading the trie
+	tr, _, err := s.classesTrie()
+	return tr, err
+}
 
-	return crypto.PoseidonArray(stateVersion, storageRoot, classesRoot), nil
+// ContractTrie returns the trie of deployed contracts.
+func (s *State) ContractTrie() (*trie.Trie, error) {
+	tr, _, err := s.storage()
+	return tr, err
+}
+
+// ContractStorageTrie returns the storage trie of the contract at the given address.
+func (s *State) ContractStorageTrie(addr *felt.Felt) (*trie.Trie, error) {
+	return storage(addr, s.txn)
 }
 
 // storage returns a [core.Trie] that represents the Starknet global state in the given Txn context.
@@ -238,6 +271,43 @@ func (s *State) Update(blockNumber uint64, update *StateUpdate, declaredClasses
 	return s.verifyStateUpdateRoot(update.NewRoot)
 }
 
+// Apply applies diff to the State without verifying the state commitment or logging the values it replaces, so the
+// state before blockNumber can't be read afterwards. Contracts in the deployed contracts of diff that are already
+// deployed have their class replaced. It is used to write a state that is downloaded in parts, whose commitment can
+// only be verified once all of them are applied.
+func (s *State) Apply(blockNumber uint64, diff *StateDiff, declaredClasses map[felt.Felt]Class) error {
+	for cHash, class := range declaredClasses {
+		if err := s.putClass(&cHash, class, blockNumber); err != nil {
+			return err
+		}
+	}
+
+	if err := s.updateDeclaredClassesTrie(diff.DeclaredV1Classes, declaredClasses); err != nil {
+		return err
+	}
+
+	stateTrie, storageCloser, err := s.storage()
+	if err != nil {
+		return err
+	}
+
+	for addr, classHash := range diff.DeployedContracts {
+		err = s.putNewContract(stateTrie, &addr, classHash, blockNumber)
+		if errors.Is(err, ErrContractAlreadyDeployed) {
+			_, err = s.replaceContract(stateTrie, &addr, classHash)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if err = s.updateContracts(stateTrie, blockNumber, diff, false); err != nil {
+		return err
+	}
+
+	return storageCloser()
+}
+
 var (
 	noClassContractsClassHash = new(felt.Felt).SetUint64(0)
 
@@ -342,6 +412,29 @@ func (s *State) Class(classHash *felt.Felt) (*DeclaredClass, error) {
 	return &class, nil
 }
 
+// DeclaredClassHashes returns the hashes of up to limit declared classes whose hashes are at least start, in
+// ascending order, and whether there are more. Unlike Cairo 1 classes, Cairo 0 classes aren't in the classes trie.
+func (s *State) DeclaredClassHashes(start *felt.Felt, limit int) ([]*felt.Felt, bool, error) {
+	iterator, err := s.txn.NewIterator()
+	if err != nil {
+		return nil, false, err
+	}
+
+	var classHashes []*felt.Felt
+	prefix := db.Class.Key()
+	for iterator.Seek(db.Class.Key(start.Marshal())); iterator.Valid(); iterator.Next() {
+		key := iterator.Key()
+		if !bytes.HasPrefix(key, prefix) {
+			break
+		}
+		if len(classHashes) == limit {
+			return classHashes, true, iterator.Close()
+		}
+		classHashes = append(classHashes, new(felt.Felt).SetBytes(key[len(prefix):]))
+	}
+	return classHashes, false, iterator.Close()
+}
+
 func (s *State) updateStorageBuffered(contractAddr *felt.Felt, updateDiff map[felt.Felt]*felt.Felt, blockNumber uint64, logChanges bool) (
 	*db.BufferedTransaction, error,
 ) {
@@ -485,13 +578,14 @@ func (s *State) updateContractCommitment(stateTrie *trie.Trie, contract *Contrac
 		return err
 	}
 
-	commitment := calculateContractCommitment(root, cHash, nonce)
+	commitment := ContractCommitment(root, cHash, nonce)
 
 	_, err = stateTrie.Put(contract.Address, commitment)
 	return err
 }
 
-func calculateContractCommitment(storageRoot, classHash, nonce *felt.Felt) *felt.Felt {
+// ContractCommitment returns the value of the leaf of a contract in the contracts trie.
+func ContractCommitment(storageRoot, classHash, nonce *felt.Felt) *felt.Felt {
 	return crypto.Pedersen(crypto.Pedersen(crypto.Pedersen(classHash, storageRoot), nonce), &felt.Zero)
 }
 
@@ -506,8 +600,7 @@ func (s *State) updateDeclaredClassesTrie(declaredClasses map[felt.Felt]*felt.Fe
 			continue
 		}
 
-		leafValue := crypto.Poseidon(leafVersion, compiledClassHash)
-		if _, err = classesTrie.Put(&classHash, leafValue); err != nil {
+		if _, err = classesTrie.Put(&classHash, ClassCommitmentLeaf(compiledClassHash)); err != nil {
 			return err
 		}
 	}
@@ -515,6 +608,11 @@ func (s *State) updateDeclaredClassesTrie(declaredClasses map[felt.Felt]*felt.Fe
 	return classesCloser()
 }
 
+// ClassCommitmentLeaf returns the value of the leaf of a Cairo 1 class in the classes trie.
+func ClassCommitmentLeaf(compiledClassHash *felt.Felt) *felt.Felt {
+	return crypto.Poseidon(leafVersion, compiledClassHash)
+}
+
 // ContractIsAlreadyDeployedAt returns if contract at given addr was deployed at blockNumber
 func (s *State) ContractIsAlreadyDeployedAt(addr *felt.Felt, blockNumber uint64) (bool, error) {
 	var deployedAt uint64
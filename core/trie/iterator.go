@@ -0,0 +1,64 @@
+package trie
+
+import (
+	"bytes"
+
+	"github.com/NethermindEth/juno/core/felt"
+)
+
+// IterateLeaves calls consume with the key and value of each leaf whose key is at least start, in ascending order of
+// keys, until consume returns false. It returns false if consume stopped the iteration.
+func (t *Trie) IterateLeaves(start *felt.Felt, consume func(key, value *felt.Felt) (bool, error)) (bool, error) {
+	if t.rootKey == nil {
+		return true, nil
+	}
+
+	startKey := t.feltToKey(start)
+	return t.iterateLeaves(t.rootKey, &startKey, consume)
+}
+
+// iterateLeaves visits the leaves below the node at key. start is nil once all of them are known to be at least the
+// start of the iteration.
+func (t *Trie) iterateLeaves(key, start *Key, consume func(key, value *felt.Felt) (bool, error)) (bool, error) {
+	if start != nil {
+		// Compare the node with the node at the same depth on the path to start, to skip the subtrees whose leaves
+		// are all before start.
+		startPrefix, err := start.SubKey(key.Len())
+		if err != nil {
+			return false, err
+		}
+		switch bytes.Compare(key.bitset[:], startPrefix.bitset[:]) {
+		case -1:
+			return true, nil
+		case 1:
+			start = nil
+		}
+	}
+
+	node, err := t.storage.Get(key)
+	if err != nil {
+		return false, err
+	}
+
+	if key.Len() == t.height {
+		leafKey, leafValue := key.Felt(), *node.Value
+		nodePool.Put(node)
+		return consume(&leafKey, &leafValue)
+	}
+
+	// The child keys are copied, as the node goes back to the pool before its children are visited.
+	var children []Key
+	for _, child := range []*Key{node.Left, node.Right} {
+		if child != nil {
+			children = append(children, *child)
+		}
+	}
+	nodePool.Put(node)
+
+	for i := range children {
+		if more, err := t.iterateLeaves(&children[i], start, consume); err != nil || !more {
+			return more, err
+		}
+	}
+	return true, nil
+}
@@ -29,19 +29,10 @@ func (k *Key) SubKey(n uint8) (*Key, error) {
 		return nil, errors.New(fmt.Sprint("cannot subtract key of length %i from key of length %i", n, k.len))
 	}
 
-	newKey := &Key{len: n}
-	copy(newKey.bitset[:], k.bitset[len(k.bitset)-int((k.len+7)/8):]) //nolint:mnd
-
-	// Shift right by the number of bits that are not needed
-	shift := k.len - n
-	for i := len(newKey.bitset) - 1; i >= 0; i-- {
-		newKey.bitset[i] >>= shift
-		if i > 0 {
-			newKey.bitset[i] |= newKey.bitset[i-1] << (8 - shift)
-		}
-	}
-
-	return newKey, nil
+	// Drop the bits that are not needed from the bottom of a copy
+	newKey := *k
+	newKey.DeleteLSB(k.len - n)
+	return &newKey, nil
 }
 
 func (k *Key) bytesNeeded() uint {
@@ -109,6 +109,43 @@ func TestDeleteLSB(t *testing.T) {
 	}
 }
 
+func TestSubKey(t *testing.T) {
+	key := trie.NewKey(16, []byte{0xF3, 0x04})
+
+	tests := map[string]struct {
+		length      uint8
+		expectedKey trie.Key
+	}{
+		"whole key": {
+			length:      16,
+			expectedKey: key,
+		},
+		"top 12 bits": {
+			length:      12,
+			expectedKey: trie.NewKey(12, []byte{0x0F, 0x30}),
+		},
+		"top 7 bits": {
+			length:      7,
+			expectedKey: trie.NewKey(7, []byte{0x79}),
+		},
+		"no bits": {
+			length:      0,
+			expectedKey: trie.NewKey(0, []byte{}),
+		},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			subKey, err := key.SubKey(test.length)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedKey, *subKey)
+		})
+	}
+
+	_, err := key.SubKey(17)
+	require.Error(t, err)
+}
+
 func TestTruncate(t *testing.T) {
 	tests := map[string]struct {
 		key         trie.Key
@@ -3,10 +3,15 @@ package trie
 import (
 	"errors"
 	"fmt"
+	"math/big"
+	"slices"
 
 	"github.com/NethermindEth/juno/core/felt"
 )
 
+// rangeProofHeight is the height of the tries range proofs are verified for.
+const rangeProofHeight = 251
+
 var (
 	ErrUnknownProofNode  = errors.New("unknown proof node")
 	ErrChildHashNotFound = errors.New("can't determine the child hash from the parent and child")
@@ -349,7 +354,7 @@ func VerifyProof(root *felt.Felt, key *Key, value *felt.Felt, proofs []ProofNode
 				return false
 			}
 			expectedHash = proofNode.Child
-			remainingPath.Truncate(251 - proofNode.Path.Len()) //nolint:mnd
+			remainingPath.Truncate(remainingPath.Len() - proofNode.Path.Len())
 		}
 	}
 
@@ -357,10 +362,11 @@ func VerifyProof(root *felt.Felt, key *Key, value *felt.Felt, proofs []ProofNode
 }
 
 // VerifyRangeProof verifies the range proof for the given range of keys.
-// This is achieved by constructing a trie from the boundary proofs, and the supplied key-values.
-// If the root of the reconstructed trie matches the supplied root, then the verification passes.
-// If the trie is constructed incorrectly then the root will have an incorrect key(len,path), and value,
-// and therefore it's hash won't match the expected root.
+// The proofs are of the boundaries of the range: a key before the first key, and a key after the last key. A missing
+// proof means that the range extends to the edge of the trie, and a missing value means that the boundary key isn't
+// set. The trie is then rehashed from the keys and boundaries, and the subtrees the proofs hash left of the left
+// boundary and right of the right boundary. If the recomputed root matches the supplied root, the keys are all the
+// keys of the trie between the boundaries.
 // ref: https://github.com/ethereum/go-ethereum/blob/v1.14.3/trie/proof.go#L484
 func VerifyRangeProof(root *felt.Felt, keys, values []*felt.Felt, proofKeys [2]*Key, proofValues [2]*felt.Felt,
 	proofs [2][]ProofNode, hash hashFunc,
@@ -382,32 +388,38 @@ func VerifyRangeProof(root *felt.Felt, keys, values []*felt.Felt, proofKeys [2]*
 		}
 	}
 
-	// Step 1: Verify proofs, and get proof paths
-	var proofPaths [2][]StorageNode
-	var err error
-	for i := 0; i < 2; i++ {
-		if proofs[i] != nil {
-			if !VerifyProof(root, proofKeys[i], proofValues[i], proofs[i], hash) {
-				return false, fmt.Errorf("invalid proof for key %x", proofKeys[i].String())
-			}
-
-			proofPaths[i], err = ProofToPath(proofs[i], proofKeys[i], hash)
-			if err != nil {
-				return false, err
-			}
+	// Step 1: collect the leaves and subtrees the proofs and keys hash
+	items := make([]rangeItem, 0, len(keys)+2*rangeProofHeight)
+	if proofs[0] != nil {
+		leftItems, err := boundaryItems(proofKeys[0], proofValues[0], proofs[0], false, hash)
+		if err != nil {
+			return false, err
 		}
+		items = append(items, leftItems...)
 	}
-
-	// Step 2: Build trie from proofPaths and keys
-	tmpTrie, err := BuildTrie(proofPaths[0], proofPaths[1], keys, values)
-	if err != nil {
-		return false, err
+	for i, key := range keys {
+		keyBytes := key.Bytes()
+		items = append(items, rangeItem{key: NewKey(rangeProofHeight, keyBytes[:]), depth: rangeProofHeight, hash: values[i]})
 	}
+	if proofs[1] != nil {
+		rightItems, err := boundaryItems(proofKeys[1], proofValues[1], proofs[1], true, hash)
+		if err != nil {
+			return false, err
+		}
+		items = append(items, rightItems...)
+	}
+	slices.SortFunc(items, func(a, b rangeItem) int {
+		aFelt, bFelt := a.key.Felt(), b.key.Felt()
+		return aFelt.Cmp(&bFelt)
+	})
 
-	// Verify that the recomputed root hash matches the provided root hash
-	recomputedRoot, err := tmpTrie.Root()
-	if err != nil {
-		return false, err
+	// Step 2: verify that the recomputed root hash matches the provided root hash
+	recomputedRoot := &felt.Zero
+	if len(items) > 0 {
+		var err error
+		if recomputedRoot, err = rangeRoot(items, 0, hash); err != nil {
+			return false, err
+		}
 	}
 	if !recomputedRoot.Equal(root) {
 		return false, errors.New("root hash mismatch")
@@ -416,7 +428,143 @@ func VerifyRangeProof(root *felt.Felt, keys, values []*felt.Felt, proofKeys [2]*
 	return true, nil
 }
 
+// rangeItem is a leaf, or a subtree which is only known by its hash, of a trie being rehashed from a range proof.
+type rangeItem struct {
+	key   Key // the key of the leaf, or any key in the subtree
+	depth uint8
+	hash  *felt.Felt
+}
+
+// boundaryItems walks the proof of a boundary key and returns the subtrees it hashes outside the range, along with the
+// boundary leaf if it is set. These are the subtrees left of the left boundary, or right of the right boundary.
+func boundaryItems(key *Key, value *felt.Felt, proof []ProofNode, right bool, hash hashFunc) ([]rangeItem, error) {
+	if key == nil || key.Len() != rangeProofHeight {
+		return nil, errors.New("invalid boundary key")
+	}
+
+	var items []rangeItem
+	// subtreeKey returns the lowest key under path from the node of the boundary's path at depth.
+	subtreeKey := func(depth uint8, path *Key) Key {
+		subKey := *key
+		subKey.DeleteLSB(key.Len() - depth)
+		prefix := subKey.Felt()
+		pathFelt := path.Felt()
+		subFelt := new(big.Int).Lsh(prefix.BigInt(new(big.Int)), uint(path.Len()))
+		subFelt.Or(subFelt, pathFelt.BigInt(new(big.Int)))
+		subFelt.Lsh(subFelt, uint(key.Len()-depth-path.Len()))
+		subBytes := subFelt.FillBytes(make([]byte, felt.Bytes))
+		return NewKey(rangeProofHeight, subBytes)
+	}
+
+	var depth uint8
+	for _, node := range proof {
+		if depth == key.Len() {
+			return nil, errors.New("proof continues past the leaf")
+		}
+		switch node := node.(type) {
+		case *Binary:
+			goesRight := key.Test(key.Len() - depth - 1)
+			sibling := NewKey(1, []byte{0})
+			if !goesRight {
+				sibling = NewKey(1, []byte{1})
+			}
+			if goesRight != right {
+				siblingHash := node.LeftHash
+				if right {
+					siblingHash = node.RightHash
+				}
+				items = append(items, rangeItem{key: subtreeKey(depth, &sibling), depth: depth + 1, hash: siblingHash})
+			}
+			depth++
+		case *Edge:
+			if node.Path == nil || node.Child == nil || depth+node.Path.Len() > key.Len() {
+				return nil, errors.New("invalid edge in proof")
+			}
+			keyPath := *key
+			keyPath.DeleteLSB(key.Len() - depth - node.Path.Len())
+			keyPath.Truncate(node.Path.Len())
+			if !keyPath.Equal(node.Path) {
+				// The boundary isn't set, and the subtree of the edge is entirely on one side of it.
+				if value != nil {
+					return nil, fmt.Errorf("proof of key %s doesn't reach its value", key)
+				}
+				keyFelt, pathFelt := keyPath.Felt(), node.Path.Felt()
+				if (pathFelt.Cmp(&keyFelt) > 0) == right {
+					items = append(items, rangeItem{key: subtreeKey(depth, node.Path), depth: depth, hash: node.Hash(hash)})
+				}
+				return items, nil
+			}
+			depth += node.Path.Len()
+		default:
+			return nil, ErrUnknownProofNode
+		}
+	}
+
+	if depth != key.Len() {
+		return nil, fmt.Errorf("proof of key %s ends before the leaf", key)
+	}
+	if value == nil {
+		return nil, fmt.Errorf("proof of key %s reaches a value that isn't supplied", key)
+	}
+	return append(items, rangeItem{key: *key, depth: depth, hash: value}), nil
+}
+
+// rangeRoot returns the hash of the subtree at depth that holds the given items, which are sorted by key.
+func rangeRoot(items []rangeItem, depth uint8, hash hashFunc) (*felt.Felt, error) {
+	// Find the depth at which the items branch out, or the depth of the only item.
+	minDepth := items[0].depth
+	for _, item := range items[1:] {
+		minDepth = min(minDepth, item.depth)
+	}
+	first, last := items[0].key, items[len(items)-1].key
+	branch := depth
+	for branch < minDepth && first.Test(rangeProofHeight-branch-1) == last.Test(rangeProofHeight-branch-1) {
+		branch++
+	}
+
+	var nodeHash *felt.Felt
+	switch {
+	case len(items) == 1:
+		nodeHash = items[0].hash
+	case branch == minDepth:
+		return nil, errors.New("proof subtrees overlap the range")
+	default:
+		split, _ := slices.BinarySearchFunc(items, true, func(item rangeItem, _ bool) int {
+			if item.key.Test(rangeProofHeight - branch - 1) {
+				return 0
+			}
+			return -1
+		})
+		left, err := rangeRoot(items[:split], branch+1, hash)
+		if err != nil {
+			return nil, err
+		}
+		right, err := rangeRoot(items[split:], branch+1, hash)
+		if err != nil {
+			return nil, err
+		}
+		nodeHash = hash(left, right)
+	}
+
+	if branch == depth {
+		return nodeHash, nil
+	}
+	path := first
+	path.DeleteLSB(rangeProofHeight - branch)
+	path.Truncate(branch - depth)
+	return (&Edge{Child: nodeHash, Path: &path}).Hash(hash), nil
+}
+
 func ensureMonotonicIncreasing(proofKeys [2]*Key, keys []*felt.Felt) error {
+	if proofKeys[0] != nil && proofKeys[1] != nil {
+		leftProofFelt, rightProofFelt := proofKeys[0].Felt(), proofKeys[1].Felt()
+		if leftProofFelt.Cmp(&rightProofFelt) >= 0 {
+			return errors.New("range is not monotonically increasing")
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
 	if proofKeys[0] != nil {
 		leftProofFelt := proofKeys[0].Felt()
 		if leftProofFelt.Cmp(keys[0]) >= 0 {
@@ -1,6 +1,7 @@
 package trie_test
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/NethermindEth/juno/core/crypto"
@@ -556,6 +557,27 @@ func TestVerifyProof(t *testing.T) {
 		assert.True(t, trie.VerifyProof(root, &leafkey, val6, expectedProofNodes, crypto.Pedersen))
 	})
 
+	t.Run("VP edges between binary nodes", func(t *testing.T) {
+		keys := []uint64{0b0000, 0b1100, 0b1110, 0b1111, 0x123456, 0x123457, 99, 100000}
+		require.NoError(t, trie.RunOnTempTriePedersen(251, func(tempTrie *trie.Trie) error {
+			for _, k := range keys {
+				_, err := tempTrie.Put(new(felt.Felt).SetUint64(k), new(felt.Felt).SetUint64(k+1))
+				require.NoError(t, err)
+			}
+			require.NoError(t, tempTrie.Commit())
+
+			root, err := tempTrie.Root()
+			require.NoError(t, err)
+			for _, k := range keys {
+				leafKey := tempTrie.FeltToKey(new(felt.Felt).SetUint64(k))
+				proofNodes, err := trie.GetProof(&leafKey, tempTrie)
+				require.NoError(t, err)
+				assert.True(t, trie.VerifyProof(root, &leafKey, new(felt.Felt).SetUint64(k+1), proofNodes, crypto.Pedersen), k)
+			}
+			return nil
+		}))
+	})
+
 	t.Run("VP  non existent key - less than root edge", func(t *testing.T) {
 		tempTrie, _ := buildSimpleDoubleBinaryTrie(t)
 
@@ -876,6 +898,81 @@ func TestVerifyRangeProof(t *testing.T) {
 	})
 }
 
+func TestVerifyRangeProofRandomRanges(t *testing.T) {
+	memdb := pebble.NewMemTest(t)
+	txn, err := memdb.NewTransaction(true)
+	require.NoError(t, err)
+	tri, err := trie.NewTriePedersen(trie.NewStorage(txn, []byte{0}), 251)
+	require.NoError(t, err)
+
+	const numKeys = 20
+	keys := make([]*felt.Felt, 0, numKeys)
+	values := make(map[felt.Felt]*felt.Felt, numKeys)
+	for range numKeys {
+		key, err := new(felt.Felt).SetRandom()
+		require.NoError(t, err)
+		// Keys are 251 bits long.
+		key.SetBytes(key.Marshal()[1:])
+		keys = append(keys, key)
+		values[*key] = new(felt.Felt).SetUint64(uint64(len(keys)))
+		_, err = tri.Put(key, values[*key])
+		require.NoError(t, err)
+	}
+	slices.SortFunc(keys, func(a, b *felt.Felt) int { return a.Cmp(b) })
+	root, err := tri.Root()
+	require.NoError(t, err)
+
+	leafKey := func(f *felt.Felt) *trie.Key {
+		fBytes := f.Bytes()
+		key := trie.NewKey(251, fBytes[:])
+		return &key
+	}
+	// verify verifies the leaves from first to last, bounded by the leaves around them.
+	verify := func(first, last int, rangeKeys, rangeValues []*felt.Felt) error {
+		var (
+			proofKeys   [2]*trie.Key
+			proofValues [2]*felt.Felt
+			proofs      [2][]trie.ProofNode
+		)
+		if first > 0 {
+			proofKeys[0], proofValues[0] = leafKey(keys[first-1]), values[*keys[first-1]]
+		}
+		if last < len(keys)-1 {
+			proofKeys[1], proofValues[1] = leafKey(keys[last+1]), values[*keys[last+1]]
+		}
+		for i, key := range proofKeys {
+			if key != nil {
+				proofs[i], err = trie.GetProof(key, tri)
+				require.NoError(t, err)
+			}
+		}
+		_, err := trie.VerifyRangeProof(root, rangeKeys, rangeValues, proofKeys, proofValues, proofs, crypto.Pedersen)
+		return err
+	}
+
+	for first := range keys {
+		for last := first; last < len(keys); last++ {
+			rangeKeys := keys[first : last+1]
+			rangeValues := make([]*felt.Felt, 0, len(rangeKeys))
+			for _, key := range rangeKeys {
+				rangeValues = append(rangeValues, values[*key])
+			}
+			require.NoError(t, verify(first, last, rangeKeys, rangeValues), "range %d-%d", first, last)
+
+			if len(rangeKeys) > 1 {
+				dropped := len(rangeKeys) / 2
+				droppedKeys := slices.Delete(slices.Clone(rangeKeys), dropped, dropped+1)
+				droppedValues := slices.Delete(slices.Clone(rangeValues), dropped, dropped+1)
+				assert.Error(t, verify(first, last, droppedKeys, droppedValues), "range %d-%d without a key", first, last)
+			}
+
+			changedValues := slices.Clone(rangeValues)
+			changedValues[0] = new(felt.Felt).SetUint64(numKeys + 1)
+			assert.Error(t, verify(first, last, rangeKeys, changedValues), "range %d-%d with a changed value", first, last)
+		}
+	}
+}
+
 func TestMergeProofPaths(t *testing.T) {
 	t.Run("3Key Trie no duplicates and all values exist in merged path", func(t *testing.T) {
 		tri := build3KeyTrie(t)
@@ -93,6 +93,16 @@ func RunOnTempTriePoseidon(height uint8, do func(*Trie) error) error {
 	return do(trie)
 }
 
+// FeltToKey converts a key, given in felt, to the [Key] of the leaf it is stored at.
+func (t *Trie) FeltToKey(k *felt.Felt) Key {
+	return t.feltToKey(k)
+}
+
+// HashFunc returns the function the trie uses to hash its nodes.
+func (t *Trie) HashFunc() hashFunc {
+	return t.hash
+}
+
 // feltToBitSet Converts a key, given in felt, to a trie.Key which when followed on a [Trie],
 // leads to the corresponding [Node]
 func (t *Trie) feltToKey(k *felt.Felt) Key {
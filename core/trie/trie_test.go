@@ -380,3 +380,54 @@ func BenchmarkTriePut(b *testing.B) {
 		return t.Commit()
 	}))
 }
+
+func TestIterateLeaves(t *testing.T) {
+	collect := func(tt *trie.Trie, start uint64, limit int) ([]uint64, bool) {
+		var keys []uint64
+		more, err := tt.IterateLeaves(new(felt.Felt).SetUint64(start), func(key, value *felt.Felt) (bool, error) {
+			if len(keys) == limit {
+				return false, nil
+			}
+			assert.Equal(t, new(felt.Felt).Add(key, new(felt.Felt).SetUint64(1)), value)
+			keys = append(keys, key.Uint64())
+			return true, nil
+		})
+		require.NoError(t, err)
+		return keys, !more
+	}
+
+	t.Run("empty trie", func(t *testing.T) {
+		require.NoError(t, trie.RunOnTempTriePedersen(251, func(tt *trie.Trie) error {
+			keys, stopped := collect(tt, 0, 10)
+			assert.Empty(t, keys)
+			assert.False(t, stopped)
+			return nil
+		}))
+	})
+
+	t.Run("leaves in ascending order from start", func(t *testing.T) {
+		require.NoError(t, trie.RunOnTempTriePedersen(251, func(tt *trie.Trie) error {
+			for _, key := range []uint64{9, 2, 1000, 3, 64, 7} {
+				_, err := tt.Put(new(felt.Felt).SetUint64(key), new(felt.Felt).SetUint64(key+1))
+				require.NoError(t, err)
+			}
+			require.NoError(t, tt.Commit())
+
+			keys, stopped := collect(tt, 0, 10)
+			assert.Equal(t, []uint64{2, 3, 7, 9, 64, 1000}, keys)
+			assert.False(t, stopped)
+
+			keys, stopped = collect(tt, 8, 10)
+			assert.Equal(t, []uint64{9, 64, 1000}, keys)
+			assert.False(t, stopped)
+
+			keys, stopped = collect(tt, 3, 2)
+			assert.Equal(t, []uint64{3, 7}, keys)
+			assert.True(t, stopped)
+
+			keys, _ = collect(tt, 1001, 10)
+			assert.Empty(t, keys)
+			return nil
+		}))
+	})
+}
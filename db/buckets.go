@@ -34,6 +34,9 @@ const (
 	Temporary // used temporarily for migrations
 	SchemaIntermediateState
 	L1HandlerTxnHashByMsgHash // maps l1 handler msg hash to l1 handler txn hash
+	EventIndex                // maps event emitters and first keys to bitmaps of the blocks that have them
+	BlockTraces               // maps block hashes to the traces of their transactions
+	StateHistoryStart         // number of the oldest block whose state can be read, once state history is pruned
 )
 
 // Key flattens a prefix and series of byte arrays into a single []byte.
@@ -113,6 +113,12 @@ func (d *DB) Update(fn func(txn db.Transaction) error) error {
 	return db.Update(d, fn)
 }
 
+// Checkpoint writes a consistent point-in-time copy of the database to destDir, which must not exist yet.
+// It can be called while the database is being written to.
+func (d *DB) Checkpoint(destDir string) error {
+	return d.pebble.Checkpoint(destDir, pebble.WithFlushedWAL())
+}
+
 // Impl : see db.DB.Impl
 func (d *DB) Impl() any {
 	return d.pebble
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/binary"
 	"fmt"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
@@ -471,3 +472,42 @@ func TestCalculatePrefixSize(t *testing.T) {
 		})
 	})
 }
+
+func TestCheckpoint(t *testing.T) {
+	testDB, err := pebble.New(t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, testDB.Close())
+	})
+
+	key, value := []byte("key"), []byte("value")
+	require.NoError(t, testDB.Update(func(txn db.Transaction) error {
+		return txn.Set(key, value)
+	}))
+
+	checkpointDir := filepath.Join(t.TempDir(), "checkpoint")
+	require.NoError(t, testDB.(*pebble.DB).Checkpoint(checkpointDir))
+
+	// Writes after the checkpoint are not part of it.
+	require.NoError(t, testDB.Update(func(txn db.Transaction) error {
+		return txn.Set([]byte("other"), value)
+	}))
+
+	checkpointDB, err := pebble.New(checkpointDir)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, checkpointDB.Close())
+	})
+	require.NoError(t, checkpointDB.View(func(txn db.Transaction) error {
+		require.NoError(t, txn.Get(key, func(val []byte) error {
+			assert.Equal(t, value, val)
+			return nil
+		}))
+		assert.ErrorIs(t, txn.Get([]byte("other"), noop), db.ErrKeyNotFound)
+		return nil
+	}))
+
+	t.Run("destination already exists", func(t *testing.T) {
+		assert.Error(t, testDB.(*pebble.DB).Checkpoint(checkpointDir))
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/NethermindEth/juno/grpc/gen"
 	"github.com/NethermindEth/juno/utils"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 var _ db.DB = (*DB)(nil)
@@ -54,6 +55,11 @@ func (d *DB) NewTransaction(write bool) (db.Transaction, error) {
 	return &transaction{client: txClient, log: d.log}, nil
 }
 
+// Heads streams the changes of the chain head of the node serving the database.
+func (d *DB) Heads(ctx context.Context) (gen.KV_HeadsClient, error) {
+	return d.kvClient.Heads(ctx, &emptypb.Empty{})
+}
+
 func (d *DB) View(fn func(txn db.Transaction) error) error {
 	return db.View(d, fn)
 }
@@ -0,0 +1,148 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db/pebble"
+	"github.com/NethermindEth/juno/migration"
+	"github.com/NethermindEth/juno/utils"
+)
+
+// A snapshot is a directory holding a Pebble checkpoint of the database and a manifest describing it:
+//
+//	<dir>/manifest.json
+//	<dir>/db/...
+const (
+	ManifestFile = "manifest.json"
+	databaseDir  = "db"
+)
+
+var ErrNetworkMismatch = errors.New("snapshot was created for a different network")
+
+type Manifest struct {
+	Network       string     `json:"network"`
+	HeadNumber    uint64     `json:"head_number"`
+	HeadHash      *felt.Felt `json:"head_hash"`
+	StateRoot     *felt.Felt `json:"state_root"`
+	SchemaVersion uint64     `json:"schema_version"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// Create writes a checkpoint of database and its manifest to dir, which must not exist yet. It refuses databases
+// that don't hold the chain of network and removes dir if anything fails. The database can be in use by a running node.
+func Create(database *pebble.DB, network *utils.Network, dir string) (*Manifest, error) {
+	if _, err := os.Stat(dir); err == nil {
+		return nil, fmt.Errorf("snapshot directory %s already exists", dir)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	manifest, err := writeSnapshot(database, network, dir)
+	if err != nil {
+		return nil, errors.Join(err, os.RemoveAll(dir))
+	}
+	return manifest, nil
+}
+
+func writeSnapshot(database *pebble.DB, network *utils.Network, dir string) (*Manifest, error) {
+	checkpointDir := filepath.Join(dir, databaseDir)
+	if err := database.Checkpoint(checkpointDir); err != nil {
+		return nil, fmt.Errorf("create checkpoint: %w", err)
+	}
+
+	// The manifest is read from the checkpoint rather than from the live database, which may have moved on since.
+	manifest, err := readManifestFromDB(checkpointDir, network)
+	if err != nil {
+		return nil, err
+	}
+
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err = os.WriteFile(filepath.Join(dir, ManifestFile), manifestJSON, 0o644); err != nil { //nolint:gosec
+		return nil, err
+	}
+	return manifest, nil
+}
+
+func readManifestFromDB(path string, network *utils.Network) (*Manifest, error) {
+	database, err := pebble.New(path)
+	if err != nil {
+		return nil, err
+	}
+	defer database.Close()
+
+	chain := blockchain.New(database, network)
+	// The manifest is stamped with network, so the database has to hold its chain.
+	if err = chain.VerifyNetwork(); err != nil {
+		return nil, err
+	}
+	head, err := chain.HeadsHeader()
+	if err != nil {
+		return nil, fmt.Errorf("get head: %w", err)
+	}
+	schema, err := migration.SchemaMetadata(database)
+	if err != nil {
+		return nil, fmt.Errorf("get schema version: %w", err)
+	}
+
+	return &Manifest{
+		Network:       network.Name,
+		HeadNumber:    head.Number,
+		HeadHash:      head.Hash,
+		StateRoot:     head.GlobalStateRoot,
+		SchemaVersion: schema.Version,
+		CreatedAt:     time.Now().UTC(),
+	}, nil
+}
+
+// ReadManifest reads the manifest of the snapshot in dir.
+func ReadManifest(dir string) (*Manifest, error) {
+	manifestJSON, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest Manifest
+	if err = json.Unmarshal(manifestJSON, &manifest); err != nil {
+		return nil, fmt.Errorf("decode manifest: %w", err)
+	}
+	return &manifest, nil
+}
+
+// Restore copies the database of the snapshot in dir to dbPath, which must not exist or be empty. It refuses
+// snapshots that were created for a different network.
+func Restore(dir, dbPath string, network *utils.Network) (*Manifest, error) {
+	manifest, err := ReadManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	if manifest.Network != network.Name {
+		return nil, fmt.Errorf("%w: snapshot has %q, expected %q", ErrNetworkMismatch, manifest.Network, network.Name)
+	}
+
+	entries, err := os.ReadDir(dbPath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	if len(entries) > 0 {
+		return nil, fmt.Errorf("database path %s is not empty", dbPath)
+	}
+
+	if err = os.CopyFS(dbPath, os.DirFS(filepath.Join(dir, databaseDir))); err != nil {
+		return nil, fmt.Errorf("copy database: %w", err)
+	}
+	return manifest, nil
+}
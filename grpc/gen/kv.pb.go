@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.31.0
+// 	protoc-gen-go v1.35.2
 // 	protoc        v3.21.12
 // source: kv.proto
 
@@ -100,11 +100,9 @@ type Cursor struct {
 
 func (x *Cursor) Reset() {
 	*x = Cursor{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_kv_proto_msgTypes[0]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_kv_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *Cursor) String() string {
@@ -115,7 +113,7 @@ func (*Cursor) ProtoMessage() {}
 
 func (x *Cursor) ProtoReflect() protoreflect.Message {
 	mi := &file_kv_proto_msgTypes[0]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -179,11 +177,9 @@ type Pair struct {
 
 func (x *Pair) Reset() {
 	*x = Pair{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_kv_proto_msgTypes[1]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_kv_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *Pair) String() string {
@@ -194,7 +190,7 @@ func (*Pair) ProtoMessage() {}
 
 func (x *Pair) ProtoReflect() protoreflect.Message {
 	mi := &file_kv_proto_msgTypes[1]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -256,11 +252,9 @@ type VersionReply struct {
 
 func (x *VersionReply) Reset() {
 	*x = VersionReply{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_kv_proto_msgTypes[2]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_kv_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *VersionReply) String() string {
@@ -271,7 +265,7 @@ func (*VersionReply) ProtoMessage() {}
 
 func (x *VersionReply) ProtoReflect() protoreflect.Message {
 	mi := &file_kv_proto_msgTypes[2]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -307,6 +301,268 @@ func (x *VersionReply) GetPatch() uint32 {
 	return 0
 }
 
+type HeadEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Event:
+	//	*HeadEvent_NewHead
+	//	*HeadEvent_Reorg
+	//	*HeadEvent_PendingTxs
+	Event isHeadEvent_Event `protobuf_oneof:"event"`
+}
+
+func (x *HeadEvent) Reset() {
+	*x = HeadEvent{}
+	mi := &file_kv_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeadEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeadEvent) ProtoMessage() {}
+
+func (x *HeadEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_kv_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeadEvent.ProtoReflect.Descriptor instead.
+func (*HeadEvent) Descriptor() ([]byte, []int) {
+	return file_kv_proto_rawDescGZIP(), []int{3}
+}
+
+func (m *HeadEvent) GetEvent() isHeadEvent_Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (x *HeadEvent) GetNewHead() *NewHead {
+	if x, ok := x.GetEvent().(*HeadEvent_NewHead); ok {
+		return x.NewHead
+	}
+	return nil
+}
+
+func (x *HeadEvent) GetReorg() *Reorg {
+	if x, ok := x.GetEvent().(*HeadEvent_Reorg); ok {
+		return x.Reorg
+	}
+	return nil
+}
+
+func (x *HeadEvent) GetPendingTxs() *PendingTxs {
+	if x, ok := x.GetEvent().(*HeadEvent_PendingTxs); ok {
+		return x.PendingTxs
+	}
+	return nil
+}
+
+type isHeadEvent_Event interface {
+	isHeadEvent_Event()
+}
+
+type HeadEvent_NewHead struct {
+	NewHead *NewHead `protobuf:"bytes,1,opt,name=new_head,json=newHead,proto3,oneof"`
+}
+
+type HeadEvent_Reorg struct {
+	Reorg *Reorg `protobuf:"bytes,2,opt,name=reorg,proto3,oneof"`
+}
+
+type HeadEvent_PendingTxs struct {
+	PendingTxs *PendingTxs `protobuf:"bytes,3,opt,name=pending_txs,json=pendingTxs,proto3,oneof"`
+}
+
+func (*HeadEvent_NewHead) isHeadEvent_Event() {}
+
+func (*HeadEvent_Reorg) isHeadEvent_Event() {}
+
+func (*HeadEvent_PendingTxs) isHeadEvent_Event() {}
+
+// A block was stored as the new head.
+type NewHead struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Number uint64 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	Hash   []byte `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+}
+
+func (x *NewHead) Reset() {
+	*x = NewHead{}
+	mi := &file_kv_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NewHead) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewHead) ProtoMessage() {}
+
+func (x *NewHead) ProtoReflect() protoreflect.Message {
+	mi := &file_kv_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewHead.ProtoReflect.Descriptor instead.
+func (*NewHead) Descriptor() ([]byte, []int) {
+	return file_kv_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *NewHead) GetNumber() uint64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *NewHead) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+// Blocks were reverted, from the first to the last known block of the orphaned chain.
+type Reorg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StartBlockHash []byte `protobuf:"bytes,1,opt,name=start_block_hash,json=startBlockHash,proto3" json:"start_block_hash,omitempty"`
+	StartBlockNum  uint64 `protobuf:"varint,2,opt,name=start_block_num,json=startBlockNum,proto3" json:"start_block_num,omitempty"`
+	EndBlockHash   []byte `protobuf:"bytes,3,opt,name=end_block_hash,json=endBlockHash,proto3" json:"end_block_hash,omitempty"`
+	EndBlockNum    uint64 `protobuf:"varint,4,opt,name=end_block_num,json=endBlockNum,proto3" json:"end_block_num,omitempty"`
+}
+
+func (x *Reorg) Reset() {
+	*x = Reorg{}
+	mi := &file_kv_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reorg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reorg) ProtoMessage() {}
+
+func (x *Reorg) ProtoReflect() protoreflect.Message {
+	mi := &file_kv_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reorg.ProtoReflect.Descriptor instead.
+func (*Reorg) Descriptor() ([]byte, []int) {
+	return file_kv_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Reorg) GetStartBlockHash() []byte {
+	if x != nil {
+		return x.StartBlockHash
+	}
+	return nil
+}
+
+func (x *Reorg) GetStartBlockNum() uint64 {
+	if x != nil {
+		return x.StartBlockNum
+	}
+	return 0
+}
+
+func (x *Reorg) GetEndBlockHash() []byte {
+	if x != nil {
+		return x.EndBlockHash
+	}
+	return nil
+}
+
+func (x *Reorg) GetEndBlockNum() uint64 {
+	if x != nil {
+		return x.EndBlockNum
+	}
+	return 0
+}
+
+// Transactions were added to the pending block.
+type PendingTxs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TransactionHashes [][]byte `protobuf:"bytes,1,rep,name=transaction_hashes,json=transactionHashes,proto3" json:"transaction_hashes,omitempty"`
+}
+
+func (x *PendingTxs) Reset() {
+	*x = PendingTxs{}
+	mi := &file_kv_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PendingTxs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PendingTxs) ProtoMessage() {}
+
+func (x *PendingTxs) ProtoReflect() protoreflect.Message {
+	mi := &file_kv_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PendingTxs.ProtoReflect.Descriptor instead.
+func (*PendingTxs) Descriptor() ([]byte, []int) {
+	return file_kv_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PendingTxs) GetTransactionHashes() [][]byte {
+	if x != nil {
+		return x.TransactionHashes
+	}
+	return nil
+}
+
 var File_kv_proto protoreflect.FileDescriptor
 
 var file_kv_proto_rawDesc = []byte{
@@ -332,22 +588,54 @@ var file_kv_proto_rawDesc = []byte{
 	0x05, 0x6d, 0x61, 0x6a, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6d, 0x61,
 	0x6a, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x69, 0x6e, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01,
 	0x28, 0x0d, 0x52, 0x05, 0x6d, 0x69, 0x6e, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x61, 0x74,
-	0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x70, 0x61, 0x74, 0x63, 0x68, 0x2a,
-	0x5e, 0x0a, 0x02, 0x4f, 0x70, 0x12, 0x09, 0x0a, 0x05, 0x46, 0x49, 0x52, 0x53, 0x54, 0x10, 0x00,
-	0x12, 0x08, 0x0a, 0x04, 0x53, 0x45, 0x45, 0x4b, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x43, 0x55,
-	0x52, 0x52, 0x45, 0x4e, 0x54, 0x10, 0x04, 0x12, 0x08, 0x0a, 0x04, 0x4e, 0x45, 0x58, 0x54, 0x10,
-	0x08, 0x12, 0x0e, 0x0a, 0x0a, 0x53, 0x45, 0x45, 0x4b, 0x5f, 0x45, 0x58, 0x41, 0x43, 0x54, 0x10,
-	0x0f, 0x12, 0x08, 0x0a, 0x04, 0x4f, 0x50, 0x45, 0x4e, 0x10, 0x1e, 0x12, 0x09, 0x0a, 0x05, 0x43,
-	0x4c, 0x4f, 0x53, 0x45, 0x10, 0x1f, 0x12, 0x07, 0x0a, 0x03, 0x47, 0x45, 0x54, 0x10, 0x40, 0x32,
-	0x6b, 0x0a, 0x02, 0x4b, 0x56, 0x12, 0x39, 0x0a, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
-	0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x62,
-	0x61, 0x73, 0x65, 0x2e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79,
-	0x12, 0x2a, 0x0a, 0x02, 0x54, 0x78, 0x12, 0x10, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73,
-	0x65, 0x2e, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x1a, 0x0e, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x62,
-	0x61, 0x73, 0x65, 0x2e, 0x50, 0x61, 0x69, 0x72, 0x28, 0x01, 0x30, 0x01, 0x42, 0x1a, 0x5a, 0x18,
-	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6a, 0x75, 0x6e, 0x6f, 0x2f,
-	0x67, 0x72, 0x70, 0x63, 0x2f, 0x67, 0x65, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x70, 0x61, 0x74, 0x63, 0x68, 0x22,
+	0xa6, 0x01, 0x0a, 0x09, 0x48, 0x65, 0x61, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x2e, 0x0a,
+	0x08, 0x6e, 0x65, 0x77, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x11, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x4e, 0x65, 0x77, 0x48, 0x65,
+	0x61, 0x64, 0x48, 0x00, 0x52, 0x07, 0x6e, 0x65, 0x77, 0x48, 0x65, 0x61, 0x64, 0x12, 0x27, 0x0a,
+	0x05, 0x72, 0x65, 0x6f, 0x72, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64,
+	0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x6f, 0x72, 0x67, 0x48, 0x00, 0x52,
+	0x05, 0x72, 0x65, 0x6f, 0x72, 0x67, 0x12, 0x37, 0x0a, 0x0b, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e,
+	0x67, 0x5f, 0x74, 0x78, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x64, 0x61,
+	0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x54, 0x78,
+	0x73, 0x48, 0x00, 0x52, 0x0a, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x54, 0x78, 0x73, 0x42,
+	0x07, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x35, 0x0a, 0x07, 0x4e, 0x65, 0x77, 0x48,
+	0x65, 0x61, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x68,
+	0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x22,
+	0xa3, 0x01, 0x0a, 0x05, 0x52, 0x65, 0x6f, 0x72, 0x67, 0x12, 0x28, 0x0a, 0x10, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x0e, 0x73, 0x74, 0x61, 0x72, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48,
+	0x61, 0x73, 0x68, 0x12, 0x26, 0x0a, 0x0f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x62, 0x6c, 0x6f,
+	0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x12, 0x24, 0x0a, 0x0e, 0x65,
+	0x6e, 0x64, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x0c, 0x65, 0x6e, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73,
+	0x68, 0x12, 0x22, 0x0a, 0x0d, 0x65, 0x6e, 0x64, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e,
+	0x75, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x65, 0x6e, 0x64, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x22, 0x3b, 0x0a, 0x0a, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67,
+	0x54, 0x78, 0x73, 0x12, 0x2d, 0x0a, 0x12, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52,
+	0x11, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x61, 0x73, 0x68,
+	0x65, 0x73, 0x2a, 0x5e, 0x0a, 0x02, 0x4f, 0x70, 0x12, 0x09, 0x0a, 0x05, 0x46, 0x49, 0x52, 0x53,
+	0x54, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x53, 0x45, 0x45, 0x4b, 0x10, 0x01, 0x12, 0x0b, 0x0a,
+	0x07, 0x43, 0x55, 0x52, 0x52, 0x45, 0x4e, 0x54, 0x10, 0x04, 0x12, 0x08, 0x0a, 0x04, 0x4e, 0x45,
+	0x58, 0x54, 0x10, 0x08, 0x12, 0x0e, 0x0a, 0x0a, 0x53, 0x45, 0x45, 0x4b, 0x5f, 0x45, 0x58, 0x41,
+	0x43, 0x54, 0x10, 0x0f, 0x12, 0x08, 0x0a, 0x04, 0x4f, 0x50, 0x45, 0x4e, 0x10, 0x1e, 0x12, 0x09,
+	0x0a, 0x05, 0x43, 0x4c, 0x4f, 0x53, 0x45, 0x10, 0x1f, 0x12, 0x07, 0x0a, 0x03, 0x47, 0x45, 0x54,
+	0x10, 0x40, 0x32, 0xa3, 0x01, 0x0a, 0x02, 0x4b, 0x56, 0x12, 0x39, 0x0a, 0x07, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x64,
+	0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x2a, 0x0a, 0x02, 0x54, 0x78, 0x12, 0x10, 0x2e, 0x64, 0x61, 0x74,
+	0x61, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x1a, 0x0e, 0x2e, 0x64,
+	0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x50, 0x61, 0x69, 0x72, 0x28, 0x01, 0x30, 0x01,
+	0x12, 0x36, 0x0a, 0x05, 0x48, 0x65, 0x61, 0x64, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x1a, 0x13, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x48, 0x65, 0x61,
+	0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x30, 0x01, 0x42, 0x1a, 0x5a, 0x18, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6a, 0x75, 0x6e, 0x6f, 0x2f, 0x67, 0x72, 0x70, 0x63,
+	0x2f, 0x67, 0x65, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -363,25 +651,34 @@ func file_kv_proto_rawDescGZIP() []byte {
 }
 
 var file_kv_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_kv_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
-var file_kv_proto_goTypes = []interface{}{
+var file_kv_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_kv_proto_goTypes = []any{
 	(Op)(0),               // 0: database.Op
 	(*Cursor)(nil),        // 1: database.Cursor
 	(*Pair)(nil),          // 2: database.Pair
 	(*VersionReply)(nil),  // 3: database.VersionReply
-	(*emptypb.Empty)(nil), // 4: google.protobuf.Empty
+	(*HeadEvent)(nil),     // 4: database.HeadEvent
+	(*NewHead)(nil),       // 5: database.NewHead
+	(*Reorg)(nil),         // 6: database.Reorg
+	(*PendingTxs)(nil),    // 7: database.PendingTxs
+	(*emptypb.Empty)(nil), // 8: google.protobuf.Empty
 }
 var file_kv_proto_depIdxs = []int32{
 	0, // 0: database.Cursor.op:type_name -> database.Op
-	4, // 1: database.KV.Version:input_type -> google.protobuf.Empty
-	1, // 2: database.KV.Tx:input_type -> database.Cursor
-	3, // 3: database.KV.Version:output_type -> database.VersionReply
-	2, // 4: database.KV.Tx:output_type -> database.Pair
-	3, // [3:5] is the sub-list for method output_type
-	1, // [1:3] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	5, // 1: database.HeadEvent.new_head:type_name -> database.NewHead
+	6, // 2: database.HeadEvent.reorg:type_name -> database.Reorg
+	7, // 3: database.HeadEvent.pending_txs:type_name -> database.PendingTxs
+	8, // 4: database.KV.Version:input_type -> google.protobuf.Empty
+	1, // 5: database.KV.Tx:input_type -> database.Cursor
+	8, // 6: database.KV.Heads:input_type -> google.protobuf.Empty
+	3, // 7: database.KV.Version:output_type -> database.VersionReply
+	2, // 8: database.KV.Tx:output_type -> database.Pair
+	4, // 9: database.KV.Heads:output_type -> database.HeadEvent
+	7, // [7:10] is the sub-list for method output_type
+	4, // [4:7] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_kv_proto_init() }
@@ -389,43 +686,10 @@ func file_kv_proto_init() {
 	if File_kv_proto != nil {
 		return
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_kv_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Cursor); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_kv_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Pair); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_kv_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VersionReply); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
+	file_kv_proto_msgTypes[3].OneofWrappers = []any{
+		(*HeadEvent_NewHead)(nil),
+		(*HeadEvent_Reorg)(nil),
+		(*HeadEvent_PendingTxs)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -433,7 +697,7 @@ func file_kv_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_kv_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   3,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -25,6 +25,9 @@ const _ = grpc.SupportPackageIsVersion7
 type KVClient interface {
 	Version(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*VersionReply, error)
 	Tx(ctx context.Context, opts ...grpc.CallOption) (KV_TxClient, error)
+	// Heads streams the changes of the chain head and of the pending block, so that nodes reading the database
+	// remotely can notify their own subscribers.
+	Heads(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (KV_HeadsClient, error)
 }
 
 type kVClient struct {
@@ -75,12 +78,47 @@ func (x *kVTxClient) Recv() (*Pair, error) {
 	return m, nil
 }
 
+func (c *kVClient) Heads(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (KV_HeadsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &KV_ServiceDesc.Streams[1], "/database.KV/Heads", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &kVHeadsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type KV_HeadsClient interface {
+	Recv() (*HeadEvent, error)
+	grpc.ClientStream
+}
+
+type kVHeadsClient struct {
+	grpc.ClientStream
+}
+
+func (x *kVHeadsClient) Recv() (*HeadEvent, error) {
+	m := new(HeadEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // KVServer is the server API for KV service.
 // All implementations must embed UnimplementedKVServer
 // for forward compatibility
 type KVServer interface {
 	Version(context.Context, *emptypb.Empty) (*VersionReply, error)
 	Tx(KV_TxServer) error
+	// Heads streams the changes of the chain head and of the pending block, so that nodes reading the database
+	// remotely can notify their own subscribers.
+	Heads(*emptypb.Empty, KV_HeadsServer) error
 	mustEmbedUnimplementedKVServer()
 }
 
@@ -94,6 +132,9 @@ func (UnimplementedKVServer) Version(context.Context, *emptypb.Empty) (*VersionR
 func (UnimplementedKVServer) Tx(KV_TxServer) error {
 	return status.Errorf(codes.Unimplemented, "method Tx not implemented")
 }
+func (UnimplementedKVServer) Heads(*emptypb.Empty, KV_HeadsServer) error {
+	return status.Errorf(codes.Unimplemented, "method Heads not implemented")
+}
 func (UnimplementedKVServer) mustEmbedUnimplementedKVServer() {}
 
 // UnsafeKVServer may be embedded to opt out of forward compatibility for this service.
@@ -151,6 +192,27 @@ func (x *kVTxServer) Recv() (*Cursor, error) {
 	return m, nil
 }
 
+func _KV_Heads_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(emptypb.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(KVServer).Heads(m, &kVHeadsServer{stream})
+}
+
+type KV_HeadsServer interface {
+	Send(*HeadEvent) error
+	grpc.ServerStream
+}
+
+type kVHeadsServer struct {
+	grpc.ServerStream
+}
+
+func (x *kVHeadsServer) Send(m *HeadEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // KV_ServiceDesc is the grpc.ServiceDesc for KV service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -170,6 +232,11 @@ var KV_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "Heads",
+			Handler:       _KV_Heads_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "kv.proto",
 }
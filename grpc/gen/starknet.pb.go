@@ -0,0 +1,623 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        v3.21.12
+// source: starknet.proto
+
+package gen
+
+import (
+	spec "github.com/NethermindEth/juno/p2p/starknet/spec"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Selects a block by its number or hash, or the latest block if neither is set.
+type BlockRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to BlockId:
+	//	*BlockRequest_Number
+	//	*BlockRequest_Hash
+	BlockId isBlockRequest_BlockId `protobuf_oneof:"block_id"`
+}
+
+func (x *BlockRequest) Reset() {
+	*x = BlockRequest{}
+	mi := &file_starknet_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockRequest) ProtoMessage() {}
+
+func (x *BlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_starknet_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockRequest.ProtoReflect.Descriptor instead.
+func (*BlockRequest) Descriptor() ([]byte, []int) {
+	return file_starknet_proto_rawDescGZIP(), []int{0}
+}
+
+func (m *BlockRequest) GetBlockId() isBlockRequest_BlockId {
+	if m != nil {
+		return m.BlockId
+	}
+	return nil
+}
+
+func (x *BlockRequest) GetNumber() uint64 {
+	if x, ok := x.GetBlockId().(*BlockRequest_Number); ok {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *BlockRequest) GetHash() *spec.Hash {
+	if x, ok := x.GetBlockId().(*BlockRequest_Hash); ok {
+		return x.Hash
+	}
+	return nil
+}
+
+type isBlockRequest_BlockId interface {
+	isBlockRequest_BlockId()
+}
+
+type BlockRequest_Number struct {
+	Number uint64 `protobuf:"varint,1,opt,name=number,proto3,oneof"`
+}
+
+type BlockRequest_Hash struct {
+	Hash *spec.Hash `protobuf:"bytes,2,opt,name=hash,proto3,oneof"`
+}
+
+func (*BlockRequest_Number) isBlockRequest_BlockId() {}
+
+func (*BlockRequest_Hash) isBlockRequest_BlockId() {}
+
+type Block struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Header       *spec.SignedBlockHeader        `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Transactions []*spec.TransactionWithReceipt `protobuf:"bytes,2,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	Events       []*spec.Event                  `protobuf:"bytes,3,rep,name=events,proto3" json:"events,omitempty"`
+}
+
+func (x *Block) Reset() {
+	*x = Block{}
+	mi := &file_starknet_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Block) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Block) ProtoMessage() {}
+
+func (x *Block) ProtoReflect() protoreflect.Message {
+	mi := &file_starknet_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Block.ProtoReflect.Descriptor instead.
+func (*Block) Descriptor() ([]byte, []int) {
+	return file_starknet_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Block) GetHeader() *spec.SignedBlockHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *Block) GetTransactions() []*spec.TransactionWithReceipt {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *Block) GetEvents() []*spec.Event {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type StateUpdate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockHash       *spec.Hash            `protobuf:"bytes,1,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	NewRoot         *spec.Hash            `protobuf:"bytes,2,opt,name=new_root,json=newRoot,proto3" json:"new_root,omitempty"`
+	OldRoot         *spec.Hash            `protobuf:"bytes,3,opt,name=old_root,json=oldRoot,proto3" json:"old_root,omitempty"`
+	ContractDiffs   []*spec.ContractDiff  `protobuf:"bytes,4,rep,name=contract_diffs,json=contractDiffs,proto3" json:"contract_diffs,omitempty"`
+	DeclaredClasses []*spec.DeclaredClass `protobuf:"bytes,5,rep,name=declared_classes,json=declaredClasses,proto3" json:"declared_classes,omitempty"`
+}
+
+func (x *StateUpdate) Reset() {
+	*x = StateUpdate{}
+	mi := &file_starknet_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StateUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StateUpdate) ProtoMessage() {}
+
+func (x *StateUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_starknet_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StateUpdate.ProtoReflect.Descriptor instead.
+func (*StateUpdate) Descriptor() ([]byte, []int) {
+	return file_starknet_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *StateUpdate) GetBlockHash() *spec.Hash {
+	if x != nil {
+		return x.BlockHash
+	}
+	return nil
+}
+
+func (x *StateUpdate) GetNewRoot() *spec.Hash {
+	if x != nil {
+		return x.NewRoot
+	}
+	return nil
+}
+
+func (x *StateUpdate) GetOldRoot() *spec.Hash {
+	if x != nil {
+		return x.OldRoot
+	}
+	return nil
+}
+
+func (x *StateUpdate) GetContractDiffs() []*spec.ContractDiff {
+	if x != nil {
+		return x.ContractDiffs
+	}
+	return nil
+}
+
+func (x *StateUpdate) GetDeclaredClasses() []*spec.DeclaredClass {
+	if x != nil {
+		return x.DeclaredClasses
+	}
+	return nil
+}
+
+type StorageRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Block    *BlockRequest `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
+	Contract *spec.Address `protobuf:"bytes,2,opt,name=contract,proto3" json:"contract,omitempty"`
+	Key      *spec.Felt252 `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (x *StorageRequest) Reset() {
+	*x = StorageRequest{}
+	mi := &file_starknet_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StorageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageRequest) ProtoMessage() {}
+
+func (x *StorageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_starknet_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageRequest.ProtoReflect.Descriptor instead.
+func (*StorageRequest) Descriptor() ([]byte, []int) {
+	return file_starknet_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *StorageRequest) GetBlock() *BlockRequest {
+	if x != nil {
+		return x.Block
+	}
+	return nil
+}
+
+func (x *StorageRequest) GetContract() *spec.Address {
+	if x != nil {
+		return x.Contract
+	}
+	return nil
+}
+
+func (x *StorageRequest) GetKey() *spec.Felt252 {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+type StorageResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value *spec.Felt252 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *StorageResponse) Reset() {
+	*x = StorageResponse{}
+	mi := &file_starknet_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StorageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageResponse) ProtoMessage() {}
+
+func (x *StorageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_starknet_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageResponse.ProtoReflect.Descriptor instead.
+func (*StorageResponse) Descriptor() ([]byte, []int) {
+	return file_starknet_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *StorageResponse) GetValue() *spec.Felt252 {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type ClassRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Block     *BlockRequest `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
+	ClassHash *spec.Hash    `protobuf:"bytes,2,opt,name=class_hash,json=classHash,proto3" json:"class_hash,omitempty"`
+}
+
+func (x *ClassRequest) Reset() {
+	*x = ClassRequest{}
+	mi := &file_starknet_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClassRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClassRequest) ProtoMessage() {}
+
+func (x *ClassRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_starknet_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClassRequest.ProtoReflect.Descriptor instead.
+func (*ClassRequest) Descriptor() ([]byte, []int) {
+	return file_starknet_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ClassRequest) GetBlock() *BlockRequest {
+	if x != nil {
+		return x.Block
+	}
+	return nil
+}
+
+func (x *ClassRequest) GetClassHash() *spec.Hash {
+	if x != nil {
+		return x.ClassHash
+	}
+	return nil
+}
+
+type StreamBlocksRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	From uint64 `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`
+}
+
+func (x *StreamBlocksRequest) Reset() {
+	*x = StreamBlocksRequest{}
+	mi := &file_starknet_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamBlocksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamBlocksRequest) ProtoMessage() {}
+
+func (x *StreamBlocksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_starknet_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamBlocksRequest.ProtoReflect.Descriptor instead.
+func (*StreamBlocksRequest) Descriptor() ([]byte, []int) {
+	return file_starknet_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *StreamBlocksRequest) GetFrom() uint64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+var File_starknet_proto protoreflect.FileDescriptor
+
+var file_starknet_proto_rawDesc = []byte{
+	0x0a, 0x0e, 0x73, 0x74, 0x61, 0x72, 0x6b, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x08, 0x73, 0x74, 0x61, 0x72, 0x6b, 0x6e, 0x65, 0x74, 0x1a, 0x15, 0x70, 0x32, 0x70, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x1a, 0x16, 0x70, 0x32, 0x70, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6d,
+	0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x15, 0x70, 0x32, 0x70, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x1a, 0x16, 0x70, 0x32, 0x70, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x68, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x15, 0x70, 0x32, 0x70, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x1b, 0x70, 0x32, 0x70, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x51, 0x0a, 0x0c,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x06,
+	0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x48, 0x00, 0x52, 0x06,
+	0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x05, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x48, 0x00, 0x52, 0x04, 0x68,
+	0x61, 0x73, 0x68, 0x42, 0x0a, 0x0a, 0x08, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x69, 0x64, 0x22,
+	0x90, 0x01, 0x0a, 0x05, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x2a, 0x0a, 0x06, 0x68, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x53, 0x69, 0x67, 0x6e,
+	0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x06, 0x68,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x3b, 0x0a, 0x0c, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x57, 0x69, 0x74, 0x68, 0x52, 0x65, 0x63,
+	0x65, 0x69, 0x70, 0x74, 0x52, 0x0c, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x1e, 0x0a, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x06, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x65, 0x76, 0x65, 0x6e,
+	0x74, 0x73, 0x22, 0xe8, 0x01, 0x0a, 0x0b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x12, 0x24, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68, 0x61, 0x73, 0x68,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x05, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x52, 0x09, 0x62,
+	0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x12, 0x20, 0x0a, 0x08, 0x6e, 0x65, 0x77, 0x5f,
+	0x72, 0x6f, 0x6f, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x05, 0x2e, 0x48, 0x61, 0x73,
+	0x68, 0x52, 0x07, 0x6e, 0x65, 0x77, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x20, 0x0a, 0x08, 0x6f, 0x6c,
+	0x64, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x05, 0x2e, 0x48,
+	0x61, 0x73, 0x68, 0x52, 0x07, 0x6f, 0x6c, 0x64, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x34, 0x0a, 0x0e,
+	0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x5f, 0x64, 0x69, 0x66, 0x66, 0x73, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x44,
+	0x69, 0x66, 0x66, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x44, 0x69, 0x66,
+	0x66, 0x73, 0x12, 0x39, 0x0a, 0x10, 0x64, 0x65, 0x63, 0x6c, 0x61, 0x72, 0x65, 0x64, 0x5f, 0x63,
+	0x6c, 0x61, 0x73, 0x73, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x44,
+	0x65, 0x63, 0x6c, 0x61, 0x72, 0x65, 0x64, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x52, 0x0f, 0x64, 0x65,
+	0x63, 0x6c, 0x61, 0x72, 0x65, 0x64, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x65, 0x73, 0x22, 0x80, 0x01,
+	0x0a, 0x0e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x2c, 0x0a, 0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x16, 0x2e, 0x73, 0x74, 0x61, 0x72, 0x6b, 0x6e, 0x65, 0x74, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x24,
+	0x0a, 0x08, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x08, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x08, 0x63, 0x6f, 0x6e, 0x74,
+	0x72, 0x61, 0x63, 0x74, 0x12, 0x1a, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x08, 0x2e, 0x46, 0x65, 0x6c, 0x74, 0x32, 0x35, 0x32, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x22, 0x31, 0x0a, 0x0f, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x08, 0x2e, 0x46, 0x65, 0x6c, 0x74, 0x32, 0x35, 0x32, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x22, 0x62, 0x0a, 0x0c, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x73, 0x74, 0x61, 0x72, 0x6b, 0x6e, 0x65, 0x74, 0x2e, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x05, 0x62, 0x6c, 0x6f, 0x63,
+	0x6b, 0x12, 0x24, 0x0a, 0x0a, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x05, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x52, 0x09, 0x63, 0x6c,
+	0x61, 0x73, 0x73, 0x48, 0x61, 0x73, 0x68, 0x22, 0x29, 0x0a, 0x13, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x66, 0x72,
+	0x6f, 0x6d, 0x32, 0xb3, 0x02, 0x0a, 0x08, 0x53, 0x74, 0x61, 0x72, 0x6b, 0x6e, 0x65, 0x74, 0x12,
+	0x33, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x16, 0x2e, 0x73, 0x74,
+	0x61, 0x72, 0x6b, 0x6e, 0x65, 0x74, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x73, 0x74, 0x61, 0x72, 0x6b, 0x6e, 0x65, 0x74, 0x2e, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x3f, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x16, 0x2e, 0x73, 0x74, 0x61, 0x72, 0x6b, 0x6e, 0x65,
+	0x74, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15,
+	0x2e, 0x73, 0x74, 0x61, 0x72, 0x6b, 0x6e, 0x65, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x43, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x53, 0x74, 0x6f, 0x72,
+	0x61, 0x67, 0x65, 0x41, 0x74, 0x12, 0x18, 0x2e, 0x73, 0x74, 0x61, 0x72, 0x6b, 0x6e, 0x65, 0x74,
+	0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x19, 0x2e, 0x73, 0x74, 0x61, 0x72, 0x6b, 0x6e, 0x65, 0x74, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61,
+	0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x08, 0x47, 0x65,
+	0x74, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x12, 0x16, 0x2e, 0x73, 0x74, 0x61, 0x72, 0x6b, 0x6e, 0x65,
+	0x74, 0x2e, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x06,
+	0x2e, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x12, 0x40, 0x0a, 0x0c, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12, 0x1d, 0x2e, 0x73, 0x74, 0x61, 0x72, 0x6b, 0x6e, 0x65,
+	0x74, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x73, 0x74, 0x61, 0x72, 0x6b, 0x6e, 0x65, 0x74,
+	0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x30, 0x01, 0x42, 0x1a, 0x5a, 0x18, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6a, 0x75, 0x6e, 0x6f, 0x2f, 0x67, 0x72, 0x70, 0x63,
+	0x2f, 0x67, 0x65, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_starknet_proto_rawDescOnce sync.Once
+	file_starknet_proto_rawDescData = file_starknet_proto_rawDesc
+)
+
+func file_starknet_proto_rawDescGZIP() []byte {
+	file_starknet_proto_rawDescOnce.Do(func() {
+		file_starknet_proto_rawDescData = protoimpl.X.CompressGZIP(file_starknet_proto_rawDescData)
+	})
+	return file_starknet_proto_rawDescData
+}
+
+var file_starknet_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_starknet_proto_goTypes = []any{
+	(*BlockRequest)(nil),                // 0: starknet.BlockRequest
+	(*Block)(nil),                       // 1: starknet.Block
+	(*StateUpdate)(nil),                 // 2: starknet.StateUpdate
+	(*StorageRequest)(nil),              // 3: starknet.StorageRequest
+	(*StorageResponse)(nil),             // 4: starknet.StorageResponse
+	(*ClassRequest)(nil),                // 5: starknet.ClassRequest
+	(*StreamBlocksRequest)(nil),         // 6: starknet.StreamBlocksRequest
+	(*spec.Hash)(nil),                   // 7: Hash
+	(*spec.SignedBlockHeader)(nil),      // 8: SignedBlockHeader
+	(*spec.TransactionWithReceipt)(nil), // 9: TransactionWithReceipt
+	(*spec.Event)(nil),                  // 10: Event
+	(*spec.ContractDiff)(nil),           // 11: ContractDiff
+	(*spec.DeclaredClass)(nil),          // 12: DeclaredClass
+	(*spec.Address)(nil),                // 13: Address
+	(*spec.Felt252)(nil),                // 14: Felt252
+	(*spec.Class)(nil),                  // 15: Class
+}
+var file_starknet_proto_depIdxs = []int32{
+	7,  // 0: starknet.BlockRequest.hash:type_name -> Hash
+	8,  // 1: starknet.Block.header:type_name -> SignedBlockHeader
+	9,  // 2: starknet.Block.transactions:type_name -> TransactionWithReceipt
+	10, // 3: starknet.Block.events:type_name -> Event
+	7,  // 4: starknet.StateUpdate.block_hash:type_name -> Hash
+	7,  // 5: starknet.StateUpdate.new_root:type_name -> Hash
+	7,  // 6: starknet.StateUpdate.old_root:type_name -> Hash
+	11, // 7: starknet.StateUpdate.contract_diffs:type_name -> ContractDiff
+	12, // 8: starknet.StateUpdate.declared_classes:type_name -> DeclaredClass
+	0,  // 9: starknet.StorageRequest.block:type_name -> starknet.BlockRequest
+	13, // 10: starknet.StorageRequest.contract:type_name -> Address
+	14, // 11: starknet.StorageRequest.key:type_name -> Felt252
+	14, // 12: starknet.StorageResponse.value:type_name -> Felt252
+	0,  // 13: starknet.ClassRequest.block:type_name -> starknet.BlockRequest
+	7,  // 14: starknet.ClassRequest.class_hash:type_name -> Hash
+	0,  // 15: starknet.Starknet.GetBlock:input_type -> starknet.BlockRequest
+	0,  // 16: starknet.Starknet.GetStateUpdate:input_type -> starknet.BlockRequest
+	3,  // 17: starknet.Starknet.GetStorageAt:input_type -> starknet.StorageRequest
+	5,  // 18: starknet.Starknet.GetClass:input_type -> starknet.ClassRequest
+	6,  // 19: starknet.Starknet.StreamBlocks:input_type -> starknet.StreamBlocksRequest
+	1,  // 20: starknet.Starknet.GetBlock:output_type -> starknet.Block
+	2,  // 21: starknet.Starknet.GetStateUpdate:output_type -> starknet.StateUpdate
+	4,  // 22: starknet.Starknet.GetStorageAt:output_type -> starknet.StorageResponse
+	15, // 23: starknet.Starknet.GetClass:output_type -> Class
+	1,  // 24: starknet.Starknet.StreamBlocks:output_type -> starknet.Block
+	20, // [20:25] is the sub-list for method output_type
+	15, // [15:20] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
+}
+
+func init() { file_starknet_proto_init() }
+func file_starknet_proto_init() {
+	if File_starknet_proto != nil {
+		return
+	}
+	file_starknet_proto_msgTypes[0].OneofWrappers = []any{
+		(*BlockRequest_Number)(nil),
+		(*BlockRequest_Hash)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_starknet_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_starknet_proto_goTypes,
+		DependencyIndexes: file_starknet_proto_depIdxs,
+		MessageInfos:      file_starknet_proto_msgTypes,
+	}.Build()
+	File_starknet_proto = out.File
+	file_starknet_proto_rawDesc = nil
+	file_starknet_proto_goTypes = nil
+	file_starknet_proto_depIdxs = nil
+}
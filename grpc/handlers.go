@@ -1,4 +1,6 @@
-//go:generate protoc --go_out=gen --go_opt=paths=source_relative --go-grpc_out=gen --go-grpc_opt=paths=source_relative kv.proto
+//go:generate go install google.golang.org/protobuf/cmd/protoc-gen-go@v1.35.2
+//go:generate go install google.golang.org/grpc/cmd/protoc-gen-go-grpc@v1.2.0
+//go:generate protoc -I . -I ../p2p/starknet --go_out=gen --go_opt=paths=source_relative --go-grpc_out=gen --go-grpc_opt=paths=source_relative kv.proto starknet.proto
 package grpc
 
 import (
@@ -10,6 +12,7 @@ import (
 	"github.com/Masterminds/semver/v3"
 	"github.com/NethermindEth/juno/db"
 	"github.com/NethermindEth/juno/grpc/gen"
+	"github.com/NethermindEth/juno/sync"
 	"github.com/NethermindEth/juno/utils"
 	"github.com/pkg/errors"
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -17,8 +20,9 @@ import (
 
 type Handler struct {
 	gen.UnimplementedKVServer
-	db      db.DB
-	version string
+	db         db.DB
+	version    string
+	syncReader sync.Reader
 }
 
 func New(database db.DB, version string) *Handler {
@@ -28,6 +32,12 @@ func New(database db.DB, version string) *Handler {
 	}
 }
 
+// WithSyncReader makes Heads stream the changes of the chain head syncReader notifies of.
+func (h *Handler) WithSyncReader(syncReader sync.Reader) *Handler {
+	h.syncReader = syncReader
+	return h
+}
+
 func (h Handler) Version(ctx context.Context, _ *emptypb.Empty) (*gen.VersionReply, error) {
 	ver, err := semver.NewVersion(h.version)
 	if err != nil {
@@ -59,6 +69,50 @@ func (h Handler) Tx(server gen.KV_TxServer) error {
 	}
 }
 
+// Heads streams the new heads, reorgs and pending transactions the sync reader notifies of, until the client
+// disconnects.
+func (h Handler) Heads(_ *emptypb.Empty, server gen.KV_HeadsServer) error {
+	if h.syncReader == nil {
+		return errors.New("heads are not available")
+	}
+
+	newHeads := h.syncReader.SubscribeNewHeads()
+	defer newHeads.Unsubscribe()
+	reorgs := h.syncReader.SubscribeReorg()
+	defer reorgs.Unsubscribe()
+	pendingTxs := h.syncReader.SubscribePendingTxs()
+	defer pendingTxs.Unsubscribe()
+
+	for {
+		event := new(gen.HeadEvent)
+		select {
+		case <-server.Context().Done():
+			return nil
+		case header := <-newHeads.Recv():
+			event.Event = &gen.HeadEvent_NewHead{NewHead: &gen.NewHead{
+				Number: header.Number,
+				Hash:   header.Hash.Marshal(),
+			}}
+		case reorg := <-reorgs.Recv():
+			event.Event = &gen.HeadEvent_Reorg{Reorg: &gen.Reorg{
+				StartBlockHash: reorg.StartBlockHash.Marshal(),
+				StartBlockNum:  reorg.StartBlockNum,
+				EndBlockHash:   reorg.EndBlockHash.Marshal(),
+				EndBlockNum:    reorg.EndBlockNum,
+			}}
+		case txs := <-pendingTxs.Recv():
+			hashes := make([][]byte, len(txs))
+			for i, txn := range txs {
+				hashes[i] = txn.Hash().Marshal()
+			}
+			event.Event = &gen.HeadEvent_PendingTxs{PendingTxs: &gen.PendingTxs{TransactionHashes: hashes}}
+		}
+		if err := server.Send(event); err != nil {
+			return err
+		}
+	}
+}
+
 //nolint:gocyclo
 func (h Handler) handleTxCursor(
 	cur *gen.Cursor,
@@ -5,11 +5,17 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
 	"github.com/NethermindEth/juno/db"
 	"github.com/NethermindEth/juno/db/pebble"
+	"github.com/NethermindEth/juno/feed"
 	"github.com/NethermindEth/juno/grpc/gen"
+	"github.com/NethermindEth/juno/mocks"
+	"github.com/NethermindEth/juno/sync"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
@@ -123,3 +129,67 @@ func TestHandlers_Tx(t *testing.T) {
 	}
 	stream.Close()
 }
+
+type headsStreamMock struct {
+	grpc.ServerStream
+	ctx    context.Context
+	events chan *gen.HeadEvent
+}
+
+func (m *headsStreamMock) Context() context.Context {
+	return m.ctx
+}
+
+func (m *headsStreamMock) Send(event *gen.HeadEvent) error {
+	m.events <- event
+	return nil
+}
+
+func TestHandlers_Heads(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	t.Cleanup(mockCtrl.Finish)
+
+	newHeads := feed.New[*core.Header]()
+	reorgs := feed.New[*sync.ReorgBlockRange]()
+	pendingTxs := feed.New[[]core.Transaction]()
+	syncReader := mocks.NewMockSyncReader(mockCtrl)
+	syncReader.EXPECT().SubscribeNewHeads().Return(sync.HeaderSubscription{Subscription: newHeads.Subscribe()})
+	syncReader.EXPECT().SubscribeReorg().Return(sync.ReorgSubscription{Subscription: reorgs.Subscribe()})
+	subscribed := make(chan struct{})
+	syncReader.EXPECT().SubscribePendingTxs().DoAndReturn(func() sync.PendingTxSubscription {
+		defer close(subscribed)
+		return sync.PendingTxSubscription{Subscription: pendingTxs.Subscribe()}
+	})
+
+	h := New(nil, "").WithSyncReader(syncReader)
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &headsStreamMock{ctx: ctx, events: make(chan *gen.HeadEvent)}
+	done := make(chan error)
+	go func() {
+		done <- h.Heads(&emptypb.Empty{}, stream)
+	}()
+
+	hash := new(felt.Felt).SetUint64(1)
+	<-subscribed
+	newHeads.Send(&core.Header{Number: 2, Hash: hash})
+	assert.Equal(t, &gen.NewHead{Number: 2, Hash: hash.Marshal()}, (<-stream.events).GetNewHead())
+
+	reorgs.Send(&sync.ReorgBlockRange{StartBlockHash: hash, StartBlockNum: 1, EndBlockHash: hash, EndBlockNum: 2})
+	assert.Equal(t, &gen.Reorg{
+		StartBlockHash: hash.Marshal(),
+		StartBlockNum:  1,
+		EndBlockHash:   hash.Marshal(),
+		EndBlockNum:    2,
+	}, (<-stream.events).GetReorg())
+
+	pendingTxs.Send([]core.Transaction{&core.InvokeTransaction{TransactionHash: hash}})
+	assert.Equal(t, [][]byte{hash.Marshal()}, (<-stream.events).GetPendingTxs().GetTransactionHashes())
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestHandlers_HeadsWithoutSyncReader(t *testing.T) {
+	h := New(nil, "")
+	require.Error(t, h.Heads(&emptypb.Empty{}, &headsStreamMock{ctx: context.Background()}))
+}
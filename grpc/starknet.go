@@ -0,0 +1,301 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/NethermindEth/juno/adapters/core2p2p"
+	"github.com/NethermindEth/juno/adapters/p2p2core"
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/grpc/gen"
+	"github.com/NethermindEth/juno/p2p/starknet/spec"
+	"github.com/NethermindEth/juno/sync"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// streamPollInterval is how often StreamBlocks checks for the next block once it reached the head, in case it
+// missed the notification of the new head.
+const streamPollInterval = 5 * time.Second
+
+// StarknetHandler serves the blocks, state updates, storage and classes of the chain as the typed messages of the
+// p2p specification.
+type StarknetHandler struct {
+	gen.UnimplementedStarknetServer
+	bcReader   blockchain.Reader
+	syncReader sync.Reader
+}
+
+func NewStarknetHandler(bcReader blockchain.Reader, syncReader sync.Reader) *StarknetHandler {
+	return &StarknetHandler{
+		bcReader:   bcReader,
+		syncReader: syncReader,
+	}
+}
+
+func (h *StarknetHandler) GetBlock(_ context.Context, req *gen.BlockRequest) (*gen.Block, error) {
+	header, err := h.header(req)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	block, err := h.block(header.Number)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return block, nil
+}
+
+func (h *StarknetHandler) GetStateUpdate(_ context.Context, req *gen.BlockRequest) (*gen.StateUpdate, error) {
+	header, err := h.header(req)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	stateUpdate, err := h.bcReader.StateUpdateByNumber(header.Number)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	contractDiffs, declaredClasses := adaptStateDiff(stateUpdate.StateDiff)
+	return &gen.StateUpdate{
+		BlockHash:       core2p2p.AdaptHash(stateUpdate.BlockHash),
+		NewRoot:         core2p2p.AdaptHash(stateUpdate.NewRoot),
+		OldRoot:         core2p2p.AdaptHash(stateUpdate.OldRoot),
+		ContractDiffs:   contractDiffs,
+		DeclaredClasses: declaredClasses,
+	}, nil
+}
+
+func (h *StarknetHandler) GetStorageAt(_ context.Context, req *gen.StorageRequest) (*gen.StorageResponse, error) {
+	if req.Contract == nil || req.Key == nil {
+		return nil, status.Error(codes.InvalidArgument, "contract and key are required")
+	}
+
+	state, closer, err := h.state(req.Block)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	defer closeState(closer)
+
+	value, err := state.ContractStorage(p2p2core.AdaptAddress(req.Contract), p2p2core.AdaptFelt(req.Key))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &gen.StorageResponse{Value: core2p2p.AdaptFelt(value)}, nil
+}
+
+func (h *StarknetHandler) GetClass(_ context.Context, req *gen.ClassRequest) (*spec.Class, error) {
+	classHash := p2p2core.AdaptHash(req.ClassHash)
+	if classHash == nil {
+		return nil, status.Error(codes.InvalidArgument, "class hash is required")
+	}
+
+	state, closer, err := h.state(req.Block)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	defer closeState(closer)
+
+	class, err := state.Class(classHash)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return core2p2p.AdaptClass(class.Class), nil
+}
+
+// StreamBlocks streams the blocks from req.From on, as they are stored. After a reorg, the blocks are streamed again
+// from the first block of the new chain, so clients replace the blocks they received from that number on.
+func (h *StarknetHandler) StreamBlocks(req *gen.StreamBlocksRequest, server gen.Starknet_StreamBlocksServer) error {
+	// Subscribing before reading the next block ensures that it isn't missed if it is stored in between.
+	var newHeads <-chan *core.Header
+	var reorgs <-chan *sync.ReorgBlockRange
+	if h.syncReader != nil {
+		headsSub := h.syncReader.SubscribeNewHeads()
+		defer headsSub.Unsubscribe()
+		newHeads = headsSub.Recv()
+		reorgSub := h.syncReader.SubscribeReorg()
+		defer reorgSub.Unsubscribe()
+		reorgs = reorgSub.Recv()
+	}
+	// rewind makes the stream continue from the first block of the new chain if blocks it sent were reverted.
+	rewind := func(number uint64, reorg *sync.ReorgBlockRange) uint64 {
+		return min(number, max(reorg.StartBlockNum, req.From))
+	}
+
+	for number := req.From; ; number++ {
+		select {
+		case reorg := <-reorgs:
+			number = rewind(number, reorg)
+		default:
+		}
+
+		block, err := h.block(number)
+		for errors.Is(err, db.ErrKeyNotFound) {
+			select {
+			case <-server.Context().Done():
+				return nil
+			case <-newHeads:
+			case reorg := <-reorgs:
+				number = rewind(number, reorg)
+			case <-time.After(streamPollInterval):
+			}
+			block, err = h.block(number)
+		}
+		if err != nil {
+			return statusError(err)
+		}
+
+		if err = server.Send(block); err != nil {
+			return err
+		}
+	}
+}
+
+func (h *StarknetHandler) header(req *gen.BlockRequest) (*core.Header, error) {
+	switch id := req.GetBlockId().(type) {
+	case *gen.BlockRequest_Number:
+		return h.bcReader.BlockHeaderByNumber(id.Number)
+	case *gen.BlockRequest_Hash:
+		hash := p2p2core.AdaptHash(id.Hash)
+		if hash == nil {
+			return nil, status.Error(codes.InvalidArgument, "block hash is empty")
+		}
+		return h.bcReader.BlockHeaderByHash(hash)
+	default:
+		return h.bcReader.HeadsHeader()
+	}
+}
+
+func (h *StarknetHandler) state(req *gen.BlockRequest) (core.StateReader, blockchain.StateCloser, error) {
+	switch id := req.GetBlockId().(type) {
+	case *gen.BlockRequest_Number:
+		return h.bcReader.StateAtBlockNumber(id.Number)
+	case *gen.BlockRequest_Hash:
+		hash := p2p2core.AdaptHash(id.Hash)
+		if hash == nil {
+			return nil, nil, status.Error(codes.InvalidArgument, "block hash is empty")
+		}
+		return h.bcReader.StateAtBlockHash(hash)
+	default:
+		return h.bcReader.HeadState()
+	}
+}
+
+func (h *StarknetHandler) block(number uint64) (*gen.Block, error) {
+	block, err := h.bcReader.BlockByNumber(number)
+	if err != nil {
+		return nil, err
+	}
+	commitments, err := h.bcReader.BlockCommitmentsByNumber(number)
+	if err != nil {
+		return nil, err
+	}
+	stateUpdate, err := h.bcReader.StateUpdateByNumber(number)
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([]*spec.TransactionWithReceipt, len(block.Transactions))
+	var events []*spec.Event
+	for i, txn := range block.Transactions {
+		receipt := block.Receipts[i]
+		txs[i] = &spec.TransactionWithReceipt{
+			Transaction: core2p2p.AdaptTransaction(txn),
+			Receipt:     core2p2p.AdaptReceipt(receipt, txn),
+		}
+		for _, event := range receipt.Events {
+			events = append(events, core2p2p.AdaptEvent(event, receipt.TransactionHash))
+		}
+	}
+
+	return &gen.Block{
+		Header: core2p2p.AdaptHeader(block.Header, commitments, stateUpdate.StateDiff.Hash(),
+			stateUpdate.StateDiff.Length()),
+		Transactions: txs,
+		Events:       events,
+	}, nil
+}
+
+// closeState closes a state opened for reading, which can't lose data, so its error is dropped.
+func closeState(closer blockchain.StateCloser) {
+	_ = closer()
+}
+
+// adaptStateDiff groups the changes of diff by contract, ordered by address, and returns the declared classes ordered
+// by class hash.
+func adaptStateDiff(diff *core.StateDiff) ([]*spec.ContractDiff, []*spec.DeclaredClass) {
+	type contractDiff struct {
+		nonce, classHash *felt.Felt
+		storage          map[felt.Felt]*felt.Felt
+	}
+	contracts := make(map[felt.Felt]*contractDiff)
+	get := func(addr felt.Felt) *contractDiff {
+		c, ok := contracts[addr]
+		if !ok {
+			c = new(contractDiff)
+			contracts[addr] = c
+		}
+		return c
+	}
+
+	for addr, nonce := range diff.Nonces {
+		get(addr).nonce = nonce
+	}
+	for addr, storage := range diff.StorageDiffs {
+		get(addr).storage = storage
+	}
+	for addr, classHash := range diff.DeployedContracts {
+		get(addr).classHash = classHash
+	}
+	for addr, classHash := range diff.ReplacedClasses {
+		get(addr).classHash = classHash
+	}
+
+	addresses := make([]felt.Felt, 0, len(contracts))
+	for addr := range contracts {
+		addresses = append(addresses, addr)
+	}
+	slices.SortFunc(addresses, func(a, b felt.Felt) int { return a.Cmp(&b) })
+
+	contractDiffs := make([]*spec.ContractDiff, len(addresses))
+	for i, addr := range addresses {
+		c := contracts[addr]
+		contractDiffs[i] = core2p2p.AdaptContractDiff(&addr, c.nonce, c.classHash, c.storage)
+	}
+
+	classHashes := make([]felt.Felt, 0, len(diff.DeclaredV0Classes)+len(diff.DeclaredV1Classes))
+	for _, classHash := range diff.DeclaredV0Classes {
+		classHashes = append(classHashes, *classHash)
+	}
+	for classHash := range diff.DeclaredV1Classes {
+		classHashes = append(classHashes, classHash)
+	}
+	slices.SortFunc(classHashes, func(a, b felt.Felt) int { return a.Cmp(&b) })
+
+	declaredClasses := make([]*spec.DeclaredClass, len(classHashes))
+	for i, classHash := range classHashes {
+		// Cairo 0 classes have no compiled class hash, which leaves it nil.
+		declaredClasses[i] = &spec.DeclaredClass{
+			ClassHash:         core2p2p.AdaptHash(&classHash),
+			CompiledClassHash: core2p2p.AdaptHash(diff.DeclaredV1Classes[classHash]),
+		}
+	}
+	return contractDiffs, declaredClasses
+}
+
+// statusError converts the errors of reading the chain to the matching gRPC status.
+func statusError(err error) error {
+	switch {
+	case errors.Is(err, db.ErrKeyNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, blockchain.ErrHistoryPruned):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return err
+	}
+}
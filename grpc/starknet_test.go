@@ -0,0 +1,205 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/juno/adapters/core2p2p"
+	"github.com/NethermindEth/juno/adapters/p2p2core"
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/clients/feeder"
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db/pebble"
+	"github.com/NethermindEth/juno/feed"
+	"github.com/NethermindEth/juno/grpc/gen"
+	"github.com/NethermindEth/juno/mocks"
+	adaptfeeder "github.com/NethermindEth/juno/starknetdata/feeder"
+	"github.com/NethermindEth/juno/sync"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type blocksStreamMock struct {
+	grpc.ServerStream
+	ctx    context.Context
+	blocks chan *gen.Block
+}
+
+func (m *blocksStreamMock) Context() context.Context {
+	return m.ctx
+}
+
+func (m *blocksStreamMock) Send(block *gen.Block) error {
+	m.blocks <- block
+	return nil
+}
+
+func storeSepoliaBlocks(t *testing.T, chain *blockchain.Blockchain, from, to uint64) []*core.StateUpdate {
+	t.Helper()
+
+	gw := adaptfeeder.New(feeder.NewTestClient(t, &utils.Sepolia))
+	stateUpdates := make([]*core.StateUpdate, 0, to-from)
+	for i := from; i < to; i++ {
+		b, err := gw.BlockByNumber(context.Background(), i)
+		require.NoError(t, err)
+		s, err := gw.StateUpdate(context.Background(), i)
+		require.NoError(t, err)
+		require.NoError(t, chain.Store(b, &core.BlockCommitments{}, s, nil))
+		stateUpdates = append(stateUpdates, s)
+	}
+	return stateUpdates
+}
+
+func TestStarknetHandler_GetBlock(t *testing.T) {
+	chain := blockchain.New(pebble.NewMemTest(t), &utils.Sepolia)
+	storeSepoliaBlocks(t, chain, 0, 2)
+	h := NewStarknetHandler(chain, nil)
+
+	first, err := chain.BlockByNumber(0)
+	require.NoError(t, err)
+	head, err := chain.Head()
+	require.NoError(t, err)
+
+	t.Run("by number", func(t *testing.T) {
+		block, err := h.GetBlock(context.Background(), &gen.BlockRequest{BlockId: &gen.BlockRequest_Number{Number: 0}})
+		require.NoError(t, err)
+		assert.Equal(t, core2p2p.AdaptHash(first.Hash), block.Header.BlockHash)
+		assert.Len(t, block.Transactions, len(first.Transactions))
+		assert.Len(t, block.Events, int(first.EventCount))
+	})
+
+	t.Run("by hash", func(t *testing.T) {
+		block, err := h.GetBlock(context.Background(), &gen.BlockRequest{
+			BlockId: &gen.BlockRequest_Hash{Hash: core2p2p.AdaptHash(first.Hash)},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(0), block.Header.Number)
+	})
+
+	t.Run("latest", func(t *testing.T) {
+		block, err := h.GetBlock(context.Background(), &gen.BlockRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, core2p2p.AdaptHash(head.Hash), block.Header.BlockHash)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := h.GetBlock(context.Background(), &gen.BlockRequest{BlockId: &gen.BlockRequest_Number{Number: 2}})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestStarknetHandler_GetStateUpdateAndStorage(t *testing.T) {
+	chain := blockchain.New(pebble.NewMemTest(t), &utils.Sepolia)
+	stateUpdate := storeSepoliaBlocks(t, chain, 0, 1)[0]
+	h := NewStarknetHandler(chain, nil)
+	block := &gen.BlockRequest{BlockId: &gen.BlockRequest_Number{Number: 0}}
+
+	update, err := h.GetStateUpdate(context.Background(), block)
+	require.NoError(t, err)
+	assert.Equal(t, core2p2p.AdaptHash(stateUpdate.BlockHash), update.BlockHash)
+	assert.Equal(t, core2p2p.AdaptHash(stateUpdate.NewRoot), update.NewRoot)
+	require.NotEmpty(t, update.ContractDiffs)
+	for i := 1; i < len(update.ContractDiffs); i++ {
+		prev := p2p2core.AdaptAddress(update.ContractDiffs[i-1].Address)
+		assert.Negative(t, prev.Cmp(p2p2core.AdaptAddress(update.ContractDiffs[i].Address)))
+	}
+
+	for addr, diffs := range stateUpdate.StateDiff.StorageDiffs {
+		for key, value := range diffs {
+			resp, err := h.GetStorageAt(context.Background(), &gen.StorageRequest{
+				Block:    block,
+				Contract: core2p2p.AdaptAddress(&addr),
+				Key:      core2p2p.AdaptFelt(&key),
+			})
+			require.NoError(t, err)
+			assert.Equal(t, core2p2p.AdaptFelt(value), resp.Value)
+		}
+	}
+
+	_, err = h.GetStorageAt(context.Background(), &gen.StorageRequest{Block: block})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestAdaptStateDiff(t *testing.T) {
+	diff := &core.StateDiff{
+		DeclaredV0Classes: []*felt.Felt{new(felt.Felt).SetUint64(4), new(felt.Felt).SetUint64(2)},
+		DeclaredV1Classes: make(map[felt.Felt]*felt.Felt),
+	}
+	for i := range uint64(8) {
+		diff.DeclaredV1Classes[*new(felt.Felt).SetUint64(2*i + 5)] = new(felt.Felt).SetUint64(i)
+	}
+
+	_, declaredClasses := adaptStateDiff(diff)
+	require.Len(t, declaredClasses, 10)
+	for i, declaredClass := range declaredClasses {
+		classHash := p2p2core.AdaptHash(declaredClass.ClassHash)
+		if i > 0 {
+			assert.Negative(t, p2p2core.AdaptHash(declaredClasses[i-1].ClassHash).Cmp(classHash))
+		}
+		assert.Equal(t, core2p2p.AdaptHash(diff.DeclaredV1Classes[*classHash]), declaredClass.CompiledClassHash)
+	}
+}
+
+func TestStarknetHandler_StreamBlocks(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	t.Cleanup(mockCtrl.Finish)
+
+	chain := blockchain.New(pebble.NewMemTest(t), &utils.Sepolia)
+	storeSepoliaBlocks(t, chain, 0, 2)
+
+	newHeads := feed.New[*core.Header]()
+	syncReader := mocks.NewMockSyncReader(mockCtrl)
+	syncReader.EXPECT().SubscribeNewHeads().Return(sync.HeaderSubscription{Subscription: newHeads.Subscribe()})
+	reorgs := feed.New[*sync.ReorgBlockRange]()
+	reorgSub := reorgs.Subscribe()
+	syncReader.EXPECT().SubscribeReorg().Return(sync.ReorgSubscription{Subscription: reorgSub})
+	h := NewStarknetHandler(chain, syncReader)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &blocksStreamMock{ctx: ctx, blocks: make(chan *gen.Block)}
+	done := make(chan error)
+	go func() {
+		done <- h.StreamBlocks(&gen.StreamBlocksRequest{From: 1}, stream)
+	}()
+
+	assert.Equal(t, uint64(1), (<-stream.blocks).Header.Number)
+
+	storeSepoliaBlocks(t, chain, 2, 3)
+	head, err := chain.HeadsHeader()
+	require.NoError(t, err)
+	newHeads.Send(head)
+	assert.Equal(t, uint64(2), (<-stream.blocks).Header.Number)
+
+	storeSepoliaBlocks(t, chain, 3, 4)
+	head, err = chain.HeadsHeader()
+	require.NoError(t, err)
+	newHeads.Send(head)
+	assert.Equal(t, uint64(3), (<-stream.blocks).Header.Number)
+
+	// The blocks are streamed again from the first block of the new chain.
+	require.NoError(t, chain.RevertHead())
+	reorgs.Send(&sync.ReorgBlockRange{
+		StartBlockHash: head.Hash,
+		StartBlockNum:  head.Number,
+		EndBlockHash:   head.Hash,
+		EndBlockNum:    head.Number,
+	})
+	require.Eventually(t, func() bool { return len(reorgSub.Recv()) == 0 }, time.Second, time.Millisecond)
+	storeSepoliaBlocks(t, chain, 3, 5)
+	head, err = chain.HeadsHeader()
+	require.NoError(t, err)
+	newHeads.Send(head)
+	for _, number := range []uint64{3, 4} {
+		assert.Equal(t, number, (<-stream.blocks).Header.Number)
+	}
+
+	cancel()
+	require.NoError(t, <-done)
+}
@@ -0,0 +1,102 @@
+package jsonrpc
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// APIKeyHeader is the header that clients send their API key in.
+const APIKeyHeader = "X-Api-Key"
+
+// bucketsSweepThreshold is the number of clients a RateLimiter tracks before it starts dropping the idle ones.
+const bucketsSweepThreshold = 10_000
+
+type clientKey struct{}
+
+// client identifies the sender of a request.
+type client struct {
+	apiKey string
+	ip     string
+}
+
+// withClient stores the API key and the IP address of the sender of r in ctx.
+func withClient(ctx context.Context, r *http.Request) context.Context {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+	return context.WithValue(ctx, clientKey{}, client{
+		apiKey: r.Header.Get(APIKeyHeader),
+		ip:     ip,
+	})
+}
+
+func clientFromContext(ctx context.Context) client {
+	c, _ := ctx.Value(clientKey{}).(client)
+	return c
+}
+
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// RateLimiter keeps a token bucket per client, which refills at perSecond tokens per second up to burst tokens. Each
+// request takes a token, and is rejected if there are none left.
+type RateLimiter struct {
+	perSecond float64
+	burst     float64
+
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+func NewRateLimiter(perSecond float64, burst uint) *RateLimiter {
+	return &RateLimiter{
+		perSecond: perSecond,
+		burst:     float64(burst),
+		buckets:   make(map[string]*tokenBucket),
+	}
+}
+
+// Allow takes a token from the bucket of clientID, and reports whether there was one.
+func (l *RateLimiter) Allow(clientID string, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	bucket, ok := l.buckets[clientID]
+	if ok {
+		bucket.tokens = l.refill(bucket, now)
+		bucket.updated = now
+	} else {
+		if len(l.buckets) >= bucketsSweepThreshold && now.Sub(l.lastSweep) >= time.Second {
+			l.sweep(now)
+		}
+		bucket = &tokenBucket{tokens: l.burst, updated: now}
+		l.buckets[clientID] = bucket
+	}
+
+	if bucket.tokens < 1 {
+		return false
+	}
+	bucket.tokens--
+	return true
+}
+
+func (l *RateLimiter) refill(bucket *tokenBucket, now time.Time) float64 {
+	return min(l.burst, bucket.tokens+now.Sub(bucket.updated).Seconds()*l.perSecond)
+}
+
+// sweep drops the buckets that are full again, since they behave the same as new ones.
+func (l *RateLimiter) sweep(now time.Time) {
+	for clientID, bucket := range l.buckets {
+		if l.refill(bucket, now) >= l.burst {
+			delete(l.buckets, clientID)
+		}
+	}
+	l.lastSweep = now
+}
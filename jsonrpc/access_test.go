@@ -0,0 +1,104 @@
+package jsonrpc_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/juno/jsonrpc"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter(t *testing.T) {
+	limiter := jsonrpc.NewRateLimiter(2, 2)
+	now := time.Now()
+
+	assert.True(t, limiter.Allow("a", now))
+	assert.True(t, limiter.Allow("a", now))
+	assert.False(t, limiter.Allow("a", now))
+	// Other clients have buckets of their own.
+	assert.True(t, limiter.Allow("b", now))
+
+	// Half a second refills a token at two tokens per second.
+	now = now.Add(500 * time.Millisecond)
+	assert.True(t, limiter.Allow("a", now))
+	assert.False(t, limiter.Allow("a", now))
+
+	// Buckets don't refill past the burst.
+	now = now.Add(time.Hour)
+	assert.True(t, limiter.Allow("a", now))
+	assert.True(t, limiter.Allow("a", now))
+	assert.False(t, limiter.Allow("a", now))
+}
+
+func TestAccess(t *testing.T) {
+	echo := func(msg string) (string, *jsonrpc.Error) {
+		return msg, nil
+	}
+	listener := CountingEventListener{}
+	log := utils.NewNopZapLogger()
+	rpc := jsonrpc.NewServer(1, log).WithListener(&listener).
+		WithAPIKeys("key1", "key2").
+		WithRateLimiter(jsonrpc.NewRateLimiter(0, 2)).
+		WithRateLimiter(jsonrpc.NewRateLimiter(0, 1), "expensive")
+	require.NoError(t, rpc.RegisterMethods(
+		jsonrpc.Method{Name: "cheap", Handler: echo, Params: []jsonrpc.Parameter{{Name: "msg"}}},
+		jsonrpc.Method{Name: "expensive", Handler: echo, Params: []jsonrpc.Parameter{{Name: "msg"}}},
+	))
+	srv := httptest.NewServer(jsonrpc.NewHTTP(rpc, log))
+	t.Cleanup(srv.Close)
+
+	call := func(t *testing.T, apiKey, method string) string {
+		t.Helper()
+
+		msg := `{"jsonrpc" : "2.0", "method" : "` + method + `", "params" : [ "abc" ], "id" : 1}`
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, srv.URL, bytes.NewReader([]byte(msg)))
+		require.NoError(t, err)
+		if apiKey != "" {
+			req.Header.Set(jsonrpc.APIKeyHeader, apiKey)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, resp.Body.Close())
+		}()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		got, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return string(got)
+	}
+
+	const (
+		ok           = `{"jsonrpc":"2.0","result":"abc","id":1}`
+		unauthorized = `{"jsonrpc":"2.0","error":{"code":-32001,"message":"Unauthorized"},"id":1}`
+		limited      = `{"jsonrpc":"2.0","error":{"code":-32005,"message":"Limit exceeded"},"id":1}`
+	)
+
+	t.Run("unauthorized", func(t *testing.T) {
+		assert.Equal(t, unauthorized, call(t, "", "cheap"))
+		assert.Equal(t, unauthorized, call(t, "wrong", "cheap"))
+	})
+
+	t.Run("limits per method group and key", func(t *testing.T) {
+		assert.Equal(t, ok, call(t, "key1", "expensive"))
+		assert.Equal(t, limited, call(t, "key1", "expensive"))
+
+		assert.Equal(t, ok, call(t, "key1", "cheap"))
+		assert.Equal(t, ok, call(t, "key1", "cheap"))
+		assert.Equal(t, limited, call(t, "key1", "cheap"))
+
+		assert.Equal(t, ok, call(t, "key2", "expensive"))
+	})
+
+	require.Len(t, listener.OnRequestRejectedCalls, 4)
+	assert.Equal(t, jsonrpc.Unauthorized, listener.OnRequestRejectedCalls[0].err.Code)
+	assert.Equal(t, jsonrpc.LimitExceeded, listener.OnRequestRejectedCalls[2].err.Code)
+	assert.Equal(t, "expensive", listener.OnRequestRejectedCalls[2].method)
+}
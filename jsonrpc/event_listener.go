@@ -10,12 +10,14 @@ type EventListener interface {
 	NewRequestListener
 	OnRequestHandled(method string, took time.Duration)
 	OnRequestFailed(method string, data any)
+	OnRequestRejected(method string, err *Error)
 }
 
 type SelectiveListener struct {
-	OnNewRequestCb     func(method string)
-	OnRequestHandledCb func(method string, took time.Duration)
-	OnRequestFailedCb  func(method string, data any)
+	OnNewRequestCb      func(method string)
+	OnRequestHandledCb  func(method string, took time.Duration)
+	OnRequestFailedCb   func(method string, data any)
+	OnRequestRejectedCb func(method string, err *Error)
 }
 
 func (l *SelectiveListener) OnNewRequest(method string) {
@@ -35,3 +37,9 @@ func (l *SelectiveListener) OnRequestFailed(method string, data any) {
 		l.OnRequestFailedCb(method, data)
 	}
 }
+
+func (l *SelectiveListener) OnRequestRejected(method string, err *Error) {
+	if l.OnRequestRejectedCb != nil {
+		l.OnRequestRejectedCb(method, err)
+	}
+}
@@ -1,6 +1,10 @@
 package jsonrpc_test
 
-import "time"
+import (
+	"time"
+
+	"github.com/NethermindEth/juno/jsonrpc"
+)
 
 type CountingEventListener struct {
 	OnNewRequestLogs      []string
@@ -12,6 +16,10 @@ type CountingEventListener struct {
 		method string
 		data   any
 	}
+	OnRequestRejectedCalls []struct {
+		method string
+		err    *jsonrpc.Error
+	}
 }
 
 func (l *CountingEventListener) OnNewRequest(method string) {
@@ -37,3 +45,13 @@ func (l *CountingEventListener) OnRequestFailed(method string, data any) {
 		data:   data,
 	})
 }
+
+func (l *CountingEventListener) OnRequestRejected(method string, err *jsonrpc.Error) {
+	l.OnRequestRejectedCalls = append(l.OnRequestRejectedCalls, struct {
+		method string
+		err    *jsonrpc.Error
+	}{
+		method: method,
+		err:    err,
+	})
+}
@@ -47,7 +47,7 @@ func (h *HTTP) ServeHTTP(writer http.ResponseWriter, req *http.Request) {
 
 	req.Body = http.MaxBytesReader(writer, req.Body, MaxRequestBodySize)
 	h.listener.OnNewRequest("any")
-	resp, header, err := h.rpc.HandleReader(req.Context(), req.Body)
+	resp, header, err := h.rpc.HandleReader(withClient(req.Context(), req), req.Body)
 
 	writer.Header().Set("Content-Type", "application/json")
 	maps.Copy(writer.Header(), header) // overwrites duplicate headers
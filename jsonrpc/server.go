@@ -25,6 +25,8 @@ const (
 	MethodNotFound = -32601 // The method does not exist / is not available.
 	InvalidParams  = -32602 // Invalid method parameter(s).
 	InternalError  = -32603 // Internal JSON-RPC error.
+	Unauthorized   = -32001 // The request doesn't carry a valid API key.
+	LimitExceeded  = -32005 // The client exceeded its rate limit.
 )
 
 var (
@@ -71,6 +73,10 @@ func Err(code int, data any) *Error {
 		return &Error{Code: MethodNotFound, Message: "Method Not Found", Data: data}
 	case InvalidParams:
 		return &Error{Code: InvalidParams, Message: "Invalid Params", Data: data}
+	case Unauthorized:
+		return &Error{Code: Unauthorized, Message: "Unauthorized", Data: data}
+	case LimitExceeded:
+		return &Error{Code: LimitExceeded, Message: "Limit exceeded", Data: data}
 	default:
 		return &Error{Code: InternalError, Message: "Internal error", Data: data}
 	}
@@ -123,6 +129,10 @@ type Server struct {
 	pool      *pool.Pool
 	log       utils.SimpleLogger
 	listener  EventListener
+
+	apiKeys            map[string]struct{}
+	rateLimiters       map[string]*RateLimiter
+	defaultRateLimiter *RateLimiter
 }
 
 type Validator interface {
@@ -153,6 +163,36 @@ func (s *Server) WithListener(listener EventListener) *Server {
 	return s
 }
 
+// WithAPIKeys rejects the requests that don't carry one of keys in the APIKeyHeader header. Empty keys are ignored,
+// as requests without the header would match them.
+func (s *Server) WithAPIKeys(keys ...string) *Server {
+	s.apiKeys = make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		if key != "" {
+			s.apiKeys[key] = struct{}{}
+		}
+	}
+	return s
+}
+
+// WithRateLimiter limits the requests to methods with limiter, or to all the methods without a limiter of their own if
+// no methods are given. Clients are told apart by their API key, or by their IP address if the server doesn't require
+// API keys.
+func (s *Server) WithRateLimiter(limiter *RateLimiter, methods ...string) *Server {
+	if len(methods) == 0 {
+		s.defaultRateLimiter = limiter
+		return s
+	}
+
+	if s.rateLimiters == nil {
+		s.rateLimiters = make(map[string]*RateLimiter, len(methods))
+	}
+	for _, method := range methods {
+		s.rateLimiters[method] = limiter
+	}
+	return s
+}
+
 // RegisterMethods verifies and creates an endpoint that the server recognises.
 //
 // - name is the method name
@@ -447,6 +487,15 @@ func (s *Server) handleRequest(ctx context.Context, req *Request) (*response, ht
 		return res, header, nil
 	}
 
+	if res.Error = s.admit(ctx, req.Method); res.Error != nil {
+		s.listener.OnRequestRejected(req.Method, res.Error)
+		s.log.Tracew("Request rejected", "method", req.Method, "err", res.Error.Message)
+		if res.ID == nil { // notification
+			return nil, header, nil
+		}
+		return res, header, nil
+	}
+
 	handlerTimer := time.Now()
 	s.listener.OnNewRequest(req.Method)
 	args, err := s.buildArguments(ctx, req.Params, calledMethod)
@@ -486,6 +535,27 @@ func (s *Server) handleRequest(ctx context.Context, req *Request) (*response, ht
 	return res, header, nil
 }
 
+// admit checks the API key and the rate limit of the client that sent the request for method.
+func (s *Server) admit(ctx context.Context, method string) *Error {
+	c := clientFromContext(ctx)
+	clientID := c.ip
+	if s.apiKeys != nil {
+		if _, ok := s.apiKeys[c.apiKey]; !ok {
+			return Err(Unauthorized, nil)
+		}
+		clientID = c.apiKey
+	}
+
+	limiter, ok := s.rateLimiters[method]
+	if !ok {
+		limiter = s.defaultRateLimiter
+	}
+	if limiter != nil && !limiter.Allow(clientID, time.Now()) {
+		return Err(LimitExceeded, nil)
+	}
+	return nil
+}
+
 func (s *Server) buildArguments(ctx context.Context, params any, method Method) ([]reflect.Value, error) {
 	handlerType := reflect.TypeOf(method.Handler)
 
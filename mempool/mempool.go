@@ -0,0 +1,412 @@
+package mempool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/clients/gateway"
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/service"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/NethermindEth/juno/vm"
+)
+
+const (
+	defaultMaxSize       = 1024
+	defaultRetryInterval = 5 * time.Second
+	defaultTTL           = time.Hour
+	blockHashLag         = 10
+)
+
+var (
+	ErrDuplicateTransaction = errors.New("transaction already known")
+	ErrInvalidNonce         = errors.New("invalid transaction nonce")
+	ErrPoolFull             = errors.New("mempool is full")
+)
+
+// Gateway relays transactions to the sequencer.
+type Gateway interface {
+	AddTransaction(context.Context, json.RawMessage) (json.RawMessage, error)
+}
+
+// Gossiper propagates the transactions submitted through this node to peers.
+type Gossiper interface {
+	GossipTransaction(ctx context.Context, txn core.Transaction, declaredClass core.Class) error
+}
+
+// BroadcastedTransaction is a transaction submitted to the node, together with everything needed to
+// execute it locally and to relay it to the gateway.
+type BroadcastedTransaction struct {
+	Transaction   core.Transaction
+	DeclaredClass core.Class
+	// Payload is the transaction as expected by the gateway.
+	Payload json.RawMessage
+}
+
+type entry struct {
+	txn         *BroadcastedTransaction
+	receivedAt  time.Time
+	broadcasted bool
+}
+
+// Pool keeps the transactions submitted through this node until they are included in a stored block.
+// Transactions are validated against the pending state before they are accepted, and relayed to the
+// gateway until it acknowledges them.
+type Pool struct {
+	bcReader blockchain.Reader
+	vm       vm.VM
+	gateway  Gateway
+	gossiper Gossiper
+	log      utils.SimpleLogger
+
+	maxSize       int
+	retryInterval time.Duration
+	ttl           time.Duration
+
+	mu  sync.RWMutex // protects txs.
+	txs map[felt.Felt]*entry
+}
+
+var _ service.Service = (*Pool)(nil)
+
+func New(bcReader blockchain.Reader, virtualMachine vm.VM, gatewayClient Gateway, log utils.SimpleLogger) *Pool {
+	return &Pool{
+		bcReader:      bcReader,
+		vm:            virtualMachine,
+		gateway:       gatewayClient,
+		log:           log,
+		maxSize:       defaultMaxSize,
+		retryInterval: defaultRetryInterval,
+		ttl:           defaultTTL,
+		txs:           make(map[felt.Felt]*entry),
+	}
+}
+
+// WithMaxSize sets the maximum number of transactions the pool holds at once.
+func (p *Pool) WithMaxSize(maxSize int) *Pool {
+	p.maxSize = maxSize
+	return p
+}
+
+// WithRetryInterval sets how often transactions the gateway didn't acknowledge are re-broadcasted.
+func (p *Pool) WithRetryInterval(interval time.Duration) *Pool {
+	p.retryInterval = interval
+	return p
+}
+
+// WithTTL sets how long a transaction is kept if it never makes it into a block.
+func (p *Pool) WithTTL(ttl time.Duration) *Pool {
+	p.ttl = ttl
+	return p
+}
+
+// WithGossiper propagates the transactions added with [Pool.Add] to peers through gossiper.
+func (p *Pool) WithGossiper(gossiper Gossiper) *Pool {
+	p.gossiper = gossiper
+	return p
+}
+
+// Add validates txn and adds it to the pool. It then tries to broadcast it once, if the gateway rejects it
+// the transaction is dropped and the gateway error is returned. Other broadcast failures are retried by [Pool.Run].
+func (p *Pool) Add(ctx context.Context, txn *BroadcastedTransaction) error {
+	e, err := p.insert(txn, false)
+	if err != nil {
+		return err
+	}
+
+	hash := txn.Transaction.Hash()
+	if err := p.broadcast(ctx, e); err != nil {
+		var gatewayErr *gateway.Error
+		if errors.As(err, &gatewayErr) {
+			p.remove(hash)
+			return err
+		}
+		p.log.Debugw("Failed to broadcast transaction, will retry", "hash", hash, "err", err)
+	}
+
+	if p.gossiper != nil {
+		if err := p.gossiper.GossipTransaction(ctx, txn.Transaction, txn.DeclaredClass); err != nil {
+			p.log.Debugw("Failed to gossip transaction", "hash", hash, "err", err)
+		}
+	}
+	return nil
+}
+
+// AddGossiped validates a transaction gossiped by a peer and adds it to the pool. It isn't broadcasted to the
+// gateway, as the node it was submitted to does that.
+func (p *Pool) AddGossiped(txn core.Transaction, declaredClass core.Class) error {
+	_, err := p.insert(&BroadcastedTransaction{Transaction: txn, DeclaredClass: declaredClass}, true)
+	return err
+}
+
+// insert validates txn and adds it to the pool. Validation runs without holding the lock, so the nonce is checked
+// again before txn is added.
+func (p *Pool) insert(txn *BroadcastedTransaction, broadcasted bool) (*entry, error) {
+	hash := txn.Transaction.Hash()
+	if p.Contains(hash) {
+		return nil, ErrDuplicateTransaction
+	}
+	if _, err := p.bcReader.TransactionByHash(hash); err == nil {
+		return nil, ErrDuplicateTransaction
+	} else if !errors.Is(err, db.ErrKeyNotFound) {
+		return nil, err
+	}
+
+	if err := p.validate(txn); err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.txs[*hash]; ok {
+		return nil, ErrDuplicateTransaction
+	}
+	if len(p.txs) >= p.maxSize {
+		return nil, ErrPoolFull
+	}
+	// Another transaction of the same sender with the same nonce may have been added while txn was validated.
+	if sender, nonce := senderAndNonce(txn.Transaction); sender != nil {
+		for _, queued := range p.txs {
+			if queuedSender, queuedNonce := senderAndNonce(queued.txn.Transaction); queuedSender != nil &&
+				queuedSender.Equal(sender) && queuedNonce.Equal(nonce) {
+				return nil, fmt.Errorf("%w: nonce %s is already used by a queued transaction", ErrInvalidNonce, nonce)
+			}
+		}
+	}
+	e := &entry{txn: txn, receivedAt: time.Now(), broadcasted: broadcasted}
+	p.txs[*hash] = e
+	return e, nil
+}
+
+// Contains returns true if the transaction is waiting in the pool.
+func (p *Pool) Contains(hash *felt.Felt) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	_, ok := p.txs[*hash]
+	return ok
+}
+
+// Len returns the number of transactions in the pool.
+func (p *Pool) Len() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return len(p.txs)
+}
+
+func (p *Pool) Run(ctx context.Context) error {
+	ticker := time.NewTicker(p.retryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			p.update(ctx)
+		}
+	}
+}
+
+// update drops the transactions that were included in a block or expired, and re-broadcasts the ones the
+// gateway hasn't acknowledged yet.
+func (p *Pool) update(ctx context.Context) {
+	p.mu.RLock()
+	entries := make([]*entry, 0, len(p.txs))
+	for _, e := range p.txs {
+		entries = append(entries, e)
+	}
+	p.mu.RUnlock()
+
+	for _, e := range entries {
+		hash := e.txn.Transaction.Hash()
+		if _, err := p.bcReader.TransactionByHash(hash); err == nil {
+			p.remove(hash)
+			continue
+		}
+		if time.Since(e.receivedAt) > p.ttl {
+			p.log.Debugw("Dropping expired transaction", "hash", hash)
+			p.remove(hash)
+			continue
+		}
+
+		p.mu.RLock()
+		broadcasted := e.broadcasted
+		p.mu.RUnlock()
+		if broadcasted {
+			continue
+		}
+
+		if err := p.broadcast(ctx, e); err != nil {
+			var gatewayErr *gateway.Error
+			if errors.As(err, &gatewayErr) {
+				p.log.Debugw("Gateway rejected transaction", "hash", hash, "err", err)
+				p.remove(hash)
+			} else {
+				p.log.Debugw("Failed to broadcast transaction", "hash", hash, "err", err)
+			}
+		}
+	}
+}
+
+func (p *Pool) broadcast(ctx context.Context, e *entry) error {
+	_, err := p.gateway.AddTransaction(ctx, e.txn.Payload)
+	var gatewayErr *gateway.Error
+	if err != nil && (!errors.As(err, &gatewayErr) || gatewayErr.Code != gateway.DuplicatedTransaction) {
+		return err
+	}
+
+	p.mu.Lock()
+	e.broadcasted = true
+	p.mu.Unlock()
+	return nil
+}
+
+func (p *Pool) remove(hash *felt.Felt) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.txs, *hash)
+}
+
+// validate checks the nonce of txn and executes it on top of the pending state, after the transactions of the
+// same sender that are already in the pool. Execution runs the account's validation entry point and the fee
+// checks, so a bad signature or an insufficient balance is caught here. A transaction whose execution reverts is
+// rejected as well, the pool only relays transactions that are expected to succeed.
+func (p *Pool) validate(txn *BroadcastedTransaction) error {
+	state, closer, header, err := p.pendingState()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := closer(); closeErr != nil {
+			p.log.Errorw("Failed to close state in mempool validation", "err", closeErr)
+		}
+	}()
+
+	txns := []core.Transaction{txn.Transaction}
+	var classes []core.Class
+	if txn.DeclaredClass != nil {
+		classes = append(classes, txn.DeclaredClass)
+	}
+
+	sender, nonce := senderAndNonce(txn.Transaction)
+	if sender != nil {
+		accountNonce, err := state.ContractNonce(sender)
+		if err != nil {
+			if !errors.Is(err, db.ErrKeyNotFound) {
+				return err
+			}
+			accountNonce = &felt.Zero
+		}
+
+		queued := p.queued(sender, accountNonce)
+		expectedNonce := new(felt.Felt).Add(accountNonce, new(felt.Felt).SetUint64(uint64(len(queued))))
+		if !nonce.Equal(expectedNonce) {
+			return fmt.Errorf("%w: expected %s, got %s", ErrInvalidNonce, expectedNonce, nonce)
+		}
+
+		queuedTxns := make([]core.Transaction, 0, len(queued)+1)
+		var queuedClasses []core.Class
+		for _, q := range queued {
+			queuedTxns = append(queuedTxns, q.Transaction)
+			if q.DeclaredClass != nil {
+				queuedClasses = append(queuedClasses, q.DeclaredClass)
+			}
+		}
+		txns = append(queuedTxns, txns...)
+		classes = append(queuedClasses, classes...)
+	}
+
+	blockHashToBeRevealed, err := p.revealedBlockHash(header.Number)
+	if err != nil {
+		return err
+	}
+	blockInfo := vm.BlockInfo{
+		Header:                header,
+		BlockHashToBeRevealed: blockHashToBeRevealed,
+	}
+	_, _, _, _, err = p.vm.Execute(txns, classes, nil, &blockInfo, state, p.bcReader.Network(), false, false, true) //nolint:dogsled
+	return err
+}
+
+// pendingState returns the pending state and header, falling back to the head if there is no pending block.
+func (p *Pool) pendingState() (core.StateReader, blockchain.StateCloser, *core.Header, error) {
+	if pending, err := p.bcReader.Pending(); err == nil {
+		state, closer, err := p.bcReader.PendingState()
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return state, closer, pending.Block.Header, nil
+	}
+
+	header, err := p.bcReader.HeadsHeader()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	state, closer, err := p.bcReader.HeadState()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return state, closer, header, nil
+}
+
+func (p *Pool) revealedBlockHash(blockNumber uint64) (*felt.Felt, error) {
+	if blockNumber < blockHashLag {
+		return nil, nil
+	}
+
+	header, err := p.bcReader.BlockHeaderByNumber(blockNumber - blockHashLag)
+	if err != nil {
+		return nil, err
+	}
+	return header.Hash, nil
+}
+
+// queued returns the pooled transactions of sender with a nonce of at least accountNonce, ordered by nonce.
+func (p *Pool) queued(sender, accountNonce *felt.Felt) []*BroadcastedTransaction {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	var queued []*BroadcastedTransaction
+	for _, e := range p.txs {
+		txnSender, txnNonce := senderAndNonce(e.txn.Transaction)
+		if txnSender != nil && txnSender.Equal(sender) && txnNonce.Cmp(accountNonce) >= 0 {
+			queued = append(queued, e.txn)
+		}
+	}
+	slices.SortFunc(queued, func(a, b *BroadcastedTransaction) int {
+		_, aNonce := senderAndNonce(a.Transaction)
+		_, bNonce := senderAndNonce(b.Transaction)
+		return aNonce.Cmp(bNonce)
+	})
+	return queued
+}
+
+// senderAndNonce returns the account whose nonce txn uses, or nil if txn doesn't use one.
+func senderAndNonce(txn core.Transaction) (*felt.Felt, *felt.Felt) {
+	switch t := txn.(type) {
+	case *core.InvokeTransaction:
+		if t.Nonce == nil {
+			return nil, nil
+		}
+		if t.SenderAddress != nil {
+			return t.SenderAddress, t.Nonce
+		}
+		return t.ContractAddress, t.Nonce
+	case *core.DeclareTransaction:
+		if t.Nonce == nil {
+			return nil, nil
+		}
+		return t.SenderAddress, t.Nonce
+	case *core.DeployAccountTransaction:
+		return t.ContractAddress, t.Nonce
+	default:
+		return nil, nil
+	}
+}
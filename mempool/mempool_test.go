@@ -0,0 +1,229 @@
+package mempool_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/clients/gateway"
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/mempool"
+	"github.com/NethermindEth/juno/mocks"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/NethermindEth/juno/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newInvoke(hash, sender, nonce uint64) *mempool.BroadcastedTransaction {
+	return &mempool.BroadcastedTransaction{
+		Transaction: &core.InvokeTransaction{
+			TransactionHash: new(felt.Felt).SetUint64(hash),
+			SenderAddress:   new(felt.Felt).SetUint64(sender),
+			Nonce:           new(felt.Felt).SetUint64(nonce),
+			Version:         new(core.TransactionVersion).SetUint64(1),
+		},
+		Payload: json.RawMessage(`{}`),
+	}
+}
+
+func TestPoolAdd(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	t.Cleanup(mockCtrl.Finish)
+
+	mockReader := mocks.NewMockReader(mockCtrl)
+	mockVM := mocks.NewMockVM(mockCtrl)
+	mockGateway := mocks.NewMockGateway(mockCtrl)
+	mockState := mocks.NewMockStateHistoryReader(mockCtrl)
+
+	sender := new(felt.Felt).SetUint64(0xabc)
+	mockReader.EXPECT().TransactionByHash(gomock.Any()).Return(nil, db.ErrKeyNotFound).AnyTimes()
+	mockReader.EXPECT().Pending().Return(blockchain.Pending{}, db.ErrKeyNotFound).AnyTimes()
+	mockReader.EXPECT().HeadsHeader().Return(&core.Header{Number: 1}, nil).AnyTimes()
+	mockReader.EXPECT().HeadState().Return(mockState, func() error { return nil }, nil).AnyTimes()
+	mockReader.EXPECT().Network().Return(&utils.Mainnet).AnyTimes()
+	mockState.EXPECT().ContractNonce(sender).Return(new(felt.Felt).SetUint64(1), nil).AnyTimes()
+
+	pool := mempool.New(mockReader, mockVM, mockGateway, utils.NewNopZapLogger())
+	ctx := context.Background()
+
+	t.Run("valid transaction is added and broadcasted", func(t *testing.T) {
+		txn := newInvoke(1, 0xabc, 1)
+		mockVM.EXPECT().Execute([]core.Transaction{txn.Transaction}, nil, nil, gomock.Any(), mockState,
+			&utils.Mainnet, false, false, true).Return(nil, nil, nil, uint64(0), nil)
+		mockGateway.EXPECT().AddTransaction(gomock.Any(), txn.Payload).Return(json.RawMessage(`{}`), nil)
+
+		require.NoError(t, pool.Add(ctx, txn))
+		assert.True(t, pool.Contains(txn.Transaction.Hash()))
+		assert.Equal(t, 1, pool.Len())
+	})
+
+	t.Run("duplicate transaction", func(t *testing.T) {
+		assert.ErrorIs(t, pool.Add(ctx, newInvoke(1, 0xabc, 1)), mempool.ErrDuplicateTransaction)
+	})
+
+	t.Run("nonce already used by a queued transaction", func(t *testing.T) {
+		assert.ErrorIs(t, pool.Add(ctx, newInvoke(2, 0xabc, 1)), mempool.ErrInvalidNonce)
+		assert.False(t, pool.Contains(new(felt.Felt).SetUint64(2)))
+	})
+
+	t.Run("next nonce is executed after the queued transaction", func(t *testing.T) {
+		queued := newInvoke(1, 0xabc, 1)
+		txn := newInvoke(3, 0xabc, 2)
+		mockVM.EXPECT().Execute([]core.Transaction{queued.Transaction, txn.Transaction}, nil, nil, gomock.Any(),
+			mockState, &utils.Mainnet, false, false, true).Return(nil, nil, nil, uint64(0), nil)
+		mockGateway.EXPECT().AddTransaction(gomock.Any(), txn.Payload).Return(json.RawMessage(`{}`), nil)
+
+		require.NoError(t, pool.Add(ctx, txn))
+		assert.Equal(t, 2, pool.Len())
+	})
+
+	t.Run("validation failure", func(t *testing.T) {
+		txn := newInvoke(4, 0xdef, 0)
+		mockState.EXPECT().ContractNonce(new(felt.Felt).SetUint64(0xdef)).Return(&felt.Zero, nil)
+		validationErr := vm.TransactionExecutionError{Index: 0, Cause: errors.New("invalid signature")}
+		mockVM.EXPECT().Execute(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any(), false, false, true).Return(nil, nil, nil, uint64(0), validationErr)
+
+		assert.ErrorIs(t, pool.Add(ctx, txn), validationErr)
+		assert.False(t, pool.Contains(txn.Transaction.Hash()))
+	})
+
+	t.Run("gateway rejection drops the transaction", func(t *testing.T) {
+		txn := newInvoke(5, 0xdef, 0)
+		mockState.EXPECT().ContractNonce(new(felt.Felt).SetUint64(0xdef)).Return(&felt.Zero, nil)
+		mockVM.EXPECT().Execute(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any(), false, false, true).Return(nil, nil, nil, uint64(0), nil)
+		gatewayErr := &gateway.Error{Code: gateway.InsufficientAccountBalance}
+		mockGateway.EXPECT().AddTransaction(gomock.Any(), txn.Payload).Return(nil, gatewayErr)
+
+		assert.Equal(t, gatewayErr, pool.Add(ctx, txn))
+		assert.False(t, pool.Contains(txn.Transaction.Hash()))
+	})
+
+	t.Run("gossiped transaction is added without broadcasting", func(t *testing.T) {
+		txn := newInvoke(7, 0xdef, 0)
+		mockState.EXPECT().ContractNonce(new(felt.Felt).SetUint64(0xdef)).Return(&felt.Zero, nil)
+		mockVM.EXPECT().Execute([]core.Transaction{txn.Transaction}, nil, nil, gomock.Any(), mockState,
+			&utils.Mainnet, false, false, true).Return(nil, nil, nil, uint64(0), nil)
+
+		require.NoError(t, pool.AddGossiped(txn.Transaction, nil))
+		assert.True(t, pool.Contains(txn.Transaction.Hash()))
+		assert.ErrorIs(t, pool.AddGossiped(txn.Transaction, nil), mempool.ErrDuplicateTransaction)
+	})
+
+	t.Run("pool is full", func(t *testing.T) {
+		fullPool := mempool.New(mockReader, mockVM, mockGateway, utils.NewNopZapLogger()).WithMaxSize(0)
+		txn := newInvoke(6, 0xdef, 0)
+		mockState.EXPECT().ContractNonce(new(felt.Felt).SetUint64(0xdef)).Return(&felt.Zero, nil)
+		mockVM.EXPECT().Execute(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any(), false, false, true).Return(nil, nil, nil, uint64(0), nil)
+
+		assert.ErrorIs(t, fullPool.Add(ctx, txn), mempool.ErrPoolFull)
+	})
+}
+
+func TestPoolRun(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	t.Cleanup(mockCtrl.Finish)
+
+	mockReader := mocks.NewMockReader(mockCtrl)
+	mockVM := mocks.NewMockVM(mockCtrl)
+	mockGateway := mocks.NewMockGateway(mockCtrl)
+	mockState := mocks.NewMockStateHistoryReader(mockCtrl)
+
+	txn := newInvoke(1, 0xabc, 0)
+	mockReader.EXPECT().Pending().Return(blockchain.Pending{}, db.ErrKeyNotFound).AnyTimes()
+	mockReader.EXPECT().HeadsHeader().Return(&core.Header{Number: 1}, nil).AnyTimes()
+	mockReader.EXPECT().HeadState().Return(mockState, func() error { return nil }, nil).AnyTimes()
+	mockReader.EXPECT().Network().Return(&utils.Mainnet).AnyTimes()
+	mockState.EXPECT().ContractNonce(gomock.Any()).Return(&felt.Zero, nil).AnyTimes()
+	mockVM.EXPECT().Execute(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any(), false, false, true).Return(nil, nil, nil, uint64(0), nil)
+
+	pool := mempool.New(mockReader, mockVM, mockGateway, utils.NewNopZapLogger()).WithRetryInterval(time.Millisecond)
+
+	// The gateway is unreachable when the transaction is submitted, so it stays in the pool.
+	mockReader.EXPECT().TransactionByHash(txn.Transaction.Hash()).Return(nil, db.ErrKeyNotFound)
+	mockGateway.EXPECT().AddTransaction(gomock.Any(), txn.Payload).Return(nil, errors.New("connection refused"))
+	require.NoError(t, pool.Add(context.Background(), txn))
+	require.True(t, pool.Contains(txn.Transaction.Hash()))
+
+	// It is re-broadcasted on the next tick, and dropped once it is stored in a block.
+	broadcasted := make(chan struct{})
+	gomock.InOrder(
+		mockReader.EXPECT().TransactionByHash(txn.Transaction.Hash()).Return(nil, db.ErrKeyNotFound),
+		mockReader.EXPECT().TransactionByHash(txn.Transaction.Hash()).Return(txn.Transaction, nil).AnyTimes(),
+	)
+	mockGateway.EXPECT().AddTransaction(gomock.Any(), txn.Payload).DoAndReturn(
+		func(context.Context, json.RawMessage) (json.RawMessage, error) {
+			close(broadcasted)
+			return json.RawMessage(`{}`), nil
+		})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go func() {
+		assert.NoError(t, pool.Run(ctx))
+	}()
+
+	<-broadcasted
+	require.Eventually(t, func() bool {
+		return pool.Len() == 0
+	}, time.Second, time.Millisecond)
+}
+
+func TestPoolAddConcurrently(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	t.Cleanup(mockCtrl.Finish)
+
+	mockReader := mocks.NewMockReader(mockCtrl)
+	mockVM := mocks.NewMockVM(mockCtrl)
+	mockGateway := mocks.NewMockGateway(mockCtrl)
+	mockState := mocks.NewMockStateHistoryReader(mockCtrl)
+
+	mockReader.EXPECT().TransactionByHash(gomock.Any()).Return(nil, db.ErrKeyNotFound).AnyTimes()
+	mockReader.EXPECT().Pending().Return(blockchain.Pending{}, db.ErrKeyNotFound).AnyTimes()
+	mockReader.EXPECT().HeadsHeader().Return(&core.Header{Number: 1}, nil).AnyTimes()
+	mockReader.EXPECT().HeadState().Return(mockState, func() error { return nil }, nil).AnyTimes()
+	mockReader.EXPECT().Network().Return(&utils.Mainnet).AnyTimes()
+	mockState.EXPECT().ContractNonce(gomock.Any()).Return(&felt.Zero, nil).AnyTimes()
+
+	// Both transactions pass validation before either is added to the pool.
+	var validating sync.WaitGroup
+	validating.Add(2)
+	mockVM.EXPECT().Execute(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any(), false, false, true).DoAndReturn(func([]core.Transaction, []core.Class, []*felt.Felt, *vm.BlockInfo,
+		core.StateReader, *utils.Network, bool, bool, bool,
+	) ([]*felt.Felt, []core.GasConsumed, []vm.TransactionTrace, uint64, error) {
+		validating.Done()
+		validating.Wait()
+		return nil, nil, nil, 0, nil
+	}).Times(2)
+	mockGateway.EXPECT().AddTransaction(gomock.Any(), gomock.Any()).Return(json.RawMessage(`{}`), nil).AnyTimes()
+
+	pool := mempool.New(mockReader, mockVM, mockGateway, utils.NewNopZapLogger())
+	errs := make(chan error, 2)
+	for hash := range uint64(2) {
+		go func() {
+			errs <- pool.Add(context.Background(), newInvoke(hash+1, 0xabc, 0))
+		}()
+	}
+
+	var failed int
+	for range 2 {
+		if err := <-errs; err != nil {
+			assert.ErrorIs(t, err, mempool.ErrInvalidNonce)
+			failed++
+		}
+	}
+	assert.Equal(t, 1, failed)
+	assert.Equal(t, 1, pool.Len())
+}
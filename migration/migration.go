@@ -67,6 +67,7 @@ var defaultMigrations = []Migration{
 	NewBucketMigrator(db.StateUpdatesByBlockNumber, changeStateDiffStruct).WithBatchSize(100), //nolint:mnd
 	NewBucketMigrator(db.Class, migrateCairo1CompiledClass).WithBatchSize(1_000),              //nolint:mnd
 	MigrationFunc(calculateL1MsgHashes),
+	MigrationFunc(buildEventIndex),
 }
 
 var ErrCallWithNewTransaction = errors.New("call with new transaction")
@@ -506,6 +507,22 @@ func calculateL1MsgHashes(txn db.Transaction, n *utils.Network) error {
 	return processBlocks(txn, processBlockFunc)
 }
 
+// buildEventIndex backfills the event index for the blocks stored before it was introduced
+func buildEventIndex(txn db.Transaction, _ *utils.Network) error {
+	processBlockFunc := func(blockNumber uint64, txnLock *sync.Mutex) error {
+		txnLock.Lock()
+		block, err := blockchain.BlockByNumber(txn, blockNumber)
+		txnLock.Unlock()
+		if err != nil {
+			return err
+		}
+		txnLock.Lock()
+		defer txnLock.Unlock()
+		return blockchain.StoreEventIndex(txn, blockNumber, block.Receipts)
+	}
+	return processBlocks(txn, processBlockFunc)
+}
+
 func bitset2Key(bs *bitset.BitSet) *trie.Key {
 	bsWords := bs.Bytes()
 	if len(bsWords) > felt.Limbs {
@@ -223,6 +223,54 @@ func TestL1HandlerTxns(t *testing.T) {
 	assert.Equal(t, l1HandlerTxnHash.String(), "0x785c2ada3f53fbc66078d47715c27718f92e6e48b96372b36e5197de69b82b5")
 }
 
+func TestBuildEventIndex(t *testing.T) {
+	testdb := pebble.NewMemTest(t)
+	chain := blockchain.New(testdb, &utils.Sepolia)
+	client := feeder.NewTestClient(t, &utils.Sepolia)
+	gw := adaptfeeder.New(client)
+
+	for i := uint64(0); i <= 6; i++ {
+		b, err := gw.BlockByNumber(context.Background(), i)
+		require.NoError(t, err)
+		su, err := gw.StateUpdate(context.Background(), i)
+		require.NoError(t, err)
+		require.NoError(t, chain.Store(b, &core.BlockCommitments{}, su, nil))
+	}
+
+	dumpEventIndex := func(txn db.Transaction) map[string][]byte {
+		it, err := txn.NewIterator()
+		require.NoError(t, err)
+		entries := make(map[string][]byte)
+		prefix := db.EventIndex.Key()
+		for it.Seek(prefix); it.Valid() && bytes.HasPrefix(it.Key(), prefix); it.Next() {
+			val, err := it.Value()
+			require.NoError(t, err)
+			entries[string(it.Key())] = val
+		}
+		require.NoError(t, it.Close())
+		return entries
+	}
+
+	var want map[string][]byte
+	require.NoError(t, testdb.Update(func(txn db.Transaction) error {
+		want = dumpEventIndex(txn)
+		require.NotEmpty(t, want)
+		for key := range want {
+			require.NoError(t, txn.Delete([]byte(key)))
+		}
+		return nil
+	}))
+
+	require.NoError(t, testdb.Update(func(txn db.Transaction) error {
+		return buildEventIndex(txn, &utils.Sepolia)
+	}))
+
+	require.NoError(t, testdb.View(func(txn db.Transaction) error {
+		assert.Equal(t, want, dumpEventIndex(txn))
+		return nil
+	}))
+}
+
 func TestMigrateTrieRootKeysFromBitsetToTrieKeys(t *testing.T) {
 	memTxn := db.NewMemTransaction()
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"net"
 	"net/http"
 	"net/http/pprof"
@@ -73,8 +74,58 @@ func exactPathServer(path string, handler http.Handler) http.HandlerFunc {
 	}
 }
 
+// executionMethods run the VM or submit transactions for validation, so they are rate limited separately from the
+// cheap reads.
+var executionMethods = []string{
+	"starknet_call",
+	"starknet_estimateFee",
+	"starknet_estimateMessageFee",
+	"starknet_simulateTransactions",
+	"starknet_traceTransaction",
+	"starknet_traceBlockTransactions",
+	"starknet_addInvokeTransaction",
+	"starknet_addDeclareTransaction",
+	"starknet_addDeployAccountTransaction",
+	"juno_simulateBlocks",
+	"juno_traceFilter",
+}
+
+// parseAPIKeys splits comma-separated API keys, trimming the whitespace around them. Empty entries are dropped, as an
+// empty key would let in the requests that carry no key.
+func parseAPIKeys(keys string) ([]string, error) {
+	var parsed []string
+	for _, key := range strings.Split(keys, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			parsed = append(parsed, key)
+		}
+	}
+	if len(parsed) == 0 {
+		return nil, fmt.Errorf("no API keys in %q", keys)
+	}
+	return parsed, nil
+}
+
+// makeRateLimiter allows perSecond requests per second to each client, in bursts of up to a second's worth.
+func makeRateLimiter(perSecond float64) *jsonrpc.RateLimiter {
+	return jsonrpc.NewRateLimiter(perSecond, uint(max(1, math.Ceil(perSecond))))
+}
+
+// apiKeyPathServer serves handler to the clients that append their API key to the path, by moving the key to the
+// header that the jsonrpc servers read it from.
+func apiKeyPathServer(handler http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		r.Header.Set(jsonrpc.APIKeyHeader, r.PathValue("apiKey"))
+		handler.ServeHTTP(w, r)
+	}
+}
+
+// apiKeyPath is the pattern of path followed by an API key.
+func apiKeyPath(path string) string {
+	return strings.TrimSuffix(path, "/") + "/{apiKey}"
+}
+
 func makeRPCOverHTTP(host string, port uint16, servers map[string]*jsonrpc.Server,
-	httpHandlers map[string]http.HandlerFunc, log utils.SimpleLogger, metricsEnabled bool, corsEnabled bool,
+	httpHandlers map[string]http.HandlerFunc, log utils.SimpleLogger, metricsEnabled, corsEnabled, apiKeysInPath bool,
 ) *httpService {
 	var listener jsonrpc.NewRequestListener
 	if metricsEnabled {
@@ -88,6 +139,9 @@ func makeRPCOverHTTP(host string, port uint16, servers map[string]*jsonrpc.Serve
 			httpHandler = httpHandler.WithListener(listener)
 		}
 		mux.Handle(path, exactPathServer(path, httpHandler))
+		if apiKeysInPath {
+			mux.Handle(apiKeyPath(path), apiKeyPathServer(httpHandler))
+		}
 	}
 	for path, handler := range httpHandlers {
 		mux.HandleFunc(path, handler)
@@ -101,7 +155,7 @@ func makeRPCOverHTTP(host string, port uint16, servers map[string]*jsonrpc.Serve
 }
 
 func makeRPCOverWebsocket(host string, port uint16, servers map[string]*jsonrpc.Server,
-	log utils.SimpleLogger, metricsEnabled bool, corsEnabled bool,
+	log utils.SimpleLogger, metricsEnabled, corsEnabled, apiKeysInPath bool,
 ) *httpService {
 	var listener jsonrpc.NewRequestListener
 	if metricsEnabled {
@@ -118,6 +172,10 @@ func makeRPCOverWebsocket(host string, port uint16, servers map[string]*jsonrpc.
 
 		wsPrefixedPath := strings.TrimSuffix("/ws"+path, "/")
 		mux.Handle(wsPrefixedPath, exactPathServer(wsPrefixedPath, wsHandler))
+		if apiKeysInPath {
+			mux.Handle(apiKeyPath(path), apiKeyPathServer(wsHandler))
+			mux.Handle(apiKeyPath(wsPrefixedPath), apiKeyPathServer(wsHandler))
+		}
 	}
 
 	var handler http.Handler = mux
@@ -166,9 +224,12 @@ func (g *grpcService) Run(ctx context.Context) error {
 	}
 }
 
-func makeGRPC(host string, port uint16, database db.DB, version string) *grpcService {
+func makeGRPC(host string, port uint16, database db.DB, version string, bcReader blockchain.Reader,
+	syncReader sync.Reader,
+) *grpcService {
 	srv := grpc.NewServer()
-	gen.RegisterKVServer(srv, junogrpc.New(database, version))
+	gen.RegisterKVServer(srv, junogrpc.New(database, version).WithSyncReader(syncReader))
+	gen.RegisterStarknetServer(srv, junogrpc.NewStarknetHandler(bcReader, syncReader))
 	return &grpcService{
 		srv:  srv,
 		host: host,
@@ -0,0 +1,60 @@
+package node
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NethermindEth/juno/jsonrpc"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAPIKeys(t *testing.T) {
+	keys, err := parseAPIKeys(" key1, ,key2 ,")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"key1", "key2"}, keys)
+
+	_, err = parseAPIKeys(" , ")
+	require.Error(t, err)
+
+	t.Run("requests without a key are rejected despite a trailing comma", func(t *testing.T) {
+		keys, err := parseAPIKeys("key1,")
+		require.NoError(t, err)
+
+		log := utils.NewNopZapLogger()
+		rpc := jsonrpc.NewServer(1, log).WithAPIKeys(keys...)
+		require.NoError(t, rpc.RegisterMethods(jsonrpc.Method{
+			Name:    "echo",
+			Handler: func(msg string) (string, *jsonrpc.Error) { return msg, nil },
+			Params:  []jsonrpc.Parameter{{Name: "msg"}},
+		}))
+		srv := httptest.NewServer(jsonrpc.NewHTTP(rpc, log))
+		t.Cleanup(srv.Close)
+
+		call := func(t *testing.T, apiKey string) string {
+			t.Helper()
+			msg := `{"jsonrpc" : "2.0", "method" : "echo", "params" : [ "abc" ], "id" : 1}`
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, srv.URL, bytes.NewReader([]byte(msg)))
+			require.NoError(t, err)
+			if apiKey != "" {
+				req.Header.Set(jsonrpc.APIKeyHeader, apiKey)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer func() {
+				require.NoError(t, resp.Body.Close())
+			}()
+			got, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			return string(got)
+		}
+
+		assert.Equal(t, `{"jsonrpc":"2.0","error":{"code":-32001,"message":"Unauthorized"},"id":1}`, call(t, ""))
+		assert.Equal(t, `{"jsonrpc":"2.0","result":"abc","id":1}`, call(t, "key1"))
+	})
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/NethermindEth/juno/jemalloc"
 	"github.com/NethermindEth/juno/jsonrpc"
 	"github.com/NethermindEth/juno/l1"
+	"github.com/NethermindEth/juno/rpc"
 	"github.com/NethermindEth/juno/sync"
 	"github.com/cockroachdb/pebble"
 	"github.com/prometheus/client_golang/prometheus"
@@ -126,7 +127,12 @@ func makeRPCMetrics(version, legacyVersion string) (jsonrpc.EventListener, jsonr
 		Subsystem: "server",
 		Name:      "requests_latency",
 	}, []string{"method", "version"})
-	prometheus.MustRegister(requests, failedRequests, requestLatencies)
+	rejectedRequests := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "rpc",
+		Subsystem: "server",
+		Name:      "rejected_requests",
+	}, []string{"method", "version", "reason"})
+	prometheus.MustRegister(requests, failedRequests, requestLatencies, rejectedRequests)
 
 	return &jsonrpc.SelectiveListener{
 			OnNewRequestCb: func(method string) {
@@ -138,6 +144,9 @@ func makeRPCMetrics(version, legacyVersion string) (jsonrpc.EventListener, jsonr
 			OnRequestFailedCb: func(method string, data any) {
 				failedRequests.WithLabelValues(method, version).Inc()
 			},
+			OnRequestRejectedCb: func(method string, err *jsonrpc.Error) {
+				rejectedRequests.WithLabelValues(method, version, err.Message).Inc()
+			},
 		}, &jsonrpc.SelectiveListener{
 			OnNewRequestCb: func(method string) {
 				requests.WithLabelValues(method, legacyVersion).Inc()
@@ -148,9 +157,35 @@ func makeRPCMetrics(version, legacyVersion string) (jsonrpc.EventListener, jsonr
 			OnRequestFailedCb: func(method string, data any) {
 				failedRequests.WithLabelValues(method, legacyVersion).Inc()
 			},
+			OnRequestRejectedCb: func(method string, err *jsonrpc.Error) {
+				rejectedRequests.WithLabelValues(method, legacyVersion, err.Message).Inc()
+			},
 		}
 }
 
+func makeRPCResponseCacheMetrics() rpc.EventListener {
+	hits := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "rpc",
+		Subsystem: "response_cache",
+		Name:      "hits",
+	}, []string{"method"})
+	misses := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "rpc",
+		Subsystem: "response_cache",
+		Name:      "misses",
+	}, []string{"method"})
+	prometheus.MustRegister(hits, misses)
+
+	return &rpc.SelectiveListener{
+		OnResponseCacheHitCb: func(method string) {
+			hits.WithLabelValues(method).Inc()
+		},
+		OnResponseCacheMissCb: func(method string) {
+			misses.WithLabelValues(method).Inc()
+		},
+	}
+}
+
 func makeSyncMetrics(syncReader sync.Reader, bcReader blockchain.Reader) sync.EventListener {
 	opTimerHistogram := prometheus.NewHistogramVec(prometheus.HistogramOpts{
 		Namespace: "sync",
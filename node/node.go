@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"path/filepath"
 	"reflect"
 	"runtime"
 	"time"
@@ -20,6 +21,7 @@ import (
 	"github.com/NethermindEth/juno/db/remote"
 	"github.com/NethermindEth/juno/jsonrpc"
 	"github.com/NethermindEth/juno/l1"
+	"github.com/NethermindEth/juno/mempool"
 	"github.com/NethermindEth/juno/migration"
 	"github.com/NethermindEth/juno/p2p"
 	"github.com/NethermindEth/juno/plugin"
@@ -61,6 +63,7 @@ type Config struct {
 	Network                utils.Network  `mapstructure:"network"`
 	EthNode                string         `mapstructure:"eth-node"`
 	DisableL1Verification  bool           `mapstructure:"disable-l1-verification"`
+	VerifyBlockSignatures  bool           `mapstructure:"verify-block-signatures"`
 	Pprof                  bool           `mapstructure:"pprof"`
 	PprofHost              string         `mapstructure:"pprof-host"`
 	PprofPort              uint16         `mapstructure:"pprof-port"`
@@ -79,11 +82,18 @@ type Config struct {
 	P2PPeers      string `mapstructure:"p2p-peers"`
 	P2PFeederNode bool   `mapstructure:"p2p-feeder-node"`
 	P2PPrivateKey string `mapstructure:"p2p-private-key"`
+	P2PSnapSync   bool   `mapstructure:"p2p-snap-sync"`
 
-	MaxVMs          uint `mapstructure:"max-vms"`
-	MaxVMQueue      uint `mapstructure:"max-vm-queue"`
-	RPCMaxBlockScan uint `mapstructure:"rpc-max-block-scan"`
-	RPCCallMaxSteps uint `mapstructure:"rpc-call-max-steps"`
+	MaxVMs             uint `mapstructure:"max-vms"`
+	MaxVMQueue         uint `mapstructure:"max-vm-queue"`
+	RPCMaxBlockScan    uint `mapstructure:"rpc-max-block-scan"`
+	RPCMaxTracedBlocks uint `mapstructure:"rpc-max-traced-blocks"`
+	RPCCallMaxSteps    uint `mapstructure:"rpc-call-max-steps"`
+
+	RPCAPIKeys            string  `mapstructure:"rpc-api-keys"`
+	RPCRateLimit          float64 `mapstructure:"rpc-rate-limit"`
+	RPCExecutionRateLimit float64 `mapstructure:"rpc-execution-rate-limit"`
+	RPCResponseCacheSize  uint    `mapstructure:"rpc-response-cache-size"`
 
 	DBCacheSize  uint `mapstructure:"db-cache-size"`
 	DBMaxHandles int  `mapstructure:"db-max-handles"`
@@ -92,6 +102,18 @@ type Config struct {
 	GatewayTimeout time.Duration `mapstructure:"gw-timeout"`
 
 	PluginPath string `mapstructure:"plugin-path"`
+
+	Mempool bool `mapstructure:"mempool"`
+
+	DBSnapshotDir  string `mapstructure:"db-snapshot-dir"`
+	DBSnapshotHost string `mapstructure:"db-snapshot-host"`
+	DBSnapshotPort uint16 `mapstructure:"db-snapshot-port"`
+
+	TraceStore             bool   `mapstructure:"trace-store"`
+	TraceStoreBackfillFrom uint64 `mapstructure:"trace-store-backfill-from"`
+	TraceStoreBackfillTo   uint64 `mapstructure:"trace-store-backfill-to"`
+
+	PruneHistoryBlocks uint64 `mapstructure:"prune-history-blocks"`
 }
 
 type Node struct {
@@ -115,9 +137,13 @@ func New(cfg *Config, version string) (*Node, error) { //nolint:gocyclo,funlen
 	}
 
 	dbIsRemote := cfg.RemoteDB != ""
-	var database db.DB
+	var (
+		database db.DB
+		remoteDB *remote.DB
+	)
 	if dbIsRemote {
-		database, err = remote.New(cfg.RemoteDB, context.TODO(), log, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		remoteDB, err = remote.New(cfg.RemoteDB, context.TODO(), log, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		database = remoteDB
 	} else {
 		database, err = pebble.NewWithOptions(cfg.DatabasePath, cfg.DBCacheSize, cfg.DBMaxHandles, cfg.Colour)
 	}
@@ -129,7 +155,21 @@ func New(cfg *Config, version string) (*Node, error) { //nolint:gocyclo,funlen
 
 	services := make([]service.Service, 0)
 
+	client := feeder.NewClient(cfg.Network.FeederURL).WithUserAgent(ua).WithLogger(log).
+		WithTimeout(cfg.GatewayTimeout).WithAPIKey(cfg.GatewayAPIKey)
+
 	chain := blockchain.New(database, &cfg.Network)
+	if cfg.VerifyBlockSignatures {
+		if cfg.Network.SequencerPublicKey == nil {
+			log.Warnw("Sequencer public key is not set for the network, fetching it from the feeder gateway. "+
+				"Set it with --cn-sequencer-public-key to not trust the feeder gateway for it", "network", cfg.Network.Name)
+			cfg.Network.SequencerPublicKey, err = client.PublicKey(context.Background())
+			if err != nil {
+				return nil, fmt.Errorf("get sequencer public key: %w", err)
+			}
+		}
+		chain.WithSignatureVerification()
+	}
 
 	// Verify that cfg.Network is compatible with the database.
 	head, err := chain.Head()
@@ -155,18 +195,21 @@ func New(cfg *Config, version string) (*Node, error) { //nolint:gocyclo,funlen
 		}
 	}
 
-	client := feeder.NewClient(cfg.Network.FeederURL).WithUserAgent(ua).WithLogger(log).
-		WithTimeout(cfg.GatewayTimeout).WithAPIKey(cfg.GatewayAPIKey)
 	synchronizer := sync.New(chain, adaptfeeder.New(client), log, cfg.PendingPollInterval, dbIsRemote)
+	if dbIsRemote {
+		// Subscriptions are notified of the changes of the node that writes the database.
+		synchronizer.WithHeadsSource(remoteDB)
+	}
 	gatewayClient := gateway.NewClient(cfg.Network.GatewayURL, log).WithUserAgent(ua).WithAPIKey(cfg.GatewayAPIKey)
 
+	var junoPlugin plugin.JunoPlugin
 	if cfg.PluginPath != "" {
-		p, err := plugin.Load(cfg.PluginPath)
+		junoPlugin, err = plugin.Load(cfg.PluginPath)
 		if err != nil {
 			return nil, err
 		}
-		synchronizer.WithPlugin(p)
-		services = append(services, plugin.NewService(p))
+		synchronizer.WithPlugin(junoPlugin)
+		services = append(services, plugin.NewService(junoPlugin))
 	}
 
 	var p2pService *p2p.Service
@@ -185,9 +228,21 @@ func New(cfg *Config, version string) (*Node, error) { //nolint:gocyclo,funlen
 		if err != nil {
 			return nil, fmt.Errorf("set up p2p service: %w", err)
 		}
+		if cfg.P2PSnapSync {
+			p2pService.WithSnapSync()
+		}
+		if junoPlugin != nil {
+			p2pService.WithPlugin(junoPlugin)
+		}
+		if cfg.P2PFeederNode {
+			p2pService.WithNewHeads(synchronizer.SubscribeNewHeads())
+		}
 
 		services = append(services, p2pService)
 	}
+	if cfg.PruneHistoryBlocks != 0 {
+		services = append(services, blockchain.NewHistoryPruner(chain, cfg.PruneHistoryBlocks, log))
+	}
 	if synchronizer != nil {
 		services = append(services, synchronizer)
 	}
@@ -200,12 +255,46 @@ func New(cfg *Config, version string) (*Node, error) { //nolint:gocyclo,funlen
 	}
 
 	rpcHandler := rpc.New(chain, syncReader, throttledVM, version, log).WithGateway(gatewayClient).WithFeeder(client)
-	rpcHandler = rpcHandler.WithFilterLimit(cfg.RPCMaxBlockScan).WithCallMaxSteps(uint64(cfg.RPCCallMaxSteps))
+	rpcHandler = rpcHandler.WithFilterLimit(cfg.RPCMaxBlockScan).WithTraceFilterLimit(cfg.RPCMaxTracedBlocks).
+		WithCallMaxSteps(uint64(cfg.RPCCallMaxSteps)).WithResponseCacheSize(uint64(cfg.RPCResponseCacheSize) * utils.Megabyte)
+	if cfg.Mempool {
+		pool := mempool.New(chain, throttledVM, gatewayClient, log)
+		if p2pService != nil {
+			pool = pool.WithGossiper(p2pService)
+			p2pService.WithTransactionPool(pool)
+		}
+		rpcHandler = rpcHandler.WithMempool(pool)
+		services = append(services, pool)
+	}
 	services = append(services, rpcHandler)
+	if cfg.TraceStore {
+		rpcHandler = rpcHandler.WithTraceStore(chain)
+		tracer := rpc.NewBlockTracer(rpcHandler, log)
+		if cfg.TraceStoreBackfillTo != 0 {
+			tracer = tracer.WithBackfill(cfg.TraceStoreBackfillFrom, cfg.TraceStoreBackfillTo)
+		}
+		services = append(services, tracer)
+	}
 	// to improve RPC throughput we double GOMAXPROCS
 	maxGoroutines := 2 * runtime.GOMAXPROCS(0)
 	jsonrpcServer := jsonrpc.NewServer(maxGoroutines, log).WithValidator(validator.Validator())
 	methods, path := rpcHandler.Methods()
+	if cfg.DBSnapshotDir != "" {
+		pebbleDB, ok := database.(*pebble.DB)
+		if !ok {
+			return nil, errors.New("database snapshots are not supported with a remote database")
+		}
+		snapshotDir, err := filepath.Abs(cfg.DBSnapshotDir)
+		if err != nil {
+			return nil, err
+		}
+		snapshotService, err := makeSnapshotService(cfg.DBSnapshotHost, cfg.DBSnapshotPort,
+			makeSnapshotMethod(pebbleDB, &cfg.Network, snapshotDir, log), log)
+		if err != nil {
+			return nil, err
+		}
+		services = append(services, snapshotService)
+	}
 	if err = jsonrpcServer.RegisterMethods(methods...); err != nil {
 		return nil, err
 	}
@@ -214,6 +303,25 @@ func New(cfg *Config, version string) (*Node, error) { //nolint:gocyclo,funlen
 	if err = jsonrpcServerLegacy.RegisterMethods(legacyMethods...); err != nil {
 		return nil, err
 	}
+	var apiKeys []string
+	if cfg.RPCAPIKeys != "" {
+		if apiKeys, err = parseAPIKeys(cfg.RPCAPIKeys); err != nil {
+			return nil, err
+		}
+		jsonrpcServer.WithAPIKeys(apiKeys...)
+		jsonrpcServerLegacy.WithAPIKeys(apiKeys...)
+	}
+	// The limiters are shared by both servers, so that clients can't double their limits by switching versions.
+	if cfg.RPCRateLimit > 0 {
+		limiter := makeRateLimiter(cfg.RPCRateLimit)
+		jsonrpcServer.WithRateLimiter(limiter)
+		jsonrpcServerLegacy.WithRateLimiter(limiter)
+	}
+	if cfg.RPCExecutionRateLimit > 0 {
+		limiter := makeRateLimiter(cfg.RPCExecutionRateLimit)
+		jsonrpcServer.WithRateLimiter(limiter, executionMethods...)
+		jsonrpcServerLegacy.WithRateLimiter(limiter, executionMethods...)
+	}
 	rpcServers := map[string]*jsonrpc.Server{
 		"/":                 jsonrpcServer,
 		path:                jsonrpcServer,
@@ -227,11 +335,13 @@ func New(cfg *Config, version string) (*Node, error) { //nolint:gocyclo,funlen
 		httpHandlers := map[string]http.HandlerFunc{
 			"/ready/sync": readinessHandlers.HandleReadySync,
 		}
-		services = append(services, makeRPCOverHTTP(cfg.HTTPHost, cfg.HTTPPort, rpcServers, httpHandlers, log, cfg.Metrics, cfg.RPCCorsEnable))
+		services = append(services, makeRPCOverHTTP(cfg.HTTPHost, cfg.HTTPPort, rpcServers, httpHandlers, log, cfg.Metrics,
+			cfg.RPCCorsEnable, len(apiKeys) > 0))
 	}
 	if cfg.Websocket {
 		services = append(services,
-			makeRPCOverWebsocket(cfg.WebsocketHost, cfg.WebsocketPort, rpcServers, log, cfg.Metrics, cfg.RPCCorsEnable))
+			makeRPCOverWebsocket(cfg.WebsocketHost, cfg.WebsocketPort, rpcServers, log, cfg.Metrics, cfg.RPCCorsEnable,
+				len(apiKeys) > 0))
 	}
 	var metricsService service.Service
 	if cfg.Metrics {
@@ -244,6 +354,7 @@ func New(cfg *Config, version string) (*Node, error) { //nolint:gocyclo,funlen
 		rpcMetrics, legacyRPCMetrics := makeRPCMetrics(path, legacyPath)
 		jsonrpcServer.WithListener(rpcMetrics)
 		jsonrpcServerLegacy.WithListener(legacyRPCMetrics)
+		rpcHandler.WithListener(makeRPCResponseCacheMetrics())
 		client.WithListener(makeFeederMetrics())
 		gatewayClient.WithListener(makeGatewayMetrics())
 		metricsService = makeMetrics(cfg.MetricsHost, cfg.MetricsPort)
@@ -254,10 +365,11 @@ func New(cfg *Config, version string) (*Node, error) { //nolint:gocyclo,funlen
 			// regular p2p node
 			p2pService.WithListener(makeSyncMetrics(&sync.NoopSynchronizer{}, chain))
 			p2pService.WithGossipTracer()
+			p2pService.WithPeerScoreMetrics()
 		}
 	}
 	if cfg.GRPC {
-		services = append(services, makeGRPC(cfg.GRPCHost, cfg.GRPCPort, database, version))
+		services = append(services, makeGRPC(cfg.GRPCHost, cfg.GRPCPort, database, version, chain, syncReader))
 	}
 	if cfg.Pprof {
 		services = append(services, makePPROF(cfg.PprofHost, cfg.PprofPort))
@@ -0,0 +1,69 @@
+package node
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/NethermindEth/juno/db/pebble"
+	"github.com/NethermindEth/juno/db/snapshot"
+	"github.com/NethermindEth/juno/jsonrpc"
+	"github.com/NethermindEth/juno/utils"
+)
+
+const createSnapshotMethod = "juno_createSnapshot"
+
+// makeSnapshotMethod returns the JSON-RPC method used by `juno db snapshot` to checkpoint the database of a
+// running node. Snapshots can only be written inside baseDir, and only when the caller expects the node's network.
+func makeSnapshotMethod(database *pebble.DB, network *utils.Network, baseDir string, log utils.SimpleLogger) jsonrpc.Method {
+	return jsonrpc.Method{
+		Name:   createSnapshotMethod,
+		Params: []jsonrpc.Parameter{{Name: "dir"}, {Name: "network"}},
+		Handler: func(dir, networkName string) (*snapshot.Manifest, *jsonrpc.Error) {
+			if networkName != network.Name {
+				return nil, jsonrpc.Err(jsonrpc.InvalidParams, fmt.Sprintf("%v: node is on %q, expected %q",
+					snapshot.ErrNetworkMismatch, network.Name, networkName))
+			}
+			dir, err := snapshotDir(baseDir, dir)
+			if err != nil {
+				return nil, jsonrpc.Err(jsonrpc.InvalidParams, err.Error())
+			}
+
+			manifest, err := snapshot.Create(database, network, dir)
+			if err != nil {
+				log.Errorw("Failed to create database snapshot", "dir", dir, "err", err)
+				return nil, jsonrpc.Err(jsonrpc.InternalError, err.Error())
+			}
+			log.Infow("Created database snapshot", "dir", dir, "head", manifest.HeadNumber)
+			return manifest, nil
+		},
+	}
+}
+
+// snapshotDir resolves dir against baseDir and makes sure it doesn't point outside of it.
+func snapshotDir(baseDir, dir string) (string, error) {
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(baseDir, dir)
+	}
+	dir = filepath.Clean(dir)
+
+	rel, err := filepath.Rel(baseDir, dir)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("snapshot directory must be inside %s", baseDir)
+	}
+	return dir, nil
+}
+
+// makeSnapshotService serves juno_createSnapshot on its own listener, apart from the public RPC endpoints, since
+// it writes to the node's disk.
+func makeSnapshotService(host string, port uint16, method jsonrpc.Method, log utils.SimpleLogger) (*httpService, error) {
+	server := jsonrpc.NewServer(1, log)
+	if err := server.RegisterMethods(method); err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/", exactPathServer("/", jsonrpc.NewHTTP(server, log)))
+	return makeHTTPService(host, port, mux), nil
+}
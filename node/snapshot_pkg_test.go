@@ -0,0 +1,103 @@
+package node
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/clients/feeder"
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/db/pebble"
+	"github.com/NethermindEth/juno/db/snapshot"
+	"github.com/NethermindEth/juno/jsonrpc"
+	adaptfeeder "github.com/NethermindEth/juno/starknetdata/feeder"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotService(t *testing.T) {
+	network := &utils.Mainnet
+	database, err := pebble.New(t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, database.Close())
+	})
+
+	gw := adaptfeeder.New(feeder.NewTestClient(t, network))
+	block, err := gw.BlockByNumber(context.Background(), 0)
+	require.NoError(t, err)
+	stateUpdate, err := gw.StateUpdate(context.Background(), 0)
+	require.NoError(t, err)
+	require.NoError(t, blockchain.New(database, network).Store(block, &core.BlockCommitments{}, stateUpdate, nil))
+
+	baseDir := t.TempDir()
+	log := utils.NewNopZapLogger()
+	service, err := makeSnapshotService("localhost", 0, makeSnapshotMethod(database.(*pebble.DB), network, baseDir, log), log)
+	require.NoError(t, err)
+	server := httptest.NewServer(service.srv.Handler)
+	t.Cleanup(server.Close)
+
+	createSnapshot := func(t *testing.T, dir, networkName string) (*snapshot.Manifest, *jsonrpc.Error) {
+		t.Helper()
+		body, err := json.Marshal(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"method":  createSnapshotMethod,
+			"params":  map[string]string{"dir": dir, "network": networkName},
+		})
+		require.NoError(t, err)
+		resp, err := http.Post(server.URL, "application/json", bytes.NewReader(body)) //nolint:noctx
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var response struct {
+			Result *snapshot.Manifest `json:"result"`
+			Error  *jsonrpc.Error     `json:"error"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+		return response.Result, response.Error
+	}
+
+	t.Run("network mismatch writes nothing", func(t *testing.T) {
+		manifest, rpcErr := createSnapshot(t, "sepolia", utils.Sepolia.Name)
+		assert.Nil(t, manifest)
+		require.NotNil(t, rpcErr)
+		assert.Equal(t, jsonrpc.InvalidParams, rpcErr.Code)
+		assert.NoDirExists(t, filepath.Join(baseDir, "sepolia"))
+	})
+
+	t.Run("directory outside of the base directory", func(t *testing.T) {
+		manifest, rpcErr := createSnapshot(t, t.TempDir(), network.Name)
+		assert.Nil(t, manifest)
+		require.NotNil(t, rpcErr)
+		assert.Equal(t, jsonrpc.InvalidParams, rpcErr.Code)
+	})
+
+	t.Run("snapshot of the head", func(t *testing.T) {
+		manifest, rpcErr := createSnapshot(t, "mainnet", network.Name)
+		require.Nil(t, rpcErr)
+		assert.Equal(t, network.Name, manifest.Network)
+		assert.Equal(t, block.Hash, manifest.HeadHash)
+		assert.FileExists(t, filepath.Join(baseDir, "mainnet", snapshot.ManifestFile))
+	})
+
+	t.Run("only the snapshot method is served", func(t *testing.T) {
+		body := []byte(`{"jsonrpc":"2.0","id":1,"method":"starknet_blockNumber"}`)
+		resp, err := http.Post(server.URL, "application/json", bytes.NewReader(body)) //nolint:noctx
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var response struct {
+			Error *jsonrpc.Error `json:"error"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+		require.NotNil(t, response.Error)
+		assert.Equal(t, jsonrpc.MethodNotFound, response.Error.Code)
+	})
+}
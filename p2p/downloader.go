@@ -0,0 +1,306 @@
+package p2p
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/p2p/starknet"
+	junoSync "github.com/NethermindEth/juno/sync"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+)
+
+const (
+	// maxDownloadPeers is the number of peers blocks are downloaded from at once.
+	maxDownloadPeers = 8
+	// downloadBatchSize is the number of consecutive blocks assigned to a peer at once.
+	downloadBatchSize = 16
+	// blockDownloadTimeout is how long a peer has to serve a block before its batch is reassigned.
+	blockDownloadTimeout = 20 * time.Second
+	// peerWaitInterval is how often peers are looked for while none can be requested from.
+	peerWaitInterval = time.Second
+)
+
+var errBlockNotServed = errors.New("peer did not serve the block")
+
+// blockBatch is a range of blocks [from, to) assigned to a peer.
+type blockBatch struct {
+	from, to uint64
+}
+
+// specBlock holds the parts of a block downloaded from a peer.
+type specBlock struct {
+	peer    peer.ID
+	header  specBlockHeaderAndSigs
+	txs     specTxWithReceipts
+	events  specEvents
+	classes specClasses
+	diffs   specContractDiffs
+}
+
+// batchResult holds the blocks a peer downloaded of a batch, in order. There are fewer of them than in the batch if
+// the download failed.
+type batchResult struct {
+	peer   peer.ID
+	batch  blockBatch
+	blocks []*specBlock
+	err    error
+}
+
+// withPeer returns a copy of s whose requests all go to id.
+func (s *syncService) withPeer(id peer.ID) *syncService {
+	peerService := *s
+	peerService.client = starknet.NewClient(func(ctx context.Context, pids ...protocol.ID) (network.Stream, error) {
+		return s.host.NewStream(ctx, id, pids...)
+	}, s.network, s.log)
+	return &peerService
+}
+
+// downloadBlocks downloads blocks from several peers at once and stores them in order, starting at from. Consecutive
+// blocks are assigned to peers in batches, which are reassigned to other peers if a peer fails to serve them in time.
+// Blocks are verified as they are stored, and peers that served invalid blocks are banned. Blocks announced over
+// gossip are stored as they arrive. It returns once storing a block fails, the chain is reorganised or ctx is
+// cancelled.
+//
+//nolint:gocyclo
+func (s *syncService) downloadBlocks(ctx context.Context, from uint64) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		results    = make(chan batchResult)
+		busy       = make(map[peer.ID]struct{})
+		downloaded = make(map[uint64]*specBlock)
+		retries    []blockBatch
+		nextBatch  = from
+		nextStore  = from
+		// Batches are only assigned this far ahead of the stored blocks, to bound the downloaded blocks kept in memory.
+		window = uint64(maxDownloadPeers * downloadBatchSize)
+	)
+	ticker := time.NewTicker(peerWaitInterval)
+	defer ticker.Stop()
+
+	for {
+		// Once blocks are announced, blocks beyond the announced ones aren't requested. Otherwise, blocks are
+		// requested until no peer has them.
+		height, announced := s.announced.height()
+		for len(busy) < maxDownloadPeers && (len(retries) > 0 ||
+			(nextBatch < nextStore+window && (!announced || nextBatch < height))) {
+			batch := blockBatch{from: nextBatch, to: nextBatch + downloadBatchSize}
+			if announced {
+				batch.to = min(batch.to, height)
+			}
+			if len(retries) > 0 {
+				batch = retries[0]
+			}
+
+			idle := make([]peer.ID, 0)
+			for _, id := range s.peers() {
+				if _, ok := busy[id]; !ok {
+					idle = append(idle, id)
+				}
+			}
+			ranked := s.scores.rank(idle, batch.from)
+			if len(ranked) == 0 {
+				break
+			}
+
+			id := ranked[0]
+			// The blocks the peer is known not to have are left to other peers.
+			assigned := batch
+			if peerHeight, ok := s.scores.knownHeight(id); ok {
+				assigned.to = min(assigned.to, peerHeight)
+			}
+			if len(retries) > 0 {
+				retries = retries[1:]
+				if assigned.to < batch.to {
+					retries = append(retries, blockBatch{from: assigned.to, to: batch.to})
+				}
+			} else {
+				nextBatch = assigned.to
+			}
+			batch = assigned
+			busy[id] = struct{}{}
+			go func() {
+				result := s.withPeer(id).downloadBatch(ctx, id, batch)
+				select {
+				case <-ctx.Done():
+				case results <- result:
+				}
+			}()
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			continue
+		case a := <-s.announcements:
+			if a.block.header.header.Number != nextStore {
+				a.stored <- errStaleAnnouncement
+				continue
+			}
+			err := s.storeBlock(ctx, a.block)
+			a.stored <- err
+			if err != nil {
+				continue
+			}
+			delete(downloaded, nextStore)
+			nextStore++
+		case result := <-results:
+			delete(busy, result.peer)
+			for _, block := range result.blocks {
+				downloaded[block.header.header.Number] = block
+			}
+			if result.err != nil {
+				s.log.Debugw("Failed to download blocks", "peer", result.peer, "from", result.batch.from,
+					"to", result.batch.to, "err", result.err)
+				failedAt := result.batch.from + uint64(len(result.blocks))
+				if errors.Is(result.err, errBlockNotServed) {
+					// The block is beyond the peer's head, which doesn't count against the peer.
+					s.scores.notServed(result.peer, failedAt)
+				} else {
+					s.scores.failed(result.peer)
+				}
+				retries = append(retries, blockBatch{from: failedAt, to: result.batch.to})
+			}
+		}
+
+		for block, ok := downloaded[nextStore]; ok; block, ok = downloaded[nextStore] {
+			delete(downloaded, nextStore)
+			if err := s.storeBlock(ctx, block); err != nil {
+				if errors.Is(err, blockchain.ErrParentDoesNotMatchHead) {
+					// The peer follows another chain, which is at least as long as the local one.
+					return s.reorg(ctx, block)
+				}
+				if !errors.Is(err, errInvalidBlock) {
+					return err
+				}
+
+				s.log.Warnw("Banning peer for serving an invalid block", "peer", block.peer, "number", nextStore, "err", err)
+				s.scores.ban(block.peer)
+				// The block is downloaded again first, followed by the other blocks the peer served, which can't be
+				// trusted either.
+				retries = append([]blockBatch{{from: nextStore, to: nextStore + 1}}, retries...)
+				for number, other := range downloaded {
+					if other.peer == block.peer {
+						delete(downloaded, number)
+						retries = append(retries, blockBatch{from: number, to: number + 1})
+					}
+				}
+				break
+			}
+			nextStore++
+		}
+	}
+}
+
+// downloadBatch downloads the blocks of batch from id, which s sends all requests to.
+func (s *syncService) downloadBatch(ctx context.Context, id peer.ID, batch blockBatch) batchResult {
+	result := batchResult{peer: id, batch: batch}
+	for blockNumber := batch.from; blockNumber < batch.to; blockNumber++ {
+		start := time.Now()
+		block, err := s.downloadBlock(ctx, blockNumber)
+		if err != nil {
+			result.err = fmt.Errorf("block %d: %w", blockNumber, err)
+			return result
+		}
+		block.peer = id
+		s.scores.served(id, blockNumber, time.Since(start))
+		result.blocks = append(result.blocks, block)
+	}
+	return result
+}
+
+// downloadBlock downloads the parts of a block.
+func (s *syncService) downloadBlock(ctx context.Context, blockNumber uint64) (*specBlock, error) {
+	ctx, cancel := context.WithTimeout(ctx, blockDownloadTimeout)
+	defer cancel()
+
+	headersAndSigsCh, err := s.genHeadersAndSigs(ctx, blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block headers parts: %w", err)
+	}
+	txsCh, err := s.genTransactions(ctx, blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+	eventsCh, err := s.genEvents(ctx, blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events: %w", err)
+	}
+	classesCh, err := s.genClasses(ctx, blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get classes: %w", err)
+	}
+	stateDiffsCh, err := s.genStateDiffs(ctx, blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get state diffs: %w", err)
+	}
+
+	// The channels close without a value if the context is cancelled, and the headers channel if the peer doesn't
+	// have the block.
+	var (
+		block                                         specBlock
+		okHeader, okTxs, okEvents, okClasses, okDiffs bool
+	)
+	block.header, okHeader = <-headersAndSigsCh
+	block.txs, okTxs = <-txsCh
+	block.events, okEvents = <-eventsCh
+	block.classes, okClasses = <-classesCh
+	block.diffs, okDiffs = <-stateDiffsCh
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
+	if !okHeader || !okTxs || !okEvents || !okClasses || !okDiffs || block.header.header.Number != blockNumber {
+		return nil, errBlockNotServed
+	}
+	return &block, nil
+}
+
+var errInvalidBlock = errors.New("invalid block")
+
+// storeBlock verifies and stores a downloaded block. It returns errInvalidBlock if the block failed verification.
+func (s *syncService) storeBlock(ctx context.Context, block *specBlock) error {
+	blockNumber := block.header.header.Number
+	prevBlockRoot := &felt.Zero
+	if blockNumber > 0 {
+		prevHeader, err := s.blockchain.BlockHeaderByNumber(blockNumber - 1)
+		if err != nil {
+			return err
+		}
+		prevBlockRoot = prevHeader.GlobalStateRoot
+	}
+
+	b, ok := <-s.adaptAndSanityCheckBlock(ctx, block.header.header, block.diffs.contractDiffs, block.classes.classes,
+		block.txs.txs, block.txs.receipts, block.events.events, prevBlockRoot)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%w: block parts don't match the header", errInvalidBlock)
+	}
+	if b.err != nil {
+		return fmt.Errorf("%w: %v", errInvalidBlock, b.err)
+	}
+
+	storeTimer := time.Now()
+	if err := s.blockchain.Store(b.block, b.commitments, b.stateUpdate, b.newClasses); err != nil {
+		return fmt.Errorf("failed to store block: %w", err)
+	}
+
+	s.log.Infow("Stored Block", "number", b.block.Number, "hash", b.block.Hash.ShortString(),
+		"root", b.block.GlobalStateRoot.ShortString())
+	s.listener.OnSyncStepDone(junoSync.OpStore, b.block.Number, time.Since(storeTimer))
+	if s.plugin != nil {
+		if err := s.plugin.NewBlock(b.block, b.stateUpdate, b.newClasses); err != nil {
+			s.log.Errorw("Plugin NewBlock failure:", "err", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startDownload runs s.downloadBlocks from the genesis block until the test ends.
+func startDownload(t *testing.T, s *syncService) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- s.downloadBlocks(ctx, 0)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		assert.ErrorIs(t, <-done, context.Canceled)
+	})
+}
+
+// waitForHeight waits until s stored the blocks up to height.
+func waitForHeight(t *testing.T, s *syncService, height uint64) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		stored, err := s.blockchain.Height()
+		return err == nil && stored >= height
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+func failures(s *syncService, id peer.ID) int {
+	s.scores.mu.Lock()
+	defer s.scores.mu.Unlock()
+	return s.scores.get(id).failures
+}
+
+func TestDownloadBlocks(t *testing.T) {
+	chain := newTestChain(t, 2, nil)
+	head, err := chain.HeadsHeader()
+	require.NoError(t, err)
+
+	t.Run("blocks beyond a peer's head are downloaded from other peers", func(t *testing.T) {
+		s := newTestService(t)
+		lagging := servePeer(t, s, newTestChain(t, 0, nil))
+		startDownload(t, s)
+
+		waitForHeight(t, s, 0)
+		require.Eventually(t, func() bool {
+			_, ok := s.scores.knownHeight(lagging)
+			return ok
+		}, 10*time.Second, 10*time.Millisecond)
+		peerHeight, _ := s.scores.knownHeight(lagging)
+		assert.Equal(t, uint64(1), peerHeight)
+		assert.Zero(t, failures(s, lagging))
+
+		servePeer(t, s, chain)
+		waitForHeight(t, s, 2)
+		assert.Zero(t, failures(s, lagging))
+		assert.False(t, s.scores.banned(lagging))
+	})
+
+	t.Run("peers serving invalid blocks are banned", func(t *testing.T) {
+		s := newTestService(t)
+		invalid := servePeer(t, s, newTestChain(t, 1, func(block *core.Block, _ *core.StateUpdate) {
+			if block.Number == 1 {
+				block.Hash = new(felt.Felt).SetUint64(1)
+			}
+		}))
+		startDownload(t, s)
+
+		waitForHeight(t, s, 0)
+		require.Eventually(t, func() bool { return s.scores.banned(invalid) }, 10*time.Second, 10*time.Millisecond)
+
+		// The invalid block is downloaded again from another peer.
+		servePeer(t, s, chain)
+		waitForHeight(t, s, 2)
+		got, err := s.blockchain.HeadsHeader()
+		require.NoError(t, err)
+		assert.Equal(t, head.Hash, got.Hash)
+	})
+
+	t.Run("blocks a peer failed to serve are retried", func(t *testing.T) {
+		s := newTestService(t)
+		// The peer doesn't support the sync protocols.
+		h, err := libp2p.New(libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+		require.NoError(t, err)
+		t.Cleanup(func() { require.NoError(t, h.Close()) })
+		require.NoError(t, s.host.Connect(context.Background(), peer.AddrInfo{ID: h.ID(), Addrs: h.Addrs()}))
+		startDownload(t, s)
+
+		require.Eventually(t, func() bool { return failures(s, h.ID()) > 0 }, 10*time.Second, 10*time.Millisecond)
+		assert.NotContains(t, s.scores.rank([]peer.ID{h.ID()}, 0), h.ID())
+
+		servePeer(t, s, chain)
+		waitForHeight(t, s, 2)
+		assert.False(t, s.scores.banned(h.ID()))
+	})
+}
@@ -0,0 +1,334 @@
+package p2p
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/NethermindEth/juno/adapters/core2p2p"
+	"github.com/NethermindEth/juno/adapters/p2p2core"
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/p2p/starknet"
+	"github.com/NethermindEth/juno/p2p/starknet/spec"
+	"github.com/NethermindEth/juno/vm"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"google.golang.org/protobuf/proto"
+)
+
+// announcementTTL is how long an announced block is relied on as the highest block of the network. Peers are
+// requested from beyond it once no block was announced for that long.
+const announcementTTL = time.Minute
+
+var errStaleAnnouncement = errors.New("announced block is not the next block to store")
+
+// TransactionPool validates and keeps the transactions gossiped by peers.
+type TransactionPool interface {
+	AddGossiped(txn core.Transaction, declaredClass core.Class) error
+}
+
+// gossipMessageID identifies messages by their content, so that a block announced or a transaction propagated by
+// several peers is only relayed once.
+func gossipMessageID(msg *pubsubpb.Message) string {
+	hash := sha256.Sum256(msg.Data)
+	return string(hash[:])
+}
+
+// startGossip joins the topics blocks and transactions are gossiped on. Messages are handled by the validators of
+// the topics, which decide whether they are relayed.
+func (s *Service) startGossip(ctx context.Context) error {
+	blocksTopic, txsTopic := starknet.NewBlocksTopic(s.network), starknet.TransactionsTopic(s.network)
+
+	blockValidator := s.synchroniser.validateNewBlock
+	if s.feederNode {
+		blockValidator = s.validateKnownBlock
+	}
+	if err := s.pubsub.RegisterTopicValidator(blocksTopic, blockValidator,
+		pubsub.WithValidatorTimeout(blockDownloadTimeout)); err != nil {
+		return err
+	}
+	if err := s.pubsub.RegisterTopicValidator(txsTopic, s.validateTransaction); err != nil {
+		return err
+	}
+
+	for _, topic := range []string{blocksTopic, txsTopic} {
+		ch, cancel, err := s.SubscribeToTopic(topic)
+		if err != nil {
+			return fmt.Errorf("subscribe to %s: %w", topic, err)
+		}
+		go func() {
+			defer cancel()
+			for range ch { //nolint:revive
+				// The messages were handled when they were validated.
+			}
+		}()
+	}
+
+	if s.newHeads.Subscription != nil {
+		go s.announceNewHeads(ctx)
+	}
+	return nil
+}
+
+// announceNewHeads announces the blocks stored by the feeder synchroniser.
+func (s *Service) announceNewHeads(ctx context.Context) {
+	defer s.newHeads.Unsubscribe()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case header, ok := <-s.newHeads.Recv():
+			if !ok {
+				return
+			}
+			if err := s.announceBlock(ctx, header); err != nil {
+				s.log.Debugw("Failed to announce block", "number", header.Number, "err", err)
+			}
+		}
+	}
+}
+
+func (s *Service) announceBlock(ctx context.Context, header *core.Header) error {
+	bc := s.synchroniser.blockchain
+	commitments, err := bc.BlockCommitmentsByNumber(header.Number)
+	if err != nil {
+		return err
+	}
+	stateUpdate, err := bc.StateUpdateByNumber(header.Number)
+	if err != nil {
+		return err
+	}
+
+	return s.publish(ctx, starknet.NewBlocksTopic(s.network), &spec.NewBlock{
+		MaybeFull: &spec.NewBlock_Header{
+			Header: &spec.BlockHeadersResponse{
+				HeaderMessage: &spec.BlockHeadersResponse_Header{
+					Header: core2p2p.AdaptHeader(header, commitments, stateUpdate.StateDiff.Hash(),
+						stateUpdate.StateDiff.Length()),
+				},
+			},
+		},
+	})
+}
+
+// GossipTransaction propagates txn to peers.
+func (s *Service) GossipTransaction(ctx context.Context, txn core.Transaction, declaredClass core.Class) error {
+	return s.publish(ctx, starknet.TransactionsTopic(s.network), &spec.GossipedTransaction{
+		Transaction: core2p2p.AdaptTransaction(txn),
+		Class:       core2p2p.AdaptClass(declaredClass),
+	})
+}
+
+func (s *Service) publish(ctx context.Context, topic string, msg proto.Message) error {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return s.PublishOnTopic(ctx, topic, data)
+}
+
+// announcedBlockID returns the number and hash of the block a NewBlock message announces.
+func announcedBlockID(data []byte) (uint64, *felt.Felt, error) {
+	var newBlock spec.NewBlock
+	if err := proto.Unmarshal(data, &newBlock); err != nil {
+		return 0, nil, err
+	}
+
+	var (
+		number uint64
+		hash   *felt.Felt
+	)
+	if id := newBlock.GetId(); id != nil {
+		number, hash = id.Number, p2p2core.AdaptHash(id.Header)
+	} else if header := newBlock.GetHeader().GetHeader(); header != nil {
+		number, hash = header.Number, p2p2core.AdaptHash(header.BlockHash)
+	}
+	if hash == nil {
+		return 0, nil, errors.New("announcement without a block hash")
+	}
+	return number, hash, nil
+}
+
+// validateKnownBlock relays the announcements of blocks stored already. It validates announcements on feeder nodes,
+// which sync from the feeder gateway rather than from peers.
+func (s *Service) validateKnownBlock(_ context.Context, from peer.ID, msg *pubsub.Message) pubsub.ValidationResult {
+	if from == s.host.ID() {
+		return pubsub.ValidationAccept
+	}
+
+	number, hash, err := announcedBlockID(msg.Data)
+	if err != nil {
+		return pubsub.ValidationReject
+	}
+	header, err := s.synchroniser.blockchain.BlockHeaderByNumber(number)
+	if err != nil || !header.Hash.Equal(hash) {
+		return pubsub.ValidationIgnore
+	}
+	return pubsub.ValidationAccept
+}
+
+// validateTransaction relays transactions whose hash matches their content and that the transaction pool, if any,
+// accepts.
+func (s *Service) validateTransaction(_ context.Context, from peer.ID, msg *pubsub.Message) pubsub.ValidationResult {
+	if from == s.host.ID() {
+		return pubsub.ValidationAccept
+	}
+
+	var gossiped spec.GossipedTransaction
+	if err := proto.Unmarshal(msg.Data, &gossiped); err != nil {
+		return pubsub.ValidationReject
+	}
+	// The adapters expect well-formed messages, which peers don't necessarily send.
+	if err := checkTransaction(gossiped.Transaction); err != nil {
+		return pubsub.ValidationReject
+	}
+	txn := p2p2core.AdaptTransaction(gossiped.Transaction, s.network)
+	switch txn.(type) {
+	case *core.InvokeTransaction, *core.DeclareTransaction, *core.DeployAccountTransaction:
+	default:
+		return pubsub.ValidationReject
+	}
+	if hash, err := core.TransactionHash(txn, s.network); err != nil || !hash.Equal(txn.Hash()) {
+		return pubsub.ValidationReject
+	}
+
+	var declaredClass core.Class
+	if declare, ok := txn.(*core.DeclareTransaction); ok {
+		if err := checkClass(gossiped.Class); err != nil {
+			return pubsub.ValidationReject
+		}
+		var err error
+		if declaredClass, err = p2p2core.TryAdaptClass(gossiped.Class); err != nil {
+			return pubsub.ValidationReject
+		}
+		if classHash, err := declaredClass.Hash(); err != nil || !classHash.Equal(declare.ClassHash) {
+			return pubsub.ValidationReject
+		}
+	}
+
+	if s.txPool == nil {
+		return pubsub.ValidationAccept
+	}
+	err := s.txPool.AddGossiped(txn, declaredClass)
+	var txnExecutionError vm.TransactionExecutionError
+	switch {
+	case err == nil:
+		return pubsub.ValidationAccept
+	case errors.As(err, &txnExecutionError):
+		return pubsub.ValidationReject
+	default:
+		// Duplicates, and transactions that can't be validated against the local state yet, aren't relayed.
+		return pubsub.ValidationIgnore
+	}
+}
+
+// announcedHead is the highest block announced by peers.
+type announcedHead struct {
+	mu     sync.Mutex
+	number uint64
+	at     time.Time
+}
+
+func (a *announcedHead) update(number uint64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if number >= a.number || time.Since(a.at) > announcementTTL {
+		a.number, a.at = number, time.Now()
+	}
+}
+
+// height returns the number of blocks of the network, or false if no block was announced recently.
+func (a *announcedHead) height() (uint64, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.at.IsZero() || time.Since(a.at) > announcementTTL {
+		return 0, false
+	}
+	return a.number + 1, true
+}
+
+// announcement is a block downloaded after it was announced, to be stored by the downloader.
+type announcement struct {
+	block  *specBlock
+	stored chan error
+}
+
+// validateNewBlock relays the announcement of the block after the local head once the block is downloaded from the
+// peer that relayed it, verified and stored. Announcements of later blocks aren't relayed, as the blocks can't be
+// stored yet, but bound the blocks requested from peers once the announced header is downloaded and verified, so
+// that a peer can't make the node rely on blocks that don't exist.
+func (s *syncService) validateNewBlock(ctx context.Context, from peer.ID, msg *pubsub.Message) pubsub.ValidationResult {
+	if from == s.host.ID() {
+		return pubsub.ValidationAccept
+	}
+
+	number, hash, err := announcedBlockID(msg.Data)
+	if err != nil {
+		return pubsub.ValidationReject
+	}
+
+	nextHeight, err := s.getNextHeight()
+	if err != nil || number < uint64(nextHeight) {
+		return pubsub.ValidationIgnore
+	}
+	if number > uint64(nextHeight) {
+		return s.validateLaterBlock(ctx, msg.ReceivedFrom, number, hash)
+	}
+
+	block, err := s.withPeer(msg.ReceivedFrom).downloadBlock(ctx, number)
+	if err != nil {
+		return pubsub.ValidationIgnore
+	}
+	// The header is verified before the block is adapted, so that the announcement is rejected if the peer that
+	// relayed it serves an incomplete or forged header.
+	header, err := s.verifyHeader(block.header.header)
+	if err != nil || !header.Hash.Equal(hash) {
+		return pubsub.ValidationReject
+	}
+	s.announced.update(number)
+	block.peer = msg.ReceivedFrom
+
+	a := announcement{block: block, stored: make(chan error, 1)}
+	select {
+	case <-ctx.Done():
+		return pubsub.ValidationIgnore
+	case s.announcements <- a:
+	}
+	select {
+	case <-ctx.Done():
+		return pubsub.ValidationIgnore
+	case err = <-a.stored:
+	}
+
+	switch {
+	case err == nil:
+		return pubsub.ValidationAccept
+	case errors.Is(err, errInvalidBlock):
+		return pubsub.ValidationReject
+	default:
+		return pubsub.ValidationIgnore
+	}
+}
+
+// validateLaterBlock counts the announcement of a block beyond the next one to store once its header, downloaded
+// from the peer that relayed the announcement, is verified and has the announced hash. The announcement isn't relayed.
+func (s *syncService) validateLaterBlock(ctx context.Context, id peer.ID, number uint64, hash *felt.Felt) pubsub.ValidationResult {
+	header, err := s.withPeer(id).requestHeader(ctx, number)
+	switch {
+	case errors.Is(err, errInvalidBlock):
+		return pubsub.ValidationReject
+	case err != nil:
+		return pubsub.ValidationIgnore
+	case !header.Hash.Equal(hash):
+		return pubsub.ValidationReject
+	}
+	s.announced.update(number)
+	return pubsub.ValidationIgnore
+}
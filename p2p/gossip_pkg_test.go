@@ -0,0 +1,384 @@
+package p2p
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/juno/adapters/core2p2p"
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db/pebble"
+	"github.com/NethermindEth/juno/p2p/starknet"
+	"github.com/NethermindEth/juno/p2p/starknet/spec"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/NethermindEth/juno/vm"
+	"github.com/libp2p/go-libp2p"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gossipTrace passes on the results of the validation of the gossip messages a host received from peers, and records
+// the peers the host added to the mesh of each topic.
+type gossipTrace struct {
+	results chan pubsub.ValidationResult
+
+	mu     sync.Mutex
+	meshes map[string][]peer.ID
+}
+
+func newGossipTrace() *gossipTrace {
+	return &gossipTrace{
+		results: make(chan pubsub.ValidationResult, 16),
+		meshes:  make(map[string][]peer.ID),
+	}
+}
+
+func (g *gossipTrace) Trace(evt *pubsubpb.TraceEvent) {
+	switch evt.GetType() {
+	case pubsubpb.TraceEvent_GRAFT:
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		topic := evt.GetGraft().GetTopic()
+		g.meshes[topic] = append(g.meshes[topic], peer.ID(evt.GetGraft().GetPeerID()))
+	case pubsubpb.TraceEvent_DELIVER_MESSAGE:
+		// Messages the host published are delivered as well.
+		if !bytes.Equal(evt.GetDeliverMessage().GetReceivedFrom(), evt.GetPeerID()) {
+			g.results <- pubsub.ValidationAccept
+		}
+	case pubsubpb.TraceEvent_REJECT_MESSAGE:
+		switch evt.GetRejectMessage().GetReason() {
+		case pubsub.RejectValidationFailed:
+			g.results <- pubsub.ValidationReject
+		case pubsub.RejectValidationIgnored:
+			g.results <- pubsub.ValidationIgnore
+		}
+	}
+}
+
+// inMesh reports whether the host added id to the mesh of topic.
+func (g *gossipTrace) inMesh(topic string, id peer.ID) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return slices.Contains(g.meshes[topic], id)
+}
+
+// next returns the result of the next message validated.
+func (g *gossipTrace) next(t *testing.T) pubsub.ValidationResult {
+	t.Helper()
+	select {
+	case result := <-g.results:
+		return result
+	case <-time.After(2 * blockDownloadTimeout):
+		require.FailNow(t, "no message validated")
+		return pubsub.ValidationIgnore
+	}
+}
+
+// gossipService is a service gossiping with peers, along with its trace.
+type gossipService struct {
+	*Service
+	trace *gossipTrace
+}
+
+// startGossipService starts gossip on a new service, which serves chain.
+func startGossipService(t *testing.T, chain *blockchain.Blockchain, feederNode bool) gossipService {
+	t.Helper()
+	h, err := libp2p.New(libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, h.Close()) })
+
+	s, err := NewWithHost(h, "", feederNode, chain, &utils.Mainnet, utils.NewNopZapLogger(), pebble.NewMemTest(t))
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	s.runCtx = ctx
+
+	trace := newGossipTrace()
+	s.pubsub, err = pubsub.NewGossipSub(ctx, h, pubsub.WithMessageIdFn(gossipMessageID), pubsub.WithEventTracer(trace))
+	require.NoError(t, err)
+	s.setProtocolHandlers()
+	require.NoError(t, s.startGossip(ctx))
+	return gossipService{Service: s, trace: trace}
+}
+
+// connectGossip connects from to to and waits until from added to to the mesh of topic, which the messages from
+// publishes are sent to.
+func connectGossip(t *testing.T, from, to gossipService, topic string) {
+	t.Helper()
+	require.NoError(t, from.host.Connect(context.Background(), peer.AddrInfo{ID: to.host.ID(), Addrs: to.host.Addrs()}))
+	require.Eventually(t, func() bool {
+		return from.trace.inMesh(topic, to.host.ID())
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+// txPoolFunc adds gossiped transactions to a transaction pool.
+type txPoolFunc func(txn core.Transaction, declaredClass core.Class) error
+
+func (f txPoolFunc) AddGossiped(txn core.Transaction, declaredClass core.Class) error {
+	return f(txn, declaredClass)
+}
+
+func TestValidateTransaction(t *testing.T) {
+	var (
+		executionFailed = new(felt.Felt).SetUint64(2)
+		notValidatedYet = new(felt.Felt).SetUint64(3)
+	)
+	receiver := startGossipService(t, nil, false)
+	receiver.WithTransactionPool(txPoolFunc(func(txn core.Transaction, _ core.Class) error {
+		switch nonce := txn.(*core.InvokeTransaction).Nonce; {
+		case nonce.Equal(executionFailed):
+			return vm.TransactionExecutionError{Cause: errors.New("reverted")}
+		case nonce.Equal(notValidatedYet):
+			return errors.New("nonce too high")
+		default:
+			return nil
+		}
+	}))
+	sender := startGossipService(t, nil, false)
+	topic := starknet.TransactionsTopic(&utils.Mainnet)
+	connectGossip(t, sender, receiver, topic)
+
+	hashed := func(t *testing.T, txn core.Transaction) core.Transaction {
+		t.Helper()
+		hash, err := core.TransactionHash(txn, &utils.Mainnet)
+		require.NoError(t, err)
+		switch txn := txn.(type) {
+		case *core.InvokeTransaction:
+			txn.TransactionHash = hash
+		case *core.DeclareTransaction:
+			txn.TransactionHash = hash
+		case *core.L1HandlerTransaction:
+			txn.TransactionHash = hash
+		}
+		return txn
+	}
+	invoke := func(t *testing.T, nonce uint64) core.Transaction {
+		t.Helper()
+		return hashed(t, &core.InvokeTransaction{
+			Version:              new(core.TransactionVersion).SetUint64(1),
+			SenderAddress:        new(felt.Felt).SetUint64(0x1234),
+			Nonce:                new(felt.Felt).SetUint64(nonce),
+			MaxFee:               new(felt.Felt).SetUint64(1),
+			CallData:             []*felt.Felt{new(felt.Felt).SetUint64(5)},
+			TransactionSignature: []*felt.Felt{new(felt.Felt).SetUint64(6)},
+		})
+	}
+	incomplete := core2p2p.AdaptTransaction(invoke(t, 5))
+	incomplete.GetInvokeV1().Nonce = nil
+	forged := invoke(t, 6).(*core.InvokeTransaction)
+	forged.TransactionHash = new(felt.Felt).SetUint64(1)
+	declare := hashed(t, &core.DeclareTransaction{
+		Version:              new(core.TransactionVersion).SetUint64(1),
+		ClassHash:            new(felt.Felt).SetUint64(0x5678),
+		SenderAddress:        new(felt.Felt).SetUint64(0x1234),
+		Nonce:                new(felt.Felt).SetUint64(7),
+		MaxFee:               new(felt.Felt).SetUint64(1),
+		TransactionSignature: []*felt.Felt{},
+	})
+	l1Handler := hashed(t, &core.L1HandlerTransaction{
+		Version:            new(core.TransactionVersion).SetUint64(0),
+		ContractAddress:    new(felt.Felt).SetUint64(0x1234),
+		EntryPointSelector: new(felt.Felt).SetUint64(1),
+		Nonce:              new(felt.Felt).SetUint64(8),
+		CallData:           []*felt.Felt{},
+	})
+
+	tests := map[string]struct {
+		msg  *spec.GossipedTransaction
+		want pubsub.ValidationResult
+	}{
+		"accepted by the transaction pool": {
+			msg:  &spec.GossipedTransaction{Transaction: core2p2p.AdaptTransaction(invoke(t, 1))},
+			want: pubsub.ValidationAccept,
+		},
+		"fails execution": {
+			msg:  &spec.GossipedTransaction{Transaction: core2p2p.AdaptTransaction(invoke(t, 2))},
+			want: pubsub.ValidationReject,
+		},
+		"can't be validated yet": {
+			msg:  &spec.GossipedTransaction{Transaction: core2p2p.AdaptTransaction(invoke(t, 3))},
+			want: pubsub.ValidationIgnore,
+		},
+		"incomplete transaction": {
+			msg:  &spec.GossipedTransaction{Transaction: incomplete},
+			want: pubsub.ValidationReject,
+		},
+		"hash doesn't match the transaction": {
+			msg:  &spec.GossipedTransaction{Transaction: core2p2p.AdaptTransaction(forged)},
+			want: pubsub.ValidationReject,
+		},
+		"declared class is incomplete": {
+			msg: &spec.GossipedTransaction{
+				Transaction: core2p2p.AdaptTransaction(declare),
+				Class:       &spec.Class{Class: &spec.Class_Cairo1{Cairo1: &spec.Cairo1Class{}}},
+			},
+			want: pubsub.ValidationReject,
+		},
+		"transaction type isn't gossiped": {
+			msg:  &spec.GossipedTransaction{Transaction: core2p2p.AdaptTransaction(l1Handler)},
+			want: pubsub.ValidationReject,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, sender.publish(context.Background(), topic, test.msg))
+			assert.Equal(t, test.want, receiver.trace.next(t))
+		})
+	}
+
+	t.Run("malformed message", func(t *testing.T) {
+		require.NoError(t, sender.PublishOnTopic(context.Background(), topic, []byte{0xff}))
+		assert.Equal(t, pubsub.ValidationReject, receiver.trace.next(t))
+	})
+}
+
+func TestValidateNewBlock(t *testing.T) {
+	chain := newTestChain(t, 2, nil)
+	topic := starknet.NewBlocksTopic(&utils.Mainnet)
+
+	// newReceiver returns a service storing the blocks announced by a peer serving sender after block head. The peer
+	// isn't requested for later blocks by the downloader, so that they are only stored if they are announced.
+	newReceiver := func(t *testing.T, sender gossipService, head uint64) gossipService {
+		t.Helper()
+		receiver := startGossipService(t, newTestChain(t, head, nil), false)
+		receiver.synchroniser.scores.notServed(sender.host.ID(), head+1)
+		connectGossip(t, sender, receiver, topic)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() {
+			done <- receiver.synchroniser.downloadBlocks(ctx, head+1)
+		}()
+		t.Cleanup(func() {
+			cancel()
+			assert.ErrorIs(t, <-done, context.Canceled)
+		})
+		return receiver
+	}
+	header := func(t *testing.T, chain *blockchain.Blockchain, number uint64) *core.Header {
+		t.Helper()
+		header, err := chain.BlockHeaderByNumber(number)
+		require.NoError(t, err)
+		return header
+	}
+	announceID := func(t *testing.T, sender gossipService, number uint64, hash *felt.Felt) {
+		t.Helper()
+		require.NoError(t, sender.publish(context.Background(), topic, &spec.NewBlock{
+			MaybeFull: &spec.NewBlock_Id{Id: &spec.BlockID{Number: number, Header: core2p2p.AdaptHash(hash)}},
+		}))
+	}
+
+	t.Run("next block is stored and relayed", func(t *testing.T) {
+		sender := startGossipService(t, chain, false)
+		receiver := newReceiver(t, sender, 1)
+
+		require.NoError(t, sender.announceBlock(context.Background(), header(t, chain, 2)))
+		assert.Equal(t, pubsub.ValidationAccept, receiver.trace.next(t))
+		got, err := receiver.synchroniser.blockchain.HeadsHeader()
+		require.NoError(t, err)
+		assert.Equal(t, header(t, chain, 2).Hash, got.Hash)
+	})
+
+	t.Run("verified blocks beyond the next one bound the blocks requested", func(t *testing.T) {
+		sender := startGossipService(t, chain, false)
+		receiver := newReceiver(t, sender, 0)
+
+		require.NoError(t, sender.announceBlock(context.Background(), header(t, chain, 2)))
+		assert.Equal(t, pubsub.ValidationIgnore, receiver.trace.next(t))
+		height, ok := receiver.synchroniser.announced.height()
+		assert.True(t, ok)
+		assert.Equal(t, uint64(3), height)
+
+		// Lower blocks announced afterwards don't lower the announced height.
+		require.NoError(t, sender.announceBlock(context.Background(), header(t, chain, 1)))
+		assert.Equal(t, pubsub.ValidationAccept, receiver.trace.next(t))
+		height, _ = receiver.synchroniser.announced.height()
+		assert.Equal(t, uint64(3), height)
+	})
+
+	t.Run("unverified blocks beyond the next one are ignored", func(t *testing.T) {
+		sender := startGossipService(t, chain, false)
+		receiver := newReceiver(t, sender, 0)
+
+		announceID(t, sender, 2, new(felt.Felt).SetUint64(1))
+		assert.Equal(t, pubsub.ValidationReject, receiver.trace.next(t))
+		// The sender doesn't have the block.
+		announceID(t, sender, 5, new(felt.Felt).SetUint64(1))
+		assert.Equal(t, pubsub.ValidationIgnore, receiver.trace.next(t))
+		_, ok := receiver.synchroniser.announced.height()
+		assert.False(t, ok)
+	})
+
+	t.Run("announced hash doesn't match the block", func(t *testing.T) {
+		sender := startGossipService(t, chain, false)
+		receiver := newReceiver(t, sender, 1)
+
+		announceID(t, sender, 2, new(felt.Felt).SetUint64(1))
+		assert.Equal(t, pubsub.ValidationReject, receiver.trace.next(t))
+		height, err := receiver.synchroniser.blockchain.Height()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), height)
+		_, ok := receiver.synchroniser.announced.height()
+		assert.False(t, ok)
+	})
+
+	t.Run("block with a forged hash", func(t *testing.T) {
+		forged := newTestChain(t, 2, func(block *core.Block, _ *core.StateUpdate) {
+			if block.Number == 2 {
+				block.Hash = new(felt.Felt).SetUint64(1)
+			}
+		})
+		sender := startGossipService(t, forged, false)
+		receiver := newReceiver(t, sender, 1)
+
+		require.NoError(t, sender.announceBlock(context.Background(), header(t, forged, 2)))
+		assert.Equal(t, pubsub.ValidationReject, receiver.trace.next(t))
+	})
+
+	t.Run("block that doesn't match its header", func(t *testing.T) {
+		// The hashes of pre-0.7 blocks don't commit to the number of events.
+		invalid := newTestChain(t, 2, func(block *core.Block, _ *core.StateUpdate) {
+			if block.Number == 2 {
+				block.EventCount++
+			}
+		})
+		sender := startGossipService(t, invalid, false)
+		receiver := newReceiver(t, sender, 1)
+
+		require.NoError(t, sender.announceBlock(context.Background(), header(t, invalid, 2)))
+		assert.Equal(t, pubsub.ValidationReject, receiver.trace.next(t))
+		height, err := receiver.synchroniser.blockchain.Height()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), height)
+	})
+
+	t.Run("malformed message", func(t *testing.T) {
+		sender := startGossipService(t, chain, false)
+		receiver := newReceiver(t, sender, 1)
+
+		require.NoError(t, sender.PublishOnTopic(context.Background(), topic, []byte{0xff}))
+		assert.Equal(t, pubsub.ValidationReject, receiver.trace.next(t))
+	})
+
+	t.Run("feeder nodes relay blocks they stored", func(t *testing.T) {
+		feeder := startGossipService(t, chain, true)
+		sender := startGossipService(t, chain, false)
+		connectGossip(t, sender, feeder, topic)
+
+		require.NoError(t, sender.announceBlock(context.Background(), header(t, chain, 2)))
+		assert.Equal(t, pubsub.ValidationAccept, feeder.trace.next(t))
+		announceID(t, sender, 2, new(felt.Felt).SetUint64(1))
+		assert.Equal(t, pubsub.ValidationIgnore, feeder.trace.next(t))
+		require.NoError(t, sender.PublishOnTopic(context.Background(), topic, []byte{0xff}))
+		assert.Equal(t, pubsub.ValidationReject, feeder.trace.next(t))
+	})
+}
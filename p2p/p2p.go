@@ -14,6 +14,7 @@ import (
 	"github.com/NethermindEth/juno/blockchain"
 	"github.com/NethermindEth/juno/db"
 	"github.com/NethermindEth/juno/p2p/starknet"
+	junoplugin "github.com/NethermindEth/juno/plugin"
 	junoSync "github.com/NethermindEth/juno/sync"
 	"github.com/NethermindEth/juno/utils"
 	"github.com/libp2p/go-libp2p"
@@ -27,6 +28,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/protocol"
 	"github.com/multiformats/go-multiaddr"
+	"github.com/prometheus/client_golang/prometheus"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -53,6 +55,10 @@ type Service struct {
 
 	feederNode bool
 	database   db.DB
+
+	runCtx   context.Context
+	txPool   TransactionPool
+	newHeads junoSync.HeaderSubscription
 }
 
 func New(addr, publicAddr, version, peers, privKeyStr string, feederNode bool, bc *blockchain.Blockchain, snNetwork *utils.Network,
@@ -245,7 +251,8 @@ func (s *Service) Run(ctx context.Context) error {
 		return err
 	}
 
-	var options []pubsub.Option
+	s.runCtx = ctx
+	options := []pubsub.Option{pubsub.WithMessageIdFn(gossipMessageID)}
 	if s.gossipTracer != nil {
 		options = append(options, pubsub.WithRawTracer(s.gossipTracer))
 	}
@@ -266,6 +273,9 @@ func (s *Service) Run(ctx context.Context) error {
 	}
 
 	s.setProtocolHandlers()
+	if err = s.startGossip(ctx); err != nil {
+		return err
+	}
 
 	if !s.feederNode {
 		s.synchroniser.start(ctx)
@@ -287,6 +297,11 @@ func (s *Service) setProtocolHandlers() {
 	s.SetProtocolHandler(starknet.TransactionsPID(), s.handler.TransactionsHandler)
 	s.SetProtocolHandler(starknet.ClassesPID(), s.handler.ClassesHandler)
 	s.SetProtocolHandler(starknet.StateDiffPID(), s.handler.StateDiffHandler)
+	s.SetProtocolHandler(starknet.ContractRangePID(), s.handler.ContractRangeHandler)
+	s.SetProtocolHandler(starknet.ClassRangePID(), s.handler.ClassRangeHandler)
+	s.SetProtocolHandler(starknet.ContractStoragePID(), s.handler.ContractStorageHandler)
+	s.SetProtocolHandler(starknet.ClassesByHashPID(), s.handler.ClassesByHashHandler)
+	s.SetProtocolHandler(starknet.DeclaredClassHashesPID(), s.handler.DeclaredClassHashesHandler)
 }
 
 func (s *Service) callAndLogErr(f func() error, msg string) {
@@ -374,30 +389,34 @@ func (s *Service) SubscribeToTopic(topic string) (chan []byte, func(), error) {
 
 	const bufferSize = 16
 	ch := make(chan []byte, bufferSize)
-	// go func() {
-	//	for {
-	//		msg, err := sub.Next(s.runCtx)
-	//		if err != nil {
-	//			close(ch)
-	//			return
-	//		}
-	//		only forward messages delivered by others
-	//		if msg.ReceivedFrom == s.host.ID() {
-	//			continue
-	//		}
-	//
-	//		select {
-	//		case ch <- msg.GetData():
-	//		case <-s.runCtx.Done():
-	//		}
-	//	}
-	// }()
+	go func() {
+		defer close(ch)
+		for {
+			msg, err := sub.Next(s.runCtx)
+			if err != nil {
+				return
+			}
+			// only forward messages delivered by others
+			if msg.ReceivedFrom == s.host.ID() {
+				continue
+			}
+
+			select {
+			case ch <- msg.GetData():
+			case <-s.runCtx.Done():
+				return
+			}
+		}
+	}()
 	return ch, sub.Cancel, nil
 }
 
-func (s *Service) PublishOnTopic(topic string) error {
-	_, err := s.joinTopic(topic)
-	return err
+func (s *Service) PublishOnTopic(ctx context.Context, topic string, data []byte) error {
+	t, err := s.joinTopic(topic)
+	if err != nil {
+		return err
+	}
+	return t.Publish(ctx, data)
 }
 
 func (s *Service) SetProtocolHandler(pid protocol.ID, handler func(network.Stream)) {
@@ -408,6 +427,32 @@ func (s *Service) WithListener(l junoSync.EventListener) {
 	s.synchroniser.WithListener(l)
 }
 
+// WithPlugin notifies plugin of the blocks stored and reverted by p2p sync.
+func (s *Service) WithPlugin(plugin junoplugin.JunoPlugin) {
+	s.synchroniser.plugin = plugin
+}
+
+// WithPeerScoreMetrics exposes the scores of the peers blocks are downloaded from as metrics.
+func (s *Service) WithPeerScoreMetrics() {
+	prometheus.MustRegister(s.synchroniser.scores)
+}
+
+// WithSnapSync downloads the state of a recent block with snap sync if the blockchain is empty, instead of syncing
+// every block from genesis.
+func (s *Service) WithSnapSync() {
+	s.synchroniser.useSnapSync = true
+}
+
+// WithTransactionPool sets the pool the transactions gossiped by peers are validated against and added to.
+func (s *Service) WithTransactionPool(pool TransactionPool) {
+	s.txPool = pool
+}
+
+// WithNewHeads announces the blocks heads receives, which feeder nodes store as they sync from the feeder gateway.
+func (s *Service) WithNewHeads(heads junoSync.HeaderSubscription) {
+	s.newHeads = heads
+}
+
 func (s *Service) WithGossipTracer() {
 	s.gossipTracer = NewGossipTracer(s.host)
 }
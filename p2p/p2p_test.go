@@ -92,7 +92,7 @@ func TestService(t *testing.T) {
 	RetryLoop:
 		for i := 0; i < maxRetries; i++ {
 			gossipedMessage := []byte(`veryImportantMessage`)
-			require.NoError(t, peerB.PublishOnTopic(topic))
+			require.NoError(t, peerB.PublishOnTopic(testCtx, topic, gossipedMessage))
 
 			select {
 			case <-time.After(time.Second):
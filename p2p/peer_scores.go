@@ -0,0 +1,219 @@
+package p2p
+
+import (
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// banDuration is how long a peer that served an invalid block is not requested from.
+	banDuration = 30 * time.Minute
+	// maxFailures caps the consecutive failures counted against a peer, so it recovers quickly once it serves blocks.
+	maxFailures = 8
+	maxBackoff  = 30 * time.Second
+	// latencyWeight is the weight of the latest latency in the moving average of a peer's latency.
+	latencyWeight = 0.2
+	// heightRecheckInterval is how long a peer that didn't have a block isn't requested for it or later blocks.
+	heightRecheckInterval = 10 * time.Second
+)
+
+var _ prometheus.Collector = (*peerScores)(nil)
+
+type peerScore struct {
+	latency     time.Duration // moving average of the time taken to serve a block
+	height      uint64        // the highest block served
+	served      uint64
+	failures    int
+	bans        uint64
+	bannedUntil time.Time
+	retryAfter  time.Time
+	lacks       uint64    // the first block the peer didn't have when it was last requested for it
+	lacksUntil  time.Time // when the peer is requested for lacks again
+}
+
+// value is higher for peers that serve blocks quickly and reliably.
+func (s *peerScore) value() float64 {
+	return 1 / (1 + s.latency.Seconds()) / math.Pow(2, float64(s.failures)) //nolint:mnd
+}
+
+// peerScores scores the peers blocks are downloaded from on their latency, the validity of the blocks they served
+// and the height they served blocks up to.
+type peerScores struct {
+	mu     sync.Mutex
+	scores map[peer.ID]*peerScore
+
+	scoreDesc   *prometheus.Desc
+	latencyDesc *prometheus.Desc
+	heightDesc  *prometheus.Desc
+	bansDesc    *prometheus.Desc
+}
+
+func newPeerScores() *peerScores {
+	return &peerScores{
+		scores: make(map[peer.ID]*peerScore),
+		scoreDesc: prometheus.NewDesc("p2p_sync_peer_score",
+			"The score of a peer blocks are downloaded from", []string{"peer"}, nil),
+		latencyDesc: prometheus.NewDesc("p2p_sync_peer_latency_seconds",
+			"The average time a peer takes to serve a block", []string{"peer"}, nil),
+		heightDesc: prometheus.NewDesc("p2p_sync_peer_height",
+			"The highest block a peer served", []string{"peer"}, nil),
+		bansDesc: prometheus.NewDesc("p2p_sync_peer_bans_total",
+			"The number of times a peer was banned for serving an invalid block", []string{"peer"}, nil),
+	}
+}
+
+func (p *peerScores) get(id peer.ID) *peerScore {
+	score, ok := p.scores[id]
+	if !ok {
+		score = new(peerScore)
+		p.scores[id] = score
+	}
+	return score
+}
+
+// served records that id served blockNumber in took.
+func (p *peerScores) served(id peer.ID, blockNumber uint64, took time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	score := p.get(id)
+	if score.served == 0 {
+		score.latency = took
+	} else {
+		score.latency = time.Duration(latencyWeight*float64(took) + (1-latencyWeight)*float64(score.latency))
+	}
+	score.height = max(score.height, blockNumber)
+	score.served++
+	score.failures = 0
+	score.retryAfter = time.Time{}
+	if blockNumber >= score.lacks {
+		score.lacksUntil = time.Time{}
+	}
+}
+
+// notServed records that id didn't have blockNumber, which is beyond its head unless it lies. It doesn't count against
+// the peer, which isn't requested for blockNumber or later blocks until heightRecheckInterval passes.
+func (p *peerScores) notServed(id peer.ID, blockNumber uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	score := p.get(id)
+	score.lacks = blockNumber
+	score.lacksUntil = time.Now().Add(heightRecheckInterval)
+}
+
+// knownHeight returns the number of blocks id is known to have, or false if it's not known to lack any.
+func (p *peerScores) knownHeight(id peer.ID) (uint64, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	score, ok := p.scores[id]
+	if !ok || !time.Now().Before(score.lacksUntil) {
+		return 0, false
+	}
+	return score.lacks, true
+}
+
+// failed records that id didn't serve a block in time, or didn't have it. The peer isn't requested from until an
+// exponentially growing backoff passes.
+func (p *peerScores) failed(id peer.ID) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	score := p.get(id)
+	score.failures = min(score.failures+1, maxFailures)
+	backoff := min(time.Second<<score.failures, maxBackoff)
+	score.retryAfter = time.Now().Add(backoff)
+}
+
+// ban records that id served an invalid block.
+func (p *peerScores) ban(id peer.ID) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	score := p.get(id)
+	score.bans++
+	score.bannedUntil = time.Now().Add(banDuration)
+}
+
+func (p *peerScores) banned(id peer.ID) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	score, ok := p.scores[id]
+	return ok && time.Now().Before(score.bannedUntil)
+}
+
+// rank returns the peers that can be requested from for blockNumber, best first. Peers that recently didn't have
+// blockNumber are left out, and those that may be behind it come last.
+func (p *peerScores) rank(ids []peer.ID, blockNumber uint64) []peer.ID {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	ranked := make([]peer.ID, 0, len(ids))
+	for _, id := range ids {
+		score := p.get(id)
+		if now.Before(score.bannedUntil) || now.Before(score.retryAfter) ||
+			(now.Before(score.lacksUntil) && blockNumber >= score.lacks) {
+			continue
+		}
+		ranked = append(ranked, id)
+	}
+
+	behind := func(score *peerScore) bool {
+		return score.served > 0 && score.height < blockNumber
+	}
+	slices.SortStableFunc(ranked, func(a, b peer.ID) int {
+		scoreA, scoreB := p.scores[a], p.scores[b]
+		if behindA, behindB := behind(scoreA), behind(scoreB); behindA != behindB {
+			if behindA {
+				return 1
+			}
+			return -1
+		}
+		valueA, valueB := scoreA.value(), scoreB.value()
+		switch {
+		case valueA > valueB:
+			return -1
+		case valueA < valueB:
+			return 1
+		default:
+			return 0
+		}
+	})
+	return ranked
+}
+
+// remove forgets the score of id, e.g. once it was removed from the peerstore.
+func (p *peerScores) remove(id peer.ID) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.scores, id)
+}
+
+func (p *peerScores) Describe(ch chan<- *prometheus.Desc) {
+	ch <- p.scoreDesc
+	ch <- p.latencyDesc
+	ch <- p.heightDesc
+	ch <- p.bansDesc
+}
+
+func (p *peerScores) Collect(ch chan<- prometheus.Metric) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for id, score := range p.scores {
+		label := id.String()
+		ch <- prometheus.MustNewConstMetric(p.scoreDesc, prometheus.GaugeValue, score.value(), label)
+		ch <- prometheus.MustNewConstMetric(p.latencyDesc, prometheus.GaugeValue, score.latency.Seconds(), label)
+		ch <- prometheus.MustNewConstMetric(p.heightDesc, prometheus.GaugeValue, float64(score.height), label)
+		ch <- prometheus.MustNewConstMetric(p.bansDesc, prometheus.CounterValue, float64(score.bans), label)
+	}
+}
@@ -0,0 +1,78 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeerScores(t *testing.T) {
+	const fast, slow, lagging peer.ID = "fast", "slow", "lagging"
+	ids := []peer.ID{slow, lagging, fast}
+
+	t.Run("rank by latency", func(t *testing.T) {
+		scores := newPeerScores()
+		scores.served(fast, 10, time.Millisecond)
+		scores.served(slow, 10, time.Second)
+		scores.served(lagging, 5, 10*time.Millisecond)
+
+		assert.Equal(t, []peer.ID{fast, lagging, slow}, scores.rank(ids, 5))
+		// Peers that only served blocks before the requested one may be behind it.
+		assert.Equal(t, []peer.ID{fast, slow, lagging}, scores.rank(ids, 8))
+	})
+
+	t.Run("back off after failures", func(t *testing.T) {
+		scores := newPeerScores()
+		scores.failed(fast)
+		assert.Equal(t, []peer.ID{slow, lagging}, scores.rank(ids, 0))
+
+		score := scores.get(fast)
+		firstBackoff := time.Until(score.retryAfter)
+		scores.failed(fast)
+		assert.Greater(t, time.Until(score.retryAfter), firstBackoff)
+		for range 2 * maxFailures {
+			scores.failed(fast)
+		}
+		assert.Equal(t, maxFailures, score.failures)
+		assert.LessOrEqual(t, time.Until(score.retryAfter), maxBackoff)
+
+		// Once the backoff passed, the peer is requested from again, after the peers that didn't fail.
+		score.retryAfter = time.Now()
+		assert.Equal(t, []peer.ID{slow, lagging, fast}, scores.rank(ids, 0))
+
+		scores.served(fast, 0, time.Millisecond)
+		assert.Zero(t, score.failures)
+		assert.True(t, score.retryAfter.IsZero())
+	})
+
+	t.Run("blocks beyond a peer's head don't count against it", func(t *testing.T) {
+		scores := newPeerScores()
+		scores.notServed(lagging, 5)
+
+		assert.Equal(t, []peer.ID{slow, lagging, fast}, scores.rank(ids, 4))
+		assert.Equal(t, []peer.ID{slow, fast}, scores.rank(ids, 5))
+		assert.Equal(t, []peer.ID{slow, fast}, scores.rank(ids, 6))
+		height, ok := scores.knownHeight(lagging)
+		assert.True(t, ok)
+		assert.Equal(t, uint64(5), height)
+		assert.Zero(t, scores.get(lagging).failures)
+
+		// The peer is requested for the block again once it may have caught up.
+		scores.get(lagging).lacksUntil = time.Now()
+		assert.Equal(t, []peer.ID{slow, lagging, fast}, scores.rank(ids, 5))
+		_, ok = scores.knownHeight(lagging)
+		assert.False(t, ok)
+	})
+
+	t.Run("ban", func(t *testing.T) {
+		scores := newPeerScores()
+		scores.ban(fast)
+		assert.True(t, scores.banned(fast))
+		assert.Equal(t, []peer.ID{slow, lagging}, scores.rank(ids, 0))
+
+		scores.remove(fast)
+		assert.False(t, scores.banned(fast))
+	})
+}
@@ -0,0 +1,250 @@
+package p2p
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/NethermindEth/juno/adapters/p2p2core"
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/p2p/starknet/spec"
+	junoSync "github.com/NethermindEth/juno/sync"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// ancestorBatchSize is the number of headers requested at once while looking for the common ancestor.
+const ancestorBatchSize = 64
+
+var (
+	errNoCommonAncestor = errors.New("no common ancestor")
+	errForkNotConfirmed = errors.New("fork not confirmed by another peer")
+)
+
+// reorg switches the local chain to the chain of the peer that served fork, whose parent doesn't match the local head.
+// The blocks of the fork after the common ancestor are downloaded from the peer and verified against their headers,
+// which must link up to fork, and the first of them must be served by another peer as well. Only then is the local
+// chain reverted to the ancestor and are the blocks of the fork stored. Peers that served invalid headers or blocks
+// are banned.
+func (s *syncService) reorg(ctx context.Context, fork *specBlock) error {
+	blocks, ancestor, err := s.downloadFork(ctx, fork)
+	if err != nil {
+		if errors.Is(err, errInvalidBlock) || errors.Is(err, errNoCommonAncestor) {
+			s.log.Warnw("Banning peer for serving an invalid fork", "peer", fork.peer, "err", err)
+			s.scores.ban(fork.peer)
+		} else {
+			s.scores.failed(fork.peer)
+		}
+		return fmt.Errorf("fork of peer %s: %w", fork.peer, err)
+	}
+
+	head, err := s.blockchain.HeadsHeader()
+	if err != nil {
+		return err
+	}
+	s.log.Infow("Reorg detected", "localHead", head.Hash, "forkBlock", fork.header.header.Number, "commonAncestor", ancestor)
+
+	for head.Number > ancestor {
+		if s.plugin != nil {
+			junoSync.NotifyPluginRevert(s.blockchain, s.plugin, s.log)
+		}
+		if err = s.blockchain.RevertHead(); err != nil {
+			return fmt.Errorf("revert block %d: %w", head.Number, err)
+		}
+		s.log.Infow("Reverted HEAD", "reverted", head.Hash)
+		s.listener.OnReorg(head.Number)
+
+		if head, err = s.blockchain.HeadsHeader(); err != nil {
+			return err
+		}
+	}
+
+	for _, block := range blocks {
+		if err = s.storeBlock(ctx, block); err != nil {
+			if errors.Is(err, errInvalidBlock) {
+				s.log.Warnw("Banning peer for serving an invalid block", "peer", fork.peer,
+					"number", block.header.header.Number, "err", err)
+				s.scores.ban(fork.peer)
+			}
+			return fmt.Errorf("store block %d of the fork: %w", block.header.header.Number, err)
+		}
+	}
+	return nil
+}
+
+// downloadFork returns the blocks of the chain of the peer that served fork after its common ancestor with the local
+// chain, up to fork, once they are verified and the fork is confirmed by another peer. It returns errInvalidBlock if
+// the peer served a block or header that fails verification or doesn't link up to fork.
+func (s *syncService) downloadFork(ctx context.Context, fork *specBlock) ([]*specBlock, uint64, error) {
+	forkHeader, err := s.verifyHeader(fork.header.header)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: %v", errInvalidBlock, err)
+	}
+	head, err := s.blockchain.HeadsHeader()
+	if err != nil {
+		return nil, 0, err
+	}
+	if forkHeader.Number <= head.Number {
+		return nil, 0, fmt.Errorf("fork at block %d isn't longer than the local chain", forkHeader.Number)
+	}
+	if forkHeader.Number == 0 {
+		return nil, 0, errNoCommonAncestor
+	}
+
+	peerService := s.withPeer(fork.peer)
+	ancestor, headers, err := peerService.findCommonAncestor(ctx, forkHeader.Number-1, forkHeader.ParentHash)
+	if err != nil {
+		return nil, 0, err
+	}
+	headers = append(headers, forkHeader)
+	if err = s.confirmFork(ctx, fork.peer, headers[0]); err != nil {
+		return nil, 0, err
+	}
+
+	blocks := make([]*specBlock, 0, len(headers))
+	for _, header := range headers[:len(headers)-1] {
+		block, err := peerService.downloadBlock(ctx, header.Number)
+		if err != nil {
+			return nil, 0, fmt.Errorf("download block %d: %w", header.Number, err)
+		}
+		block.peer = fork.peer
+		blocks = append(blocks, block)
+	}
+	blocks = append(blocks, fork)
+
+	for i, block := range blocks {
+		if err = s.verifyForkBlock(block, headers[i].Hash); err != nil {
+			return nil, 0, err
+		}
+	}
+	return blocks, ancestor, nil
+}
+
+// findCommonAncestor walks the headers s's peer serves backwards from number, whose hash is hash, until one matches
+// the local header of the same number. Each header is verified, so that the hashes linking the headers up are the ones
+// the headers commit to. It returns the ancestor and the verified headers after it, in order.
+func (s *syncService) findCommonAncestor(ctx context.Context, number uint64, hash *felt.Felt) (uint64, []*core.Header,
+	error,
+) {
+	var forkHeaders []*core.Header
+	for {
+		headers, err := s.client.RequestBlockHeaders(ctx, &spec.BlockHeadersRequest{
+			Iteration: &spec.Iteration{
+				Start:     &spec.Iteration_BlockNumber{BlockNumber: number},
+				Direction: spec.Iteration_Backward,
+				Limit:     min(ancestorBatchSize, number+1),
+				Step:      1,
+			},
+		})
+		if err != nil {
+			return 0, nil, err
+		}
+
+		received := 0
+		for res := range headers {
+			v, ok := res.HeaderMessage.(*spec.BlockHeadersResponse_Header)
+			if !ok {
+				break
+			}
+			header, err := s.verifyHeader(v.Header)
+			if err != nil {
+				return 0, nil, fmt.Errorf("%w: %v", errInvalidBlock, err)
+			}
+			if header.Number != number || !header.Hash.Equal(hash) {
+				return 0, nil, fmt.Errorf("%w: header %d doesn't link to the fork", errInvalidBlock, header.Number)
+			}
+
+			local, err := s.blockchain.BlockHeaderByNumber(number)
+			if err != nil {
+				return 0, nil, fmt.Errorf("read local header %d: %w", number, err)
+			}
+			if local.Hash.Equal(hash) {
+				return number, forkHeaders, nil
+			}
+			if number == 0 {
+				return 0, nil, errNoCommonAncestor
+			}
+
+			forkHeaders = append([]*core.Header{header}, forkHeaders...)
+			number--
+			hash = header.ParentHash
+			received++
+		}
+		if received == 0 {
+			return 0, nil, errors.New("peer served no headers")
+		}
+	}
+}
+
+// confirmFork checks that a peer other than forkPeer serves header, the first header of a fork after the common
+// ancestor, so that a single peer can't make the local chain revert.
+func (s *syncService) confirmFork(ctx context.Context, forkPeer peer.ID, header *core.Header) error {
+	others := make([]peer.ID, 0)
+	for _, id := range s.peers() {
+		if id != forkPeer {
+			others = append(others, id)
+		}
+	}
+
+	for _, id := range s.scores.rank(others, header.Number) {
+		served, err := s.withPeer(id).requestHeader(ctx, header.Number)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			s.log.Debugw("Peer didn't confirm fork", "peer", id, "number", header.Number, "err", err)
+			continue
+		}
+		if served.Hash.Equal(header.Hash) {
+			return nil
+		}
+	}
+	return errForkNotConfirmed
+}
+
+// requestHeader requests the header of blockNumber and verifies it. It returns errInvalidBlock if the peer served an
+// invalid header.
+func (s *syncService) requestHeader(ctx context.Context, blockNumber uint64) (*core.Header, error) {
+	ctx, cancel := context.WithTimeout(ctx, blockDownloadTimeout)
+	defer cancel()
+
+	headersCh, err := s.genHeadersAndSigs(ctx, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	res, ok := <-headersCh
+	if !ok {
+		return nil, errBlockNotServed
+	}
+	header, err := s.verifyHeader(res.header)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidBlock, err)
+	}
+	if header.Number != blockNumber {
+		return nil, fmt.Errorf("%w: requested header %d, got %d", errInvalidBlock, blockNumber, header.Number)
+	}
+	return header, nil
+}
+
+// verifyForkBlock checks that the parts of a block of a fork match its header, whose hash is hash. The state diff is
+// only checked against the header of blocks from 0.13.2 on, as older headers don't commit to it, and the state root
+// once the block is stored.
+func (s *syncService) verifyForkBlock(block *specBlock, hash *felt.Felt) error {
+	number := block.header.header.Number
+	if _, err := s.verifyHeader(block.header.header); err != nil {
+		return fmt.Errorf("%w: %v", errInvalidBlock, err)
+	}
+	b, err := s.adaptBlock(block.header.header, block.txs.txs, block.txs.receipts, block.events.events)
+	if err != nil {
+		return fmt.Errorf("%w: block %d: %v", errInvalidBlock, number, err)
+	}
+	if !b.Hash.Equal(hash) {
+		return fmt.Errorf("%w: block %d doesn't link to the fork", errInvalidBlock, number)
+	}
+	// Deployed contracts and replaced classes commit to the state diff alike, so no state is needed to tell them apart.
+	stateDiff := p2p2core.AdaptStateDiff(nil, block.diffs.contractDiffs, block.classes.classes)
+	if _, err = core.VerifyBlockHash(b, s.network, stateDiff); err != nil {
+		return fmt.Errorf("%w: block %d: %v", errInvalidBlock, number, err)
+	}
+	return nil
+}
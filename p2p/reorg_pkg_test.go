@@ -0,0 +1,128 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/crypto"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// relink sets the parent hash of a pre-0.7 mainnet block and recomputes its hash.
+func relink(t *testing.T, block *core.Block, stateUpdate *core.StateUpdate, parentHash *felt.Felt) {
+	t.Helper()
+	commitments, err := core.VerifyBlockHash(block, &utils.Mainnet, stateUpdate.StateDiff)
+	require.NoError(t, err)
+	block.ParentHash = parentHash
+	block.Hash = crypto.PedersenArray(new(felt.Felt).SetUint64(block.Number), block.GlobalStateRoot, &felt.Zero, &felt.Zero,
+		new(felt.Felt).SetUint64(block.TransactionCount), commitments.TransactionCommitment, &felt.Zero, &felt.Zero,
+		&felt.Zero, &felt.Zero, utils.Mainnet.L2ChainIDFelt(), parentHash)
+	stateUpdate.BlockHash = block.Hash
+}
+
+// downloadFrom downloads a block from id.
+func downloadFrom(t *testing.T, s *syncService, id peer.ID, blockNumber uint64) *specBlock {
+	t.Helper()
+	block, err := s.withPeer(id).downloadBlock(context.Background(), blockNumber)
+	require.NoError(t, err)
+	block.peer = id
+	return block
+}
+
+func TestReorg(t *testing.T) {
+	chain := newTestChain(t, 2, nil)
+	head, err := chain.HeadsHeader()
+	require.NoError(t, err)
+
+	// The local chain has another block 1, which the blocks of chain don't link to.
+	localHash := new(felt.Felt).SetUint64(1)
+	newLocalService := func(t *testing.T) *syncService {
+		t.Helper()
+		s := newTestService(t)
+		s.blockchain = newTestChain(t, 1, func(block *core.Block, _ *core.StateUpdate) {
+			if block.Number == 1 {
+				block.Hash = localHash
+			}
+		})
+		return s
+	}
+	assertLocalHead := func(t *testing.T, s *syncService) {
+		t.Helper()
+		got, err := s.blockchain.HeadsHeader()
+		require.NoError(t, err)
+		assert.Equal(t, localHash, got.Hash)
+	}
+
+	t.Run("fork confirmed by another peer", func(t *testing.T) {
+		s := newLocalService(t)
+		forkPeer := servePeer(t, s, chain)
+		servePeer(t, s, chain)
+
+		require.NoError(t, s.reorg(context.Background(), downloadFrom(t, s, forkPeer, 2)))
+		got, err := s.blockchain.HeadsHeader()
+		require.NoError(t, err)
+		assert.Equal(t, head.Hash, got.Hash)
+		assert.False(t, s.scores.banned(forkPeer))
+	})
+
+	t.Run("fork not confirmed by another peer", func(t *testing.T) {
+		s := newLocalService(t)
+		forkPeer := servePeer(t, s, chain)
+		// The other peer doesn't have the first block of the fork yet.
+		servePeer(t, s, newTestChain(t, 0, nil))
+
+		require.ErrorIs(t, s.reorg(context.Background(), downloadFrom(t, s, forkPeer, 2)), errForkNotConfirmed)
+		assertLocalHead(t, s)
+		assert.False(t, s.scores.banned(forkPeer))
+	})
+
+	t.Run("fork block with a forged hash", func(t *testing.T) {
+		s := newLocalService(t)
+		forkPeer := servePeer(t, s, newTestChain(t, 2, func(block *core.Block, _ *core.StateUpdate) {
+			if block.Number == 2 {
+				block.Hash = new(felt.Felt).SetUint64(2)
+			}
+		}))
+		servePeer(t, s, chain)
+
+		require.ErrorIs(t, s.reorg(context.Background(), downloadFrom(t, s, forkPeer, 2)), errInvalidBlock)
+		assertLocalHead(t, s)
+		assert.True(t, s.scores.banned(forkPeer))
+	})
+
+	t.Run("headers that don't link up", func(t *testing.T) {
+		s := newLocalService(t)
+		// Block 2 links to the hash the peer serves for block 1, which the header of block 1 doesn't commit to.
+		forgedHash := new(felt.Felt).SetUint64(3)
+		forkPeer := servePeer(t, s, newTestChain(t, 2, func(block *core.Block, stateUpdate *core.StateUpdate) {
+			switch block.Number {
+			case 1:
+				block.Hash = forgedHash
+			case 2:
+				relink(t, block, stateUpdate, forgedHash)
+			}
+		}))
+		servePeer(t, s, chain)
+
+		fork := downloadFrom(t, s, forkPeer, 2)
+		_, err := s.verifyHeader(fork.header.header)
+		require.NoError(t, err)
+		require.ErrorIs(t, s.reorg(context.Background(), fork), errInvalidBlock)
+		assertLocalHead(t, s)
+		assert.True(t, s.scores.banned(forkPeer))
+	})
+
+	t.Run("fork not longer than the local chain", func(t *testing.T) {
+		s := newLocalService(t)
+		forkPeer := servePeer(t, s, chain)
+		servePeer(t, s, chain)
+
+		require.ErrorContains(t, s.reorg(context.Background(), downloadFrom(t, s, forkPeer, 1)), "isn't longer")
+		assertLocalHead(t, s)
+	})
+}
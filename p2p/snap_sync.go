@@ -0,0 +1,702 @@
+package p2p
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"time"
+
+	"github.com/NethermindEth/juno/adapters/core2p2p"
+	"github.com/NethermindEth/juno/adapters/p2p2core"
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/crypto"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/core/trie"
+	"github.com/NethermindEth/juno/p2p/starknet"
+	"github.com/NethermindEth/juno/p2p/starknet/spec"
+	"github.com/NethermindEth/juno/utils"
+)
+
+const (
+	snapRetryDelay = time.Second
+	// snapClassAttempts is the number of times declared classes that aren't proven to exist are requested.
+	snapClassAttempts = 3
+)
+
+// Snap sync downloads the state of a recent block from the trie ranges peers serve, and stores the block as the head
+// of an empty blockchain. Peers only serve the state of their head, so the block, called the pivot, moves while the
+// state is downloaded. The blocks between the first and the last pivot are replayed over the downloaded state, which
+// brings the values downloaded at earlier pivots up to date, as state diffs set values rather than change them. The
+// state is then verified against the state root of the last pivot, and downloaded again if it doesn't match.
+
+// snapPivots tracks the range of blocks the downloaded state was served at.
+type snapPivots struct {
+	first, last *spec.SnapshotHead
+	// headers are the verified headers of the pivots, by number.
+	headers map[uint64]*core.Header
+}
+
+// updatePivots verifies that head commits to the roots of its tries and adds it to the range of pivots. The block must
+// be below the announced head of the network, and its header, requested from any peer, must be verified, so that a
+// peer can't widen the range with a block that doesn't exist.
+func (s *syncService) updatePivots(ctx context.Context, pivots *snapPivots, head *spec.SnapshotHead) error {
+	if head.GetBlock() == nil {
+		return errors.New("snapshot without a head")
+	}
+	stateRoot := p2p2core.AdaptHash(head.StateRoot)
+	contractsRoot, classesRoot := p2p2core.AdaptHash(head.ContractsRoot), p2p2core.AdaptHash(head.ClassesRoot)
+	if stateRoot == nil || contractsRoot == nil || classesRoot == nil {
+		return errors.New("snapshot head without trie roots")
+	}
+	number := head.Block.Number
+	if !core.StateCommitment(contractsRoot, classesRoot).Equal(stateRoot) {
+		return fmt.Errorf("trie roots of snapshot head #%d don't match its state root", number)
+	}
+	if height, announced := s.announced.height(); announced && number >= height {
+		return fmt.Errorf("snapshot head #%d is beyond the announced head of the network", number)
+	}
+
+	header, ok := pivots.headers[number]
+	if !ok {
+		headersCh, err := s.genHeadersAndSigs(ctx, number)
+		if err != nil {
+			return err
+		}
+		res, ok := <-headersCh
+		if !ok {
+			return fmt.Errorf("no header for snapshot head #%d", number)
+		}
+		if header, err = s.verifyHeader(res.header); err != nil {
+			return err
+		}
+		if header.Number != number {
+			return fmt.Errorf("requested header %d, got %d", number, header.Number)
+		}
+		if pivots.headers == nil {
+			pivots.headers = make(map[uint64]*core.Header)
+		}
+		pivots.headers[number] = header
+	}
+	if !header.Hash.Equal(p2p2core.AdaptHash(head.Block.Header)) || !header.GlobalStateRoot.Equal(stateRoot) {
+		return fmt.Errorf("snapshot head #%d doesn't match the header of the block", number)
+	}
+
+	if pivots.first == nil || number < pivots.first.Block.Number {
+		pivots.first = head
+	}
+	if pivots.last == nil || number > pivots.last.Block.Number {
+		pivots.last = head
+	}
+	return nil
+}
+
+// verifyRange verifies that keys are all the leaves of the trie with the given root from start up to the right
+// boundary of the proof, or up to the end of the trie if there are no more leaves.
+func verifyRange(root *felt.Felt, hash func(*felt.Felt, *felt.Felt) *felt.Felt, start *felt.Felt, keys, values []*felt.Felt,
+	proof *spec.PatriciaRangeProof, more bool,
+) error {
+	var (
+		proofKeys   [2]*trie.Key
+		proofValues [2]*felt.Felt
+		proofs      [2][]trie.ProofNode
+	)
+	for i, boundary := range []*spec.PatriciaBoundary{proof.GetLeft(), proof.GetRight()} {
+		if boundary == nil {
+			continue
+		}
+		key := p2p2core.AdaptFelt(boundary.Key)
+		if key == nil {
+			return errors.New("range boundary without a key")
+		}
+		proofNodes, err := p2p2core.AdaptProof(boundary.Proof)
+		if err != nil {
+			return err
+		}
+		keyBytes := key.Bytes()
+		// All state tries have the height of the contract storage tries.
+		leafKey := trie.NewKey(core.ContractStorageTrieHeight, keyBytes[:])
+		proofKeys[i], proofValues[i], proofs[i] = &leafKey, p2p2core.AdaptFelt(boundary.Value), proofNodes
+	}
+
+	// The left boundary is the key before start, so that the proof covers the leaves from start.
+	if start.IsZero() != (proofKeys[0] == nil) {
+		return errors.New("range proof doesn't start at the requested start")
+	}
+	if proofKeys[0] != nil {
+		if leftKey := proofKeys[0].Felt(); !new(felt.Felt).Add(&leftKey, new(felt.Felt).SetUint64(1)).Equal(start) {
+			return errors.New("range proof doesn't start at the requested start")
+		}
+	}
+	if more != (proofKeys[1] != nil) {
+		return errors.New("range proof doesn't end where the range does")
+	}
+
+	_, err := trie.VerifyRangeProof(root, keys, values, proofKeys, proofValues, proofs, hash)
+	return err
+}
+
+// firstResponse returns the first response of a request, as the snapshot protocols send one per request.
+func firstResponse[T any](responses iter.Seq[T], err error) (T, error) {
+	var zero T
+	if err != nil {
+		return zero, err
+	}
+	for res := range responses {
+		return res, nil
+	}
+	return zero, errors.New("no response")
+}
+
+// retry calls f until it succeeds, as requests go to random peers which may fail or serve invalid data.
+func (s *syncService) retry(ctx context.Context, what string, f func() error) error {
+	for {
+		err := f()
+		if err == nil {
+			return nil
+		}
+		s.log.Debugw("Snap sync request failed", "request", what, "err", err)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(snapRetryDelay):
+		}
+	}
+}
+
+// errSnapStateMismatch is returned when the downloaded state doesn't match the state root of the last pivot.
+var errSnapStateMismatch = errors.New("downloaded state doesn't match the pivot")
+
+// snapSync downloads the state and stores the last pivot block as the head of the empty blockchain. Storage ranges
+// served at other pivots than their contract aren't proven, so the state is downloaded again until it matches.
+func (s *syncService) snapSync(ctx context.Context) error {
+	for {
+		err := s.snapDownload(ctx)
+		if !errors.Is(err, errSnapStateMismatch) {
+			return err
+		}
+		s.log.Warnw("Downloading the state again", "err", err)
+	}
+}
+
+// snapDownload discards the state of an interrupted snap sync, downloads the state and stores the last pivot block.
+func (s *syncService) snapDownload(ctx context.Context) error {
+	root, err := s.blockchain.StateCommitment()
+	if err != nil {
+		return err
+	}
+	if !root.IsZero() {
+		s.log.Infow("Discarding the state of an interrupted snap sync")
+		if err = s.blockchain.ResetSnapshot(); err != nil {
+			return err
+		}
+	}
+
+	var pivots snapPivots
+	// Class hashes of contracts, whose classes are downloaded after the Cairo 1 classes.
+	classHashes := make(map[felt.Felt]struct{})
+	// Class hashes of the classes that were downloaded.
+	downloaded := make(map[felt.Felt]struct{})
+
+	s.log.Infow("Downloading contracts")
+	if err = s.snapContracts(ctx, &pivots, classHashes); err != nil {
+		return fmt.Errorf("download contracts: %w", err)
+	}
+	s.log.Infow("Downloading Cairo 1 classes")
+	if err = s.snapClasses(ctx, &pivots, downloaded); err != nil {
+		return fmt.Errorf("download classes: %w", err)
+	}
+
+	first, last := pivots.first.Block.Number, pivots.last.Block.Number
+	s.log.Infow("Replaying blocks between pivots", "from", first+1, "to", last)
+	for blockNumber := first + 1; blockNumber <= last; blockNumber++ {
+		if err = s.snapReplayBlock(ctx, blockNumber, classHashes, downloaded); err != nil {
+			return fmt.Errorf("replay block %d: %w", blockNumber, err)
+		}
+	}
+
+	var missing []*felt.Felt
+	for classHash := range classHashes {
+		if _, ok := downloaded[classHash]; !ok {
+			missing = append(missing, &classHash)
+		}
+	}
+	s.log.Infow("Downloading classes of contracts", "count", len(missing))
+	classes, err := s.snapClassDefinitions(ctx, missing, false)
+	if err != nil {
+		return fmt.Errorf("download classes of contracts: %w", err)
+	}
+	if err = s.blockchain.ApplySnapshot(last, core.EmptyStateDiff(), classes); err != nil {
+		return err
+	}
+
+	// Cairo 0 classes that were declared but never deployed are in neither trie.
+	declared, err := s.snapDeclaredClassHashes(ctx)
+	if err != nil {
+		return fmt.Errorf("list declared classes: %w", err)
+	}
+	missing = missing[:0]
+	for _, classHash := range declared {
+		_, isDownloaded := downloaded[*classHash]
+		_, isContractClass := classHashes[*classHash]
+		if !isDownloaded && !isContractClass {
+			missing = append(missing, classHash)
+		}
+	}
+	s.log.Infow("Downloading declared classes", "count", len(missing))
+	if classes, err = s.snapClassDefinitions(ctx, missing, true); err != nil {
+		return fmt.Errorf("download declared classes: %w", err)
+	}
+	if err = s.blockchain.ApplySnapshot(last, core.EmptyStateDiff(), classes); err != nil {
+		return err
+	}
+
+	return s.snapStorePivot(ctx, pivots.last)
+}
+
+func (s *syncService) snapContracts(ctx context.Context, pivots *snapPivots, classHashes map[felt.Felt]struct{}) error {
+	start := &felt.Zero
+	for {
+		var res *spec.ContractRangeResponse
+		err := s.retry(ctx, "contract range", func() error {
+			var err error
+			res, err = firstResponse(s.client.RequestContractRange(ctx, &spec.ContractRangeRequest{
+				Start: core2p2p.AdaptAddress(start),
+				Limit: starknet.MaxRangeLimit,
+			}))
+			if err != nil {
+				return err
+			}
+			if err = s.updatePivots(ctx, pivots, res.Head); err != nil {
+				return err
+			}
+
+			keys := make([]*felt.Felt, 0, len(res.States))
+			values := make([]*felt.Felt, 0, len(res.States))
+			for _, state := range res.States {
+				addr, classHash := p2p2core.AdaptAddress(state.Address), p2p2core.AdaptHash(state.ClassHash)
+				storageRoot, nonce := p2p2core.AdaptHash(state.StorageRoot), p2p2core.AdaptFelt(state.Nonce)
+				if addr == nil || classHash == nil || storageRoot == nil || nonce == nil {
+					return errors.New("incomplete contract state")
+				}
+				keys = append(keys, addr)
+				values = append(values, core.ContractCommitment(storageRoot, classHash, nonce))
+			}
+			return verifyRange(p2p2core.AdaptHash(res.Head.ContractsRoot), crypto.Pedersen, start, keys, values, res.Proof, res.More)
+		})
+		if err != nil {
+			return err
+		}
+
+		diff := core.EmptyStateDiff()
+		for _, state := range res.States {
+			addr := p2p2core.AdaptAddress(state.Address)
+			classHash := p2p2core.AdaptHash(state.ClassHash)
+			diff.DeployedContracts[*addr] = classHash
+			if nonce := p2p2core.AdaptFelt(state.Nonce); !nonce.IsZero() {
+				diff.Nonces[*addr] = nonce
+			}
+			classHashes[*classHash] = struct{}{}
+		}
+		if err = s.blockchain.ApplySnapshot(res.Head.Block.Number, diff, nil); err != nil {
+			return err
+		}
+
+		for _, state := range res.States {
+			if p2p2core.AdaptHash(state.StorageRoot).IsZero() {
+				continue
+			}
+			err = s.snapStorage(ctx, pivots, p2p2core.AdaptAddress(state.Address), p2p2core.AdaptHash(state.StorageRoot),
+				res.Head.Block.Number)
+			if err != nil {
+				return err
+			}
+		}
+
+		if !res.More || len(res.States) == 0 {
+			return nil
+		}
+		start = new(felt.Felt).Add(p2p2core.AdaptAddress(res.States[len(res.States)-1].Address), new(felt.Felt).SetUint64(1))
+		s.log.Debugw("Downloaded contracts", "up to", start)
+	}
+}
+
+// snapStorage downloads the storage of the contract at addr, whose storage root was proven at pivot by the contract
+// range. Ranges served at that pivot must have that root, other ranges are only checked with the whole state.
+func (s *syncService) snapStorage(ctx context.Context, pivots *snapPivots, addr, provenRoot *felt.Felt, pivot uint64) error {
+	start := &felt.Zero
+	for {
+		var (
+			res    *spec.ContractStorageResponse
+			values map[felt.Felt]*felt.Felt
+		)
+		err := s.retry(ctx, "contract storage", func() error {
+			var err error
+			res, err = firstResponse(s.client.RequestContractStorage(ctx, &spec.ContractStorageRequest{
+				Address: core2p2p.AdaptAddress(addr),
+				Start:   core2p2p.AdaptFelt(start),
+				Limit:   starknet.MaxRangeLimit,
+			}))
+			if err != nil {
+				return err
+			}
+			if err = s.updatePivots(ctx, pivots, res.Head); err != nil {
+				return err
+			}
+			storageRoot := p2p2core.AdaptHash(res.StorageRoot)
+			if storageRoot == nil {
+				return errors.New("storage range without a root")
+			}
+			if res.Head.Block.Number == pivot && !storageRoot.Equal(provenRoot) {
+				return errors.New("storage root doesn't match the proven contract state")
+			}
+
+			keys := make([]*felt.Felt, 0, len(res.Values))
+			leaves := make([]*felt.Felt, 0, len(res.Values))
+			values = make(map[felt.Felt]*felt.Felt, len(res.Values))
+			for _, value := range res.Values {
+				key, leaf := p2p2core.AdaptFelt(value.Key), p2p2core.AdaptFelt(value.Value)
+				if key == nil || leaf == nil {
+					return errors.New("incomplete storage value")
+				}
+				keys = append(keys, key)
+				leaves = append(leaves, leaf)
+				values[*key] = leaf
+			}
+			return verifyRange(storageRoot, crypto.Pedersen, start, keys, leaves, res.Proof, res.More)
+		})
+		if err != nil {
+			return err
+		}
+
+		diff := core.EmptyStateDiff()
+		diff.StorageDiffs[*addr] = values
+		if err = s.blockchain.ApplySnapshot(res.Head.Block.Number, diff, nil); err != nil {
+			return err
+		}
+
+		if !res.More || len(res.Values) == 0 {
+			return nil
+		}
+		start = new(felt.Felt).Add(p2p2core.AdaptFelt(res.Values[len(res.Values)-1].Key), new(felt.Felt).SetUint64(1))
+	}
+}
+
+func (s *syncService) snapClasses(ctx context.Context, pivots *snapPivots, downloaded map[felt.Felt]struct{}) error {
+	start := &felt.Zero
+	for {
+		var (
+			res                 *spec.ClassRangeResponse
+			compiledClassHashes map[felt.Felt]*felt.Felt
+		)
+		err := s.retry(ctx, "class range", func() error {
+			var err error
+			res, err = firstResponse(s.client.RequestClassRange(ctx, &spec.ClassRangeRequest{
+				Start: core2p2p.AdaptHash(start),
+				Limit: starknet.MaxRangeLimit,
+			}))
+			if err != nil {
+				return err
+			}
+			if err = s.updatePivots(ctx, pivots, res.Head); err != nil {
+				return err
+			}
+
+			keys := make([]*felt.Felt, 0, len(res.Classes))
+			leaves := make([]*felt.Felt, 0, len(res.Classes))
+			compiledClassHashes = make(map[felt.Felt]*felt.Felt, len(res.Classes))
+			for _, class := range res.Classes {
+				classHash, compiledClassHash := p2p2core.AdaptHash(class.ClassHash), p2p2core.AdaptHash(class.CompiledClassHash)
+				if classHash == nil || compiledClassHash == nil {
+					return errors.New("incomplete class")
+				}
+				keys = append(keys, classHash)
+				leaves = append(leaves, core.ClassCommitmentLeaf(compiledClassHash))
+				compiledClassHashes[*classHash] = compiledClassHash
+			}
+			return verifyRange(p2p2core.AdaptHash(res.Head.ClassesRoot), crypto.Poseidon, start, keys, leaves, res.Proof, res.More)
+		})
+		if err != nil {
+			return err
+		}
+
+		classHashes := make([]*felt.Felt, 0, len(compiledClassHashes))
+		for classHash := range compiledClassHashes {
+			classHashes = append(classHashes, &classHash)
+		}
+		classes, err := s.snapClassDefinitions(ctx, classHashes, false)
+		if err != nil {
+			return err
+		}
+		for classHash, class := range classes {
+			cairo1, ok := class.(*core.Cairo1Class)
+			if !ok || cairo1.Compiled == nil || !cairo1.Compiled.Hash().Equal(compiledClassHashes[classHash]) {
+				return fmt.Errorf("class %s doesn't match its compiled class hash", &classHash)
+			}
+			downloaded[classHash] = struct{}{}
+		}
+
+		diff := core.EmptyStateDiff()
+		diff.DeclaredV1Classes = compiledClassHashes
+		if err = s.blockchain.ApplySnapshot(res.Head.Block.Number, diff, classes); err != nil {
+			return err
+		}
+
+		if !res.More || len(res.Classes) == 0 {
+			return nil
+		}
+		start = new(felt.Felt).Add(p2p2core.AdaptHash(res.Classes[len(res.Classes)-1].ClassHash), new(felt.Felt).SetUint64(1))
+	}
+}
+
+// snapDeclaredClassHashes lists the hashes of the classes declared at the head of a peer.
+func (s *syncService) snapDeclaredClassHashes(ctx context.Context) ([]*felt.Felt, error) {
+	var classHashes []*felt.Felt
+	start := &felt.Zero
+	for {
+		var res *spec.DeclaredClassHashesResponse
+		err := s.retry(ctx, "declared class hashes", func() error {
+			var err error
+			res, err = firstResponse(s.client.RequestDeclaredClassHashes(ctx, &spec.DeclaredClassHashesRequest{
+				Start: core2p2p.AdaptHash(start),
+				Limit: starknet.MaxRangeLimit,
+			}))
+			if err != nil {
+				return err
+			}
+			for _, classHash := range res.ClassHashes {
+				if classHash == nil {
+					return errors.New("missing class hash")
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		classHashes = append(classHashes, utils.Map(res.ClassHashes, p2p2core.AdaptHash)...)
+		if !res.More || len(res.ClassHashes) == 0 {
+			return classHashes, nil
+		}
+		start = new(felt.Felt).Add(classHashes[len(classHashes)-1], new(felt.Felt).SetUint64(1))
+	}
+}
+
+// snapClassDefinitions downloads the classes with the given hashes. Unless the hashes are proven to be declared, classes
+// no peer serves after snapClassAttempts requests are skipped rather than requested forever.
+func (s *syncService) snapClassDefinitions(ctx context.Context, classHashes []*felt.Felt, unproven bool,
+) (map[felt.Felt]core.Class, error) {
+	classes := make(map[felt.Felt]core.Class, len(classHashes))
+	for len(classHashes) > 0 {
+		batch := classHashes[:min(len(classHashes), starknet.MaxClassesByHash)]
+		pending, attempts := batch, 0
+		err := s.retry(ctx, "classes", func() error {
+			responses, err := s.client.RequestClassesByHash(ctx, &spec.ClassesByHashRequest{
+				ClassHashes: utils.Map(pending, core2p2p.AdaptHash),
+			})
+			if err != nil {
+				return err
+			}
+
+			for res := range responses {
+				class, ok := res.ClassMessage.(*spec.ClassesResponse_Class)
+				if !ok || class.Class == nil {
+					break
+				}
+				coreClass := p2p2core.AdaptClass(class.Class)
+				classHash, err := coreClass.Hash()
+				if err != nil {
+					return err
+				}
+				if !slices.ContainsFunc(pending, classHash.Equal) {
+					return fmt.Errorf("class %s wasn't requested", classHash)
+				}
+				classes[*classHash] = coreClass
+			}
+
+			var missing []*felt.Felt
+			for _, classHash := range pending {
+				if _, ok := classes[*classHash]; !ok {
+					missing = append(missing, classHash)
+				}
+			}
+			if pending = missing; len(pending) == 0 {
+				return nil
+			}
+			if attempts++; unproven && attempts == snapClassAttempts {
+				s.log.Warnw("Skipping declared classes no peer served", "count", len(pending))
+				return nil
+			}
+			return fmt.Errorf("%d classes are missing", len(pending))
+		})
+		if err != nil {
+			return nil, err
+		}
+		classHashes = classHashes[len(batch):]
+	}
+	return classes, nil
+}
+
+// snapReplayBlock applies the state diff of a block between the first and the last pivot, once it is verified against
+// the header of the block.
+func (s *syncService) snapReplayBlock(ctx context.Context, blockNumber uint64, classHashes,
+	downloaded map[felt.Felt]struct{},
+) error {
+	var (
+		diff       *core.StateDiff
+		newClasses map[felt.Felt]core.Class
+	)
+	err := s.retry(ctx, "block state diff", func() error {
+		headersCh, err := s.genHeadersAndSigs(ctx, blockNumber)
+		if err != nil {
+			return err
+		}
+		stateDiffsCh, err := s.genStateDiffs(ctx, blockNumber)
+		if err != nil {
+			return err
+		}
+		classesCh, err := s.genClasses(ctx, blockNumber)
+		if err != nil {
+			return err
+		}
+		header, ok := <-headersCh
+		if !ok {
+			return errors.New("no header")
+		}
+		if _, err = s.verifyHeader(header.header); err != nil {
+			return err
+		}
+		if header.header.Number != blockNumber {
+			return fmt.Errorf("requested header %d, got %d", blockNumber, header.header.Number)
+		}
+		contractDiffs, ok := <-stateDiffsCh
+		if !ok {
+			return errors.New("no state diff")
+		}
+		classes, ok := <-classesCh
+		if !ok {
+			return errors.New("no classes")
+		}
+
+		newClasses = make(map[felt.Felt]core.Class, len(classes.classes))
+		for _, class := range classes.classes {
+			coreClass := p2p2core.AdaptClass(class)
+			classHash, err := coreClass.Hash()
+			if err != nil {
+				return err
+			}
+			newClasses[*classHash] = coreClass
+		}
+		// Without the state before the block, contracts whose class is replaced appear to be deployed, which
+		// applying the diff handles alike.
+		diff = p2p2core.AdaptStateDiff(nil, contractDiffs.contractDiffs, classes.classes)
+		return checkStateDiff(header.header, diff)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, classHash := range diff.DeployedContracts {
+		classHashes[*classHash] = struct{}{}
+	}
+	for classHash := range newClasses {
+		downloaded[classHash] = struct{}{}
+	}
+	return s.blockchain.ApplySnapshot(blockNumber, diff, newClasses)
+}
+
+// checkStateDiff checks that diff is the state diff the verified header commits to. Headers before 0.13.2 only
+// commit to it through their signature.
+func checkStateDiff(header *spec.SignedBlockHeader, diff *core.StateDiff) error {
+	commitment := header.GetStateDiffCommitment()
+	root := p2p2core.AdaptHash(commitment.GetRoot())
+	if root == nil || !diff.Hash().Equal(root) || diff.Length() != commitment.GetStateDiffLength() {
+		return fmt.Errorf("state diff doesn't match the header of block %d", header.Number)
+	}
+	return nil
+}
+
+// snapStorePivot stores the last pivot block, verifying the downloaded state against its state root. It returns
+// errSnapStateMismatch if the state doesn't match.
+func (s *syncService) snapStorePivot(ctx context.Context, pivot *spec.SnapshotHead) error {
+	blockNumber := pivot.Block.Number
+	var body blockBody
+	err := s.retry(ctx, "pivot block", func() error {
+		prevBlockRoot := &felt.Zero
+		if blockNumber > 0 {
+			headersCh, err := s.genHeadersAndSigs(ctx, blockNumber-1)
+			if err != nil {
+				return err
+			}
+			prevHeader, ok := <-headersCh
+			if !ok {
+				return errors.New("no parent header")
+			}
+			prevBlockRoot = p2p2core.AdaptHash(prevHeader.header.StateRoot)
+		}
+
+		headersCh, err := s.genHeadersAndSigs(ctx, blockNumber)
+		if err != nil {
+			return err
+		}
+		txsCh, err := s.genTransactions(ctx, blockNumber)
+		if err != nil {
+			return err
+		}
+		eventsCh, err := s.genEvents(ctx, blockNumber)
+		if err != nil {
+			return err
+		}
+		classesCh, err := s.genClasses(ctx, blockNumber)
+		if err != nil {
+			return err
+		}
+		stateDiffsCh, err := s.genStateDiffs(ctx, blockNumber)
+		if err != nil {
+			return err
+		}
+
+		header, okHeader := <-headersCh
+		txs, okTxs := <-txsCh
+		events, okEvents := <-eventsCh
+		classes, okClasses := <-classesCh
+		contractDiffs, okDiffs := <-stateDiffsCh
+		if !okHeader || !okTxs || !okEvents || !okClasses || !okDiffs {
+			return errors.New("incomplete pivot block")
+		}
+
+		body = <-s.adaptAndSanityCheckBlock(ctx, header.header, contractDiffs.contractDiffs, classes.classes, txs.txs,
+			txs.receipts, events.events, prevBlockRoot)
+		if body.err != nil {
+			return body.err
+		}
+		if body.block == nil {
+			return errors.New("invalid pivot block")
+		}
+		if !body.block.Hash.Equal(p2p2core.AdaptHash(pivot.Block.Header)) {
+			return errors.New("pivot block hash doesn't match the snapshot head")
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	root, err := s.blockchain.StateCommitment()
+	if err != nil {
+		return err
+	}
+	if !root.Equal(body.block.GlobalStateRoot) {
+		return fmt.Errorf("%w: state root %s, block #%d has %s", errSnapStateMismatch, root, body.block.Number,
+			body.block.GlobalStateRoot)
+	}
+	if err = s.blockchain.StoreSnapshotHead(body.block, body.commitments, body.stateUpdate); err != nil {
+		return err
+	}
+	s.log.Infow("Stored snap sync pivot", "number", body.block.Number, "hash", body.block.Hash.ShortString(),
+		"root", body.block.GlobalStateRoot.ShortString())
+	return nil
+}
@@ -0,0 +1,279 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/juno/adapters/core2p2p"
+	"github.com/NethermindEth/juno/adapters/p2p2core"
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/crypto"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/p2p/starknet/spec"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// undeployedClassHash is a Cairo 0 class the snapshot test chain declares at block 1 but never deploys.
+const undeployedClassHash = "0x1efa8f84fd4dff9e2902ec88717cf0dafc8c188f80c3450615944a469428f7f"
+
+// newSnapTestChain stores the mainnet blocks up to head, along with a class that is declared but never deployed.
+func newSnapTestChain(t *testing.T, head uint64) *blockchain.Blockchain {
+	t.Helper()
+	return newTestChain(t, head, func(block *core.Block, stateUpdate *core.StateUpdate) {
+		if block.Number == 1 {
+			stateUpdate.StateDiff.DeclaredV0Classes = append(stateUpdate.StateDiff.DeclaredV0Classes,
+				utils.HexToFelt(t, undeployedClassHash))
+		}
+	})
+}
+
+func TestSnapSync(t *testing.T) {
+	chain := newSnapTestChain(t, 2)
+	s := newTestService(t, chain)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	t.Cleanup(cancel)
+	require.NoError(t, s.snapSync(ctx))
+
+	want, err := chain.HeadsHeader()
+	require.NoError(t, err)
+	got, err := s.blockchain.HeadsHeader()
+	require.NoError(t, err)
+	assert.Equal(t, want.Hash, got.Hash)
+	assert.Equal(t, want.GlobalStateRoot, got.GlobalStateRoot)
+
+	state, closer, err := s.blockchain.HeadState()
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, closer()) })
+	for _, classHash := range []string{"0x10455c752b86932ce552f2b0fe81a880746649b9aee7e0d842bf3f52378f9f8", undeployedClassHash} {
+		_, err = state.Class(utils.HexToFelt(t, classHash))
+		assert.NoError(t, err, classHash)
+	}
+}
+
+func TestSnapshotRanges(t *testing.T) {
+	chain := newSnapTestChain(t, 2)
+	s := newTestService(t, chain)
+	ctx := context.Background()
+
+	t.Run("contract range", func(t *testing.T) {
+		var addresses []*felt.Felt
+		for start := &felt.Zero; ; {
+			res, err := firstResponse(s.client.RequestContractRange(ctx, &spec.ContractRangeRequest{
+				Start: core2p2p.AdaptAddress(start),
+				Limit: 1,
+			}))
+			require.NoError(t, err)
+			require.Len(t, res.States, 1)
+
+			state := res.States[0]
+			address := p2p2core.AdaptAddress(state.Address)
+			value := core.ContractCommitment(p2p2core.AdaptHash(state.StorageRoot), p2p2core.AdaptHash(state.ClassHash),
+				p2p2core.AdaptFelt(state.Nonce))
+			root := p2p2core.AdaptHash(res.Head.ContractsRoot)
+			require.NoError(t, verifyRange(root, crypto.Pedersen, start, []*felt.Felt{address}, []*felt.Felt{value},
+				res.Proof, res.More))
+
+			// A range missing its only contract or ending early doesn't verify.
+			assert.Error(t, verifyRange(root, crypto.Pedersen, start, nil, nil, res.Proof, res.More))
+			assert.Error(t, verifyRange(root, crypto.Pedersen, start, []*felt.Felt{address}, []*felt.Felt{value},
+				res.Proof, !res.More))
+			assert.Error(t, verifyRange(root, crypto.Pedersen, start, []*felt.Felt{address},
+				[]*felt.Felt{new(felt.Felt).Add(value, new(felt.Felt).SetUint64(1))}, res.Proof, res.More))
+
+			addresses = append(addresses, address)
+			if !res.More {
+				break
+			}
+			start = new(felt.Felt).Add(address, new(felt.Felt).SetUint64(1))
+		}
+
+		state, closer, err := chain.HeadState()
+		require.NoError(t, err)
+		t.Cleanup(func() { require.NoError(t, closer()) })
+		for _, address := range addresses {
+			_, err = state.ContractClassHash(address)
+			require.NoError(t, err)
+		}
+		assert.Greater(t, len(addresses), 1)
+	})
+
+	t.Run("contract storage", func(t *testing.T) {
+		address := utils.HexToFelt(t, "0x20cfa74ee3564b4cd5435cdace0f9c4d43b939620e4a0bb5076105df0a626c6")
+		values := 0
+		for start := &felt.Zero; ; {
+			res, err := firstResponse(s.client.RequestContractStorage(ctx, &spec.ContractStorageRequest{
+				Address: core2p2p.AdaptAddress(address),
+				Start:   core2p2p.AdaptFelt(start),
+				Limit:   1,
+			}))
+			require.NoError(t, err)
+			require.Len(t, res.Values, 1)
+
+			key, value := p2p2core.AdaptFelt(res.Values[0].Key), p2p2core.AdaptFelt(res.Values[0].Value)
+			root := p2p2core.AdaptHash(res.StorageRoot)
+			require.NoError(t, verifyRange(root, crypto.Pedersen, start, []*felt.Felt{key}, []*felt.Felt{value},
+				res.Proof, res.More))
+			assert.Error(t, verifyRange(root, crypto.Pedersen, start, nil, nil, res.Proof, res.More))
+
+			values++
+			if !res.More {
+				break
+			}
+			start = new(felt.Felt).Add(key, new(felt.Felt).SetUint64(1))
+		}
+		assert.Greater(t, values, 1)
+	})
+
+	t.Run("class range of an empty trie", func(t *testing.T) {
+		res, err := firstResponse(s.client.RequestClassRange(ctx, &spec.ClassRangeRequest{
+			Start: core2p2p.AdaptHash(&felt.Zero),
+		}))
+		require.NoError(t, err)
+		assert.Empty(t, res.Classes)
+		assert.False(t, res.More)
+		require.NoError(t, verifyRange(p2p2core.AdaptHash(res.Head.ClassesRoot), crypto.Poseidon, &felt.Zero, nil, nil,
+			res.Proof, res.More))
+	})
+
+	t.Run("declared class hashes", func(t *testing.T) {
+		res, err := firstResponse(s.client.RequestDeclaredClassHashes(ctx, &spec.DeclaredClassHashesRequest{Limit: 1}))
+		require.NoError(t, err)
+		require.Len(t, res.ClassHashes, 1)
+		assert.True(t, res.More)
+
+		classHashes, err := s.snapDeclaredClassHashes(ctx)
+		require.NoError(t, err)
+		assert.Len(t, classHashes, 2)
+		assert.Contains(t, classHashes, utils.HexToFelt(t, undeployedClassHash))
+	})
+}
+
+func TestUpdatePivots(t *testing.T) {
+	chain := newSnapTestChain(t, 2)
+	s := newTestService(t, chain)
+	ctx := context.Background()
+
+	head := func(t *testing.T) *spec.SnapshotHead {
+		t.Helper()
+		res, err := firstResponse(s.client.RequestContractRange(ctx, &spec.ContractRangeRequest{Limit: 1}))
+		require.NoError(t, err)
+		return res.Head
+	}
+
+	t.Run("valid head", func(t *testing.T) {
+		var pivots snapPivots
+		require.NoError(t, s.updatePivots(ctx, &pivots, head(t)))
+		assert.Equal(t, uint64(2), pivots.first.Block.Number)
+		assert.Equal(t, uint64(2), pivots.last.Block.Number)
+	})
+
+	t.Run("trie roots don't match the state root", func(t *testing.T) {
+		h := head(t)
+		h.ClassesRoot = core2p2p.AdaptHash(new(felt.Felt).SetUint64(1))
+		assert.ErrorContains(t, s.updatePivots(ctx, new(snapPivots), h), "don't match its state root")
+	})
+
+	t.Run("head beyond the announced head", func(t *testing.T) {
+		h := head(t)
+		h.Block.Number = 1 << 40
+		s.announced.update(2)
+		t.Cleanup(func() { s.announced = new(announcedHead) })
+		assert.ErrorContains(t, s.updatePivots(ctx, new(snapPivots), h), "beyond the announced head")
+	})
+
+	t.Run("head of a block that doesn't exist", func(t *testing.T) {
+		h := head(t)
+		h.Block.Number = 1 << 40
+		var pivots snapPivots
+		require.Error(t, s.updatePivots(ctx, &pivots, h))
+		assert.Nil(t, pivots.last)
+	})
+
+	t.Run("head doesn't match the header of the block", func(t *testing.T) {
+		h := head(t)
+		h.Block.Number = 1
+		var pivots snapPivots
+		assert.ErrorContains(t, s.updatePivots(ctx, &pivots, h), "doesn't match the header")
+		assert.Nil(t, pivots.last)
+	})
+}
+
+func TestSnapStorage(t *testing.T) {
+	chain := newSnapTestChain(t, 2)
+	s := newTestService(t, chain)
+	ctx := context.Background()
+
+	address := utils.HexToFelt(t, "0x20cfa74ee3564b4cd5435cdace0f9c4d43b939620e4a0bb5076105df0a626c6")
+	res, err := firstResponse(s.client.RequestContractRange(ctx, &spec.ContractRangeRequest{
+		Start: core2p2p.AdaptAddress(address),
+		Limit: 1,
+	}))
+	require.NoError(t, err)
+	require.Len(t, res.States, 1)
+	provenRoot := p2p2core.AdaptHash(res.States[0].StorageRoot)
+	// Storage is downloaded after the contract is.
+	diff := core.EmptyStateDiff()
+	diff.DeployedContracts[*address] = p2p2core.AdaptHash(res.States[0].ClassHash)
+	require.NoError(t, s.blockchain.ApplySnapshot(2, diff, nil))
+
+	t.Run("storage root doesn't match the contract range", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(ctx, 3*snapRetryDelay)
+		t.Cleanup(cancel)
+		err := s.snapStorage(ctx, new(snapPivots), address, new(felt.Felt).SetUint64(1), 2)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("storage root matches the contract range", func(t *testing.T) {
+		require.NoError(t, s.snapStorage(ctx, new(snapPivots), address, provenRoot, 2))
+	})
+
+	t.Run("storage served at another pivot", func(t *testing.T) {
+		// The storage root is only checked with the whole state.
+		require.NoError(t, s.snapStorage(ctx, new(snapPivots), address, new(felt.Felt).SetUint64(1), 1))
+	})
+}
+
+func TestSnapReplayBlock(t *testing.T) {
+	chain := newSnapTestChain(t, 2)
+	s := newTestService(t, chain)
+
+	t.Run("state diff matches the header", func(t *testing.T) {
+		classHashes := make(map[felt.Felt]struct{})
+		require.NoError(t, s.snapReplayBlock(context.Background(), 2, classHashes, make(map[felt.Felt]struct{})))
+		assert.NotEmpty(t, classHashes)
+	})
+
+	t.Run("state diff doesn't match the header", func(t *testing.T) {
+		header, err := chain.BlockHeaderByNumber(2)
+		require.NoError(t, err)
+		commitments, err := chain.BlockCommitmentsByNumber(2)
+		require.NoError(t, err)
+		stateUpdate, err := chain.StateUpdateByNumber(2)
+		require.NoError(t, err)
+		diff := stateUpdate.StateDiff
+		specHeader := core2p2p.AdaptHeader(header, commitments, diff.Hash(), diff.Length())
+		require.NoError(t, checkStateDiff(specHeader, diff))
+
+		diff.Nonces[*new(felt.Felt).SetUint64(1)] = new(felt.Felt).SetUint64(1)
+		assert.ErrorContains(t, checkStateDiff(specHeader, diff), "doesn't match the header")
+	})
+}
+
+func TestSnapStorePivot(t *testing.T) {
+	chain := newSnapTestChain(t, 2)
+	s := newTestService(t, chain)
+	ctx := context.Background()
+
+	res, err := firstResponse(s.client.RequestContractRange(ctx, &spec.ContractRangeRequest{Limit: 1}))
+	require.NoError(t, err)
+
+	// None of the state was downloaded.
+	require.ErrorIs(t, s.snapStorePivot(ctx, res.Head), errSnapStateMismatch)
+	_, err = s.blockchain.Height()
+	assert.Error(t, err)
+}
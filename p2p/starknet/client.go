@@ -123,3 +123,31 @@ func (c *Client) RequestTransactions(ctx context.Context, req *spec.Transactions
 	return requestAndReceiveStream[*spec.TransactionsRequest, *spec.TransactionsResponse](
 		ctx, c.newStream, TransactionsPID(), req, c.log)
 }
+
+func (c *Client) RequestContractRange(ctx context.Context, req *spec.ContractRangeRequest) (iter.Seq[*spec.ContractRangeResponse], error) {
+	return requestAndReceiveStream[*spec.ContractRangeRequest, *spec.ContractRangeResponse](
+		ctx, c.newStream, ContractRangePID(), req, c.log)
+}
+
+func (c *Client) RequestClassRange(ctx context.Context, req *spec.ClassRangeRequest) (iter.Seq[*spec.ClassRangeResponse], error) {
+	return requestAndReceiveStream[*spec.ClassRangeRequest, *spec.ClassRangeResponse](ctx, c.newStream, ClassRangePID(), req, c.log)
+}
+
+func (c *Client) RequestContractStorage(ctx context.Context, req *spec.ContractStorageRequest) (
+	iter.Seq[*spec.ContractStorageResponse], error,
+) {
+	return requestAndReceiveStream[*spec.ContractStorageRequest, *spec.ContractStorageResponse](
+		ctx, c.newStream, ContractStoragePID(), req, c.log)
+}
+
+func (c *Client) RequestClassesByHash(ctx context.Context, req *spec.ClassesByHashRequest) (iter.Seq[*spec.ClassesResponse], error) {
+	return requestAndReceiveStream[*spec.ClassesByHashRequest, *spec.ClassesResponse](
+		ctx, c.newStream, ClassesByHashPID(), req, c.log)
+}
+
+func (c *Client) RequestDeclaredClassHashes(ctx context.Context, req *spec.DeclaredClassHashesRequest) (
+	iter.Seq[*spec.DeclaredClassHashesResponse], error,
+) {
+	return requestAndReceiveStream[*spec.DeclaredClassHashesRequest, *spec.DeclaredClassHashesResponse](
+		ctx, c.newStream, DeclaredClassHashesPID(), req, c.log)
+}
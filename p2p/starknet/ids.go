@@ -1,6 +1,7 @@
 package starknet
 
 import (
+	"github.com/NethermindEth/juno/utils"
 	"github.com/libp2p/go-libp2p/core/protocol"
 )
 
@@ -25,3 +26,33 @@ func ClassesPID() protocol.ID {
 func StateDiffPID() protocol.ID {
 	return Prefix + "/state_diffs/0.1.0-rc.0"
 }
+
+func ContractRangePID() protocol.ID {
+	return Prefix + "/snapshot/contract_range/0.1.0-rc.0"
+}
+
+func ClassRangePID() protocol.ID {
+	return Prefix + "/snapshot/class_range/0.1.0-rc.0"
+}
+
+func ContractStoragePID() protocol.ID {
+	return Prefix + "/snapshot/contract_storage/0.1.0-rc.0"
+}
+
+func ClassesByHashPID() protocol.ID {
+	return Prefix + "/snapshot/classes/0.1.0-rc.0"
+}
+
+func DeclaredClassHashesPID() protocol.ID {
+	return Prefix + "/snapshot/declared_class_hashes/0.1.0-rc.0"
+}
+
+// NewBlocksTopic is the pubsub topic new blocks of network are announced on.
+func NewBlocksTopic(network *utils.Network) string {
+	return Prefix + "/" + network.L2ChainID + "/new_blocks/0.1.0-rc.0"
+}
+
+// TransactionsTopic is the pubsub topic transactions of network are propagated on.
+func TransactionsTopic(network *utils.Network) string {
+	return Prefix + "/" + network.L2ChainID + "/transactions/0.1.0-rc.0"
+}
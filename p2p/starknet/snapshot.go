@@ -0,0 +1,397 @@
+package starknet
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+
+	"github.com/NethermindEth/juno/adapters/core2p2p"
+	"github.com/NethermindEth/juno/adapters/p2p2core"
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/core/trie"
+	"github.com/NethermindEth/juno/p2p/starknet/spec"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/libp2p/go-libp2p/core/network"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// MaxRangeLimit is the largest number of trie leaves served per range request.
+	MaxRangeLimit = 1024
+	// MaxClassesByHash is the largest number of classes served per classes by hash request.
+	MaxClassesByHash = 128
+)
+
+// snapshotState is the head state ranges are served from.
+type snapshotState interface {
+	core.StateReader
+	core.TrieReader
+	DeclaredClassHashes(start *felt.Felt, limit int) ([]*felt.Felt, bool, error)
+}
+
+func (h *Handler) ContractRangeHandler(stream network.Stream) {
+	streamHandler[*spec.ContractRangeRequest](h.ctx, &h.wg, stream, h.onContractRangeRequest, h.log)
+}
+
+func (h *Handler) ClassRangeHandler(stream network.Stream) {
+	streamHandler[*spec.ClassRangeRequest](h.ctx, &h.wg, stream, h.onClassRangeRequest, h.log)
+}
+
+func (h *Handler) ContractStorageHandler(stream network.Stream) {
+	streamHandler[*spec.ContractStorageRequest](h.ctx, &h.wg, stream, h.onContractStorageRequest, h.log)
+}
+
+func (h *Handler) ClassesByHashHandler(stream network.Stream) {
+	streamHandler[*spec.ClassesByHashRequest](h.ctx, &h.wg, stream, h.onClassesByHashRequest, h.log)
+}
+
+func (h *Handler) DeclaredClassHashesHandler(stream network.Stream) {
+	streamHandler[*spec.DeclaredClassHashesRequest](h.ctx, &h.wg, stream, h.onDeclaredClassHashesRequest, h.log)
+}
+
+// headSnapshot returns the head state along with the block it belongs to.
+func (h *Handler) headSnapshot() (snapshotState, *spec.SnapshotHead, func(), error) {
+	stateReader, closer, err := h.bcReader.HeadState()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	closeState := func() {
+		if closeErr := closer(); closeErr != nil {
+			h.log.Errorw("Failed to close state reader", "err", closeErr)
+		}
+	}
+
+	state, ok := stateReader.(snapshotState)
+	if !ok {
+		closeState()
+		return nil, nil, nil, fmt.Errorf("state snapshots are not supported by %T", stateReader)
+	}
+
+	// The head is read after the state, so it may be a later block if one was stored in between.
+	header, err := h.bcReader.HeadsHeader()
+	if err != nil {
+		closeState()
+		return nil, nil, nil, err
+	}
+	var roots [2]*felt.Felt
+	for i, trieFunc := range []func() (*trie.Trie, error){state.ContractTrie, state.ClassTrie} {
+		tr, err := trieFunc()
+		if err == nil {
+			roots[i], err = tr.Root()
+		}
+		if err != nil {
+			closeState()
+			return nil, nil, nil, err
+		}
+	}
+	if !core.StateCommitment(roots[0], roots[1]).Equal(header.GlobalStateRoot) {
+		closeState()
+		return nil, nil, nil, errors.New("head moved while reading its state")
+	}
+
+	return state, &spec.SnapshotHead{
+		Block:         core2p2p.AdaptBlockID(header),
+		StateRoot:     core2p2p.AdaptHash(header.GlobalStateRoot),
+		ContractsRoot: core2p2p.AdaptHash(roots[0]),
+		ClassesRoot:   core2p2p.AdaptHash(roots[1]),
+	}, closeState, nil
+}
+
+// rangeLeaves returns up to limit leaves of tr whose keys are at least start, and the key of the next leaf if there
+// are more.
+func rangeLeaves(tr *trie.Trie, start *felt.Felt, limit uint32) (keys, values []*felt.Felt, next *felt.Felt, err error) {
+	if limit == 0 || limit > MaxRangeLimit {
+		limit = MaxRangeLimit
+	}
+
+	_, err = tr.IterateLeaves(start, func(key, value *felt.Felt) (bool, error) {
+		if len(keys) == int(limit) {
+			next = key
+			return false, nil
+		}
+		keys = append(keys, key)
+		values = append(values, value)
+		return true, nil
+	})
+	return keys, values, next, err
+}
+
+// rangeProof returns the boundaries of the range of tr that starts at start and, if there are more leaves, ends
+// before next.
+func rangeProof(tr *trie.Trie, start, next *felt.Felt) (*spec.PatriciaRangeProof, error) {
+	var boundaries [2]*felt.Felt
+	if !start.IsZero() {
+		boundaries[0] = new(felt.Felt).Sub(start, new(felt.Felt).SetUint64(1))
+	}
+	boundaries[1] = next
+
+	var (
+		keys   [2]*trie.Key
+		proofs [2][]trie.ProofNode
+		err    error
+	)
+	for i, boundary := range boundaries {
+		if boundary != nil {
+			key := tr.FeltToKey(boundary)
+			keys[i] = &key
+		}
+	}
+	switch {
+	case keys[0] != nil && keys[1] != nil:
+		proofs, err = trie.GetBoundaryProofs(keys[0], keys[1], tr)
+	case keys[0] != nil:
+		proofs[0], err = trie.GetProof(keys[0], tr)
+	case keys[1] != nil:
+		proofs[1], err = trie.GetProof(keys[1], tr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var adapted [2]*spec.PatriciaBoundary
+	for i, boundary := range boundaries {
+		if boundary == nil {
+			continue
+		}
+		value, err := tr.Get(boundary)
+		if err != nil {
+			return nil, err
+		}
+		adapted[i] = &spec.PatriciaBoundary{
+			Key:   core2p2p.AdaptFelt(boundary),
+			Proof: core2p2p.AdaptProof(proofs[i]),
+		}
+		if !value.IsZero() {
+			adapted[i].Value = core2p2p.AdaptFelt(value)
+		}
+	}
+	return &spec.PatriciaRangeProof{Left: adapted[0], Right: adapted[1]}, nil
+}
+
+func singleResponse(msg proto.Message) iter.Seq[proto.Message] {
+	return func(yield func(proto.Message) bool) {
+		yield(msg)
+	}
+}
+
+func (h *Handler) onContractRangeRequest(req *spec.ContractRangeRequest) (iter.Seq[proto.Message], error) {
+	state, head, closer, err := h.headSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	defer closer()
+
+	contracts, err := state.ContractTrie()
+	if err != nil {
+		return nil, err
+	}
+	start := p2p2core.AdaptAddress(req.Start)
+	if start == nil {
+		start = &felt.Zero
+	}
+	addresses, _, next, err := rangeLeaves(contracts, start, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	states := make([]*spec.ContractState, 0, len(addresses))
+	for _, addr := range addresses {
+		classHash, err := state.ContractClassHash(addr)
+		if err != nil {
+			return nil, err
+		}
+		nonce, err := state.ContractNonce(addr)
+		if err != nil {
+			return nil, err
+		}
+		storage, err := state.ContractStorageTrie(addr)
+		if err != nil {
+			return nil, err
+		}
+		storageRoot, err := storage.Root()
+		if err != nil {
+			return nil, err
+		}
+
+		states = append(states, &spec.ContractState{
+			Address:     core2p2p.AdaptAddress(addr),
+			ClassHash:   core2p2p.AdaptHash(classHash),
+			StorageRoot: core2p2p.AdaptHash(storageRoot),
+			Nonce:       core2p2p.AdaptFelt(nonce),
+		})
+	}
+
+	proof, err := rangeProof(contracts, start, next)
+	if err != nil {
+		return nil, err
+	}
+	return singleResponse(&spec.ContractRangeResponse{
+		Head:   head,
+		States: states,
+		Proof:  proof,
+		More:   next != nil,
+	}), nil
+}
+
+func (h *Handler) onClassRangeRequest(req *spec.ClassRangeRequest) (iter.Seq[proto.Message], error) {
+	state, head, closer, err := h.headSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	defer closer()
+
+	classTrie, err := state.ClassTrie()
+	if err != nil {
+		return nil, err
+	}
+	start := p2p2core.AdaptHash(req.Start)
+	if start == nil {
+		start = &felt.Zero
+	}
+	classHashes, _, next, err := rangeLeaves(classTrie, start, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	classes := make([]*spec.DeclaredClass, 0, len(classHashes))
+	for _, classHash := range classHashes {
+		declared, err := state.Class(classHash)
+		if err != nil {
+			return nil, err
+		}
+		cairo1, ok := declared.Class.(*core.Cairo1Class)
+		if !ok || cairo1.Compiled == nil {
+			return nil, fmt.Errorf("class %s in the classes trie is not a compiled Cairo 1 class", classHash)
+		}
+
+		classes = append(classes, &spec.DeclaredClass{
+			ClassHash:         core2p2p.AdaptHash(classHash),
+			CompiledClassHash: core2p2p.AdaptHash(cairo1.Compiled.Hash()),
+		})
+	}
+
+	proof, err := rangeProof(classTrie, start, next)
+	if err != nil {
+		return nil, err
+	}
+	return singleResponse(&spec.ClassRangeResponse{
+		Head:    head,
+		Classes: classes,
+		Proof:   proof,
+		More:    next != nil,
+	}), nil
+}
+
+func (h *Handler) onContractStorageRequest(req *spec.ContractStorageRequest) (iter.Seq[proto.Message], error) {
+	state, head, closer, err := h.headSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	defer closer()
+
+	addr := p2p2core.AdaptAddress(req.Address)
+	if addr == nil {
+		return nil, errors.New("missing contract address")
+	}
+	storage, err := state.ContractStorageTrie(addr)
+	if err != nil {
+		return nil, err
+	}
+	storageRoot, err := storage.Root()
+	if err != nil {
+		return nil, err
+	}
+	start := p2p2core.AdaptFelt(req.Start)
+	if start == nil {
+		start = &felt.Zero
+	}
+	keys, values, next, err := rangeLeaves(storage, start, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	storedValues := make([]*spec.ContractStoredValue, 0, len(keys))
+	for i := range keys {
+		storedValues = append(storedValues, &spec.ContractStoredValue{
+			Key:   core2p2p.AdaptFelt(keys[i]),
+			Value: core2p2p.AdaptFelt(values[i]),
+		})
+	}
+
+	proof, err := rangeProof(storage, start, next)
+	if err != nil {
+		return nil, err
+	}
+	return singleResponse(&spec.ContractStorageResponse{
+		Head:        head,
+		StorageRoot: core2p2p.AdaptHash(storageRoot),
+		Values:      storedValues,
+		Proof:       proof,
+		More:        next != nil,
+	}), nil
+}
+
+func (h *Handler) onClassesByHashRequest(req *spec.ClassesByHashRequest) (iter.Seq[proto.Message], error) {
+	if len(req.ClassHashes) > MaxClassesByHash {
+		return nil, fmt.Errorf("requested %d classes, at most %d are served", len(req.ClassHashes), MaxClassesByHash)
+	}
+
+	stateReader, closer, err := h.bcReader.HeadState()
+	if err != nil {
+		return nil, err
+	}
+
+	return func(yield func(proto.Message) bool) {
+		defer func() {
+			if closeErr := closer(); closeErr != nil {
+				h.log.Errorw("Failed to close state reader", "err", closeErr)
+			}
+		}()
+
+		for _, hash := range req.ClassHashes {
+			classHash := p2p2core.AdaptHash(hash)
+			if classHash == nil {
+				break
+			}
+			// Fin is sent early if a class isn't known.
+			declared, err := stateReader.Class(classHash)
+			if err != nil {
+				break
+			}
+
+			if !yield(&spec.ClassesResponse{
+				ClassMessage: &spec.ClassesResponse_Class{Class: core2p2p.AdaptClass(declared.Class)},
+			}) {
+				return
+			}
+		}
+
+		yield(&spec.ClassesResponse{ClassMessage: &spec.ClassesResponse_Fin{}})
+	}, nil
+}
+
+func (h *Handler) onDeclaredClassHashesRequest(req *spec.DeclaredClassHashesRequest) (iter.Seq[proto.Message], error) {
+	state, _, closer, err := h.headSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	defer closer()
+
+	start := p2p2core.AdaptHash(req.Start)
+	if start == nil {
+		start = &felt.Zero
+	}
+	limit := req.Limit
+	if limit == 0 || limit > MaxRangeLimit {
+		limit = MaxRangeLimit
+	}
+	classHashes, more, err := state.DeclaredClassHashes(start, int(limit))
+	if err != nil {
+		return nil, err
+	}
+	return singleResponse(&spec.DeclaredClassHashesResponse{
+		ClassHashes: utils.Map(classHashes, core2p2p.AdaptHash),
+		More:        more,
+	}), nil
+}
@@ -0,0 +1,150 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        v5.27.1
+// source: p2p/proto/gossip.proto
+
+package spec
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// A transaction propagated to peers, with the class it declares, if any.
+type GossipedTransaction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Transaction *Transaction `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	Class       *Class       `protobuf:"bytes,2,opt,name=class,proto3" json:"class,omitempty"`
+}
+
+func (x *GossipedTransaction) Reset() {
+	*x = GossipedTransaction{}
+	mi := &file_p2p_proto_gossip_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GossipedTransaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GossipedTransaction) ProtoMessage() {}
+
+func (x *GossipedTransaction) ProtoReflect() protoreflect.Message {
+	mi := &file_p2p_proto_gossip_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GossipedTransaction.ProtoReflect.Descriptor instead.
+func (*GossipedTransaction) Descriptor() ([]byte, []int) {
+	return file_p2p_proto_gossip_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GossipedTransaction) GetTransaction() *Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *GossipedTransaction) GetClass() *Class {
+	if x != nil {
+		return x.Class
+	}
+	return nil
+}
+
+var File_p2p_proto_gossip_proto protoreflect.FileDescriptor
+
+var file_p2p_proto_gossip_proto_rawDesc = []byte{
+	0x0a, 0x16, 0x70, 0x32, 0x70, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x6f, 0x73, 0x73,
+	0x69, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x15, 0x70, 0x32, 0x70, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x1b, 0x70, 0x32, 0x70, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x63, 0x0a, 0x13,
+	0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x05, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x06, 0x2e, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x52, 0x05, 0x63, 0x6c, 0x61, 0x73,
+	0x73, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x4e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x64, 0x45, 0x74, 0x68, 0x2f, 0x6a, 0x75,
+	0x6e, 0x6f, 0x2f, 0x70, 0x32, 0x70, 0x2f, 0x73, 0x74, 0x61, 0x72, 0x6b, 0x6e, 0x65, 0x74, 0x2f,
+	0x73, 0x70, 0x65, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_p2p_proto_gossip_proto_rawDescOnce sync.Once
+	file_p2p_proto_gossip_proto_rawDescData = file_p2p_proto_gossip_proto_rawDesc
+)
+
+func file_p2p_proto_gossip_proto_rawDescGZIP() []byte {
+	file_p2p_proto_gossip_proto_rawDescOnce.Do(func() {
+		file_p2p_proto_gossip_proto_rawDescData = protoimpl.X.CompressGZIP(file_p2p_proto_gossip_proto_rawDescData)
+	})
+	return file_p2p_proto_gossip_proto_rawDescData
+}
+
+var file_p2p_proto_gossip_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_p2p_proto_gossip_proto_goTypes = []any{
+	(*GossipedTransaction)(nil), // 0: GossipedTransaction
+	(*Transaction)(nil),         // 1: Transaction
+	(*Class)(nil),               // 2: Class
+}
+var file_p2p_proto_gossip_proto_depIdxs = []int32{
+	1, // 0: GossipedTransaction.transaction:type_name -> Transaction
+	2, // 1: GossipedTransaction.class:type_name -> Class
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_p2p_proto_gossip_proto_init() }
+func file_p2p_proto_gossip_proto_init() {
+	if File_p2p_proto_gossip_proto != nil {
+		return
+	}
+	file_p2p_proto_class_proto_init()
+	file_p2p_proto_transaction_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_p2p_proto_gossip_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_p2p_proto_gossip_proto_goTypes,
+		DependencyIndexes: file_p2p_proto_gossip_proto_depIdxs,
+		MessageInfos:      file_p2p_proto_gossip_proto_msgTypes,
+	}.Build()
+	File_p2p_proto_gossip_proto = out.File
+	file_p2p_proto_gossip_proto_rawDesc = nil
+	file_p2p_proto_gossip_proto_goTypes = nil
+	file_p2p_proto_gossip_proto_depIdxs = nil
+}
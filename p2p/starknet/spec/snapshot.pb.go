@@ -0,0 +1,1304 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        v5.27.1
+// source: p2p/proto/snapshot.proto
+
+package spec
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PatriciaNode struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Node:
+	//	*PatriciaNode_Edge_
+	//	*PatriciaNode_Binary_
+	Node isPatriciaNode_Node `protobuf_oneof:"node"`
+}
+
+func (x *PatriciaNode) Reset() {
+	*x = PatriciaNode{}
+	mi := &file_p2p_proto_snapshot_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PatriciaNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatriciaNode) ProtoMessage() {}
+
+func (x *PatriciaNode) ProtoReflect() protoreflect.Message {
+	mi := &file_p2p_proto_snapshot_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PatriciaNode.ProtoReflect.Descriptor instead.
+func (*PatriciaNode) Descriptor() ([]byte, []int) {
+	return file_p2p_proto_snapshot_proto_rawDescGZIP(), []int{0}
+}
+
+func (m *PatriciaNode) GetNode() isPatriciaNode_Node {
+	if m != nil {
+		return m.Node
+	}
+	return nil
+}
+
+func (x *PatriciaNode) GetEdge() *PatriciaNode_Edge {
+	if x, ok := x.GetNode().(*PatriciaNode_Edge_); ok {
+		return x.Edge
+	}
+	return nil
+}
+
+func (x *PatriciaNode) GetBinary() *PatriciaNode_Binary {
+	if x, ok := x.GetNode().(*PatriciaNode_Binary_); ok {
+		return x.Binary
+	}
+	return nil
+}
+
+type isPatriciaNode_Node interface {
+	isPatriciaNode_Node()
+}
+
+type PatriciaNode_Edge_ struct {
+	Edge *PatriciaNode_Edge `protobuf:"bytes,1,opt,name=edge,proto3,oneof"`
+}
+
+type PatriciaNode_Binary_ struct {
+	Binary *PatriciaNode_Binary `protobuf:"bytes,2,opt,name=binary,proto3,oneof"`
+}
+
+func (*PatriciaNode_Edge_) isPatriciaNode_Node() {}
+
+func (*PatriciaNode_Binary_) isPatriciaNode_Node() {}
+
+// The nodes on the path from the root to a key, excluding the leaf.
+type PatriciaProof struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Nodes []*PatriciaNode `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+}
+
+func (x *PatriciaProof) Reset() {
+	*x = PatriciaProof{}
+	mi := &file_p2p_proto_snapshot_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PatriciaProof) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatriciaProof) ProtoMessage() {}
+
+func (x *PatriciaProof) ProtoReflect() protoreflect.Message {
+	mi := &file_p2p_proto_snapshot_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PatriciaProof.ProtoReflect.Descriptor instead.
+func (*PatriciaProof) Descriptor() ([]byte, []int) {
+	return file_p2p_proto_snapshot_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PatriciaProof) GetNodes() []*PatriciaNode {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+// A key bounding a range, with the proof of its value.
+type PatriciaBoundary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key   *Felt252       `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value *Felt252       `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"` // Missing if the key isn't set.
+	Proof *PatriciaProof `protobuf:"bytes,3,opt,name=proof,proto3" json:"proof,omitempty"`
+}
+
+func (x *PatriciaBoundary) Reset() {
+	*x = PatriciaBoundary{}
+	mi := &file_p2p_proto_snapshot_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PatriciaBoundary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatriciaBoundary) ProtoMessage() {}
+
+func (x *PatriciaBoundary) ProtoReflect() protoreflect.Message {
+	mi := &file_p2p_proto_snapshot_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PatriciaBoundary.ProtoReflect.Descriptor instead.
+func (*PatriciaBoundary) Descriptor() ([]byte, []int) {
+	return file_p2p_proto_snapshot_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PatriciaBoundary) GetKey() *Felt252 {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *PatriciaBoundary) GetValue() *Felt252 {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *PatriciaBoundary) GetProof() *PatriciaProof {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+// The boundaries of a range: the key before the requested start, and the first leaf after the range. The left boundary
+// is missing if the range starts at zero, and the right boundary if there are no leaves after the range.
+type PatriciaRangeProof struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Left  *PatriciaBoundary `protobuf:"bytes,1,opt,name=left,proto3" json:"left,omitempty"`
+	Right *PatriciaBoundary `protobuf:"bytes,2,opt,name=right,proto3" json:"right,omitempty"`
+}
+
+func (x *PatriciaRangeProof) Reset() {
+	*x = PatriciaRangeProof{}
+	mi := &file_p2p_proto_snapshot_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PatriciaRangeProof) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatriciaRangeProof) ProtoMessage() {}
+
+func (x *PatriciaRangeProof) ProtoReflect() protoreflect.Message {
+	mi := &file_p2p_proto_snapshot_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PatriciaRangeProof.ProtoReflect.Descriptor instead.
+func (*PatriciaRangeProof) Descriptor() ([]byte, []int) {
+	return file_p2p_proto_snapshot_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PatriciaRangeProof) GetLeft() *PatriciaBoundary {
+	if x != nil {
+		return x.Left
+	}
+	return nil
+}
+
+func (x *PatriciaRangeProof) GetRight() *PatriciaBoundary {
+	if x != nil {
+		return x.Right
+	}
+	return nil
+}
+
+// The block whose state a range was served at, with the roots of the tries its state root commits to.
+type SnapshotHead struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Block         *BlockID `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
+	StateRoot     *Hash    `protobuf:"bytes,2,opt,name=state_root,json=stateRoot,proto3" json:"state_root,omitempty"`
+	ContractsRoot *Hash    `protobuf:"bytes,3,opt,name=contracts_root,json=contractsRoot,proto3" json:"contracts_root,omitempty"`
+	ClassesRoot   *Hash    `protobuf:"bytes,4,opt,name=classes_root,json=classesRoot,proto3" json:"classes_root,omitempty"`
+}
+
+func (x *SnapshotHead) Reset() {
+	*x = SnapshotHead{}
+	mi := &file_p2p_proto_snapshot_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SnapshotHead) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotHead) ProtoMessage() {}
+
+func (x *SnapshotHead) ProtoReflect() protoreflect.Message {
+	mi := &file_p2p_proto_snapshot_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotHead.ProtoReflect.Descriptor instead.
+func (*SnapshotHead) Descriptor() ([]byte, []int) {
+	return file_p2p_proto_snapshot_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SnapshotHead) GetBlock() *BlockID {
+	if x != nil {
+		return x.Block
+	}
+	return nil
+}
+
+func (x *SnapshotHead) GetStateRoot() *Hash {
+	if x != nil {
+		return x.StateRoot
+	}
+	return nil
+}
+
+func (x *SnapshotHead) GetContractsRoot() *Hash {
+	if x != nil {
+		return x.ContractsRoot
+	}
+	return nil
+}
+
+func (x *SnapshotHead) GetClassesRoot() *Hash {
+	if x != nil {
+		return x.ClassesRoot
+	}
+	return nil
+}
+
+// A leaf of the contracts trie.
+type ContractState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address     *Address `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	ClassHash   *Hash    `protobuf:"bytes,2,opt,name=class_hash,json=classHash,proto3" json:"class_hash,omitempty"`
+	StorageRoot *Hash    `protobuf:"bytes,3,opt,name=storage_root,json=storageRoot,proto3" json:"storage_root,omitempty"`
+	Nonce       *Felt252 `protobuf:"bytes,4,opt,name=nonce,proto3" json:"nonce,omitempty"`
+}
+
+func (x *ContractState) Reset() {
+	*x = ContractState{}
+	mi := &file_p2p_proto_snapshot_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContractState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContractState) ProtoMessage() {}
+
+func (x *ContractState) ProtoReflect() protoreflect.Message {
+	mi := &file_p2p_proto_snapshot_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContractState.ProtoReflect.Descriptor instead.
+func (*ContractState) Descriptor() ([]byte, []int) {
+	return file_p2p_proto_snapshot_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ContractState) GetAddress() *Address {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *ContractState) GetClassHash() *Hash {
+	if x != nil {
+		return x.ClassHash
+	}
+	return nil
+}
+
+func (x *ContractState) GetStorageRoot() *Hash {
+	if x != nil {
+		return x.StorageRoot
+	}
+	return nil
+}
+
+func (x *ContractState) GetNonce() *Felt252 {
+	if x != nil {
+		return x.Nonce
+	}
+	return nil
+}
+
+type ContractRangeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Start *Address `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	Limit uint32   `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *ContractRangeRequest) Reset() {
+	*x = ContractRangeRequest{}
+	mi := &file_p2p_proto_snapshot_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContractRangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContractRangeRequest) ProtoMessage() {}
+
+func (x *ContractRangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_p2p_proto_snapshot_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContractRangeRequest.ProtoReflect.Descriptor instead.
+func (*ContractRangeRequest) Descriptor() ([]byte, []int) {
+	return file_p2p_proto_snapshot_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ContractRangeRequest) GetStart() *Address {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *ContractRangeRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// The contracts with the lowest addresses that are at least the requested start.
+type ContractRangeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Head   *SnapshotHead       `protobuf:"bytes,1,opt,name=head,proto3" json:"head,omitempty"`
+	States []*ContractState    `protobuf:"bytes,2,rep,name=states,proto3" json:"states,omitempty"`
+	Proof  *PatriciaRangeProof `protobuf:"bytes,3,opt,name=proof,proto3" json:"proof,omitempty"`
+	More   bool                `protobuf:"varint,4,opt,name=more,proto3" json:"more,omitempty"` // Whether the trie has leaves after the range.
+}
+
+func (x *ContractRangeResponse) Reset() {
+	*x = ContractRangeResponse{}
+	mi := &file_p2p_proto_snapshot_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContractRangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContractRangeResponse) ProtoMessage() {}
+
+func (x *ContractRangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_p2p_proto_snapshot_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContractRangeResponse.ProtoReflect.Descriptor instead.
+func (*ContractRangeResponse) Descriptor() ([]byte, []int) {
+	return file_p2p_proto_snapshot_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ContractRangeResponse) GetHead() *SnapshotHead {
+	if x != nil {
+		return x.Head
+	}
+	return nil
+}
+
+func (x *ContractRangeResponse) GetStates() []*ContractState {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+func (x *ContractRangeResponse) GetProof() *PatriciaRangeProof {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+func (x *ContractRangeResponse) GetMore() bool {
+	if x != nil {
+		return x.More
+	}
+	return false
+}
+
+type ClassRangeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Start *Hash  `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	Limit uint32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *ClassRangeRequest) Reset() {
+	*x = ClassRangeRequest{}
+	mi := &file_p2p_proto_snapshot_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClassRangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClassRangeRequest) ProtoMessage() {}
+
+func (x *ClassRangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_p2p_proto_snapshot_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClassRangeRequest.ProtoReflect.Descriptor instead.
+func (*ClassRangeRequest) Descriptor() ([]byte, []int) {
+	return file_p2p_proto_snapshot_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ClassRangeRequest) GetStart() *Hash {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *ClassRangeRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// The Cairo 1 classes with the lowest hashes that are at least the requested start.
+type ClassRangeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Head    *SnapshotHead       `protobuf:"bytes,1,opt,name=head,proto3" json:"head,omitempty"`
+	Classes []*DeclaredClass    `protobuf:"bytes,2,rep,name=classes,proto3" json:"classes,omitempty"`
+	Proof   *PatriciaRangeProof `protobuf:"bytes,3,opt,name=proof,proto3" json:"proof,omitempty"`
+	More    bool                `protobuf:"varint,4,opt,name=more,proto3" json:"more,omitempty"` // Whether the trie has leaves after the range.
+}
+
+func (x *ClassRangeResponse) Reset() {
+	*x = ClassRangeResponse{}
+	mi := &file_p2p_proto_snapshot_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClassRangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClassRangeResponse) ProtoMessage() {}
+
+func (x *ClassRangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_p2p_proto_snapshot_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClassRangeResponse.ProtoReflect.Descriptor instead.
+func (*ClassRangeResponse) Descriptor() ([]byte, []int) {
+	return file_p2p_proto_snapshot_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ClassRangeResponse) GetHead() *SnapshotHead {
+	if x != nil {
+		return x.Head
+	}
+	return nil
+}
+
+func (x *ClassRangeResponse) GetClasses() []*DeclaredClass {
+	if x != nil {
+		return x.Classes
+	}
+	return nil
+}
+
+func (x *ClassRangeResponse) GetProof() *PatriciaRangeProof {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+func (x *ClassRangeResponse) GetMore() bool {
+	if x != nil {
+		return x.More
+	}
+	return false
+}
+
+type ContractStorageRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address *Address `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Start   *Felt252 `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	Limit   uint32   `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *ContractStorageRequest) Reset() {
+	*x = ContractStorageRequest{}
+	mi := &file_p2p_proto_snapshot_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContractStorageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContractStorageRequest) ProtoMessage() {}
+
+func (x *ContractStorageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_p2p_proto_snapshot_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContractStorageRequest.ProtoReflect.Descriptor instead.
+func (*ContractStorageRequest) Descriptor() ([]byte, []int) {
+	return file_p2p_proto_snapshot_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ContractStorageRequest) GetAddress() *Address {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *ContractStorageRequest) GetStart() *Felt252 {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *ContractStorageRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// The storage values of a contract with the lowest keys that are at least the requested start.
+type ContractStorageResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Head        *SnapshotHead          `protobuf:"bytes,1,opt,name=head,proto3" json:"head,omitempty"`
+	StorageRoot *Hash                  `protobuf:"bytes,2,opt,name=storage_root,json=storageRoot,proto3" json:"storage_root,omitempty"`
+	Values      []*ContractStoredValue `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty"`
+	Proof       *PatriciaRangeProof    `protobuf:"bytes,4,opt,name=proof,proto3" json:"proof,omitempty"`
+	More        bool                   `protobuf:"varint,5,opt,name=more,proto3" json:"more,omitempty"` // Whether the trie has leaves after the range.
+}
+
+func (x *ContractStorageResponse) Reset() {
+	*x = ContractStorageResponse{}
+	mi := &file_p2p_proto_snapshot_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContractStorageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContractStorageResponse) ProtoMessage() {}
+
+func (x *ContractStorageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_p2p_proto_snapshot_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContractStorageResponse.ProtoReflect.Descriptor instead.
+func (*ContractStorageResponse) Descriptor() ([]byte, []int) {
+	return file_p2p_proto_snapshot_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ContractStorageResponse) GetHead() *SnapshotHead {
+	if x != nil {
+		return x.Head
+	}
+	return nil
+}
+
+func (x *ContractStorageResponse) GetStorageRoot() *Hash {
+	if x != nil {
+		return x.StorageRoot
+	}
+	return nil
+}
+
+func (x *ContractStorageResponse) GetValues() []*ContractStoredValue {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *ContractStorageResponse) GetProof() *PatriciaRangeProof {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+func (x *ContractStorageResponse) GetMore() bool {
+	if x != nil {
+		return x.More
+	}
+	return false
+}
+
+// Responded to with a ClassesResponse per class, followed by Fin.
+type ClassesByHashRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClassHashes []*Hash `protobuf:"bytes,1,rep,name=class_hashes,json=classHashes,proto3" json:"class_hashes,omitempty"`
+}
+
+func (x *ClassesByHashRequest) Reset() {
+	*x = ClassesByHashRequest{}
+	mi := &file_p2p_proto_snapshot_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClassesByHashRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClassesByHashRequest) ProtoMessage() {}
+
+func (x *ClassesByHashRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_p2p_proto_snapshot_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClassesByHashRequest.ProtoReflect.Descriptor instead.
+func (*ClassesByHashRequest) Descriptor() ([]byte, []int) {
+	return file_p2p_proto_snapshot_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ClassesByHashRequest) GetClassHashes() []*Hash {
+	if x != nil {
+		return x.ClassHashes
+	}
+	return nil
+}
+
+type DeclaredClassHashesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Start *Hash  `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	Limit uint32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *DeclaredClassHashesRequest) Reset() {
+	*x = DeclaredClassHashesRequest{}
+	mi := &file_p2p_proto_snapshot_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeclaredClassHashesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeclaredClassHashesRequest) ProtoMessage() {}
+
+func (x *DeclaredClassHashesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_p2p_proto_snapshot_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeclaredClassHashesRequest.ProtoReflect.Descriptor instead.
+func (*DeclaredClassHashesRequest) Descriptor() ([]byte, []int) {
+	return file_p2p_proto_snapshot_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DeclaredClassHashesRequest) GetStart() *Hash {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *DeclaredClassHashesRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// The lowest hashes of declared classes that are at least the requested start. Unlike Cairo 1 classes, Cairo 0 classes
+// aren't committed to by the state root, so the range isn't proven.
+type DeclaredClassHashesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClassHashes []*Hash `protobuf:"bytes,1,rep,name=class_hashes,json=classHashes,proto3" json:"class_hashes,omitempty"`
+	More        bool    `protobuf:"varint,2,opt,name=more,proto3" json:"more,omitempty"` // Whether there are more declared classes after the range.
+}
+
+func (x *DeclaredClassHashesResponse) Reset() {
+	*x = DeclaredClassHashesResponse{}
+	mi := &file_p2p_proto_snapshot_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeclaredClassHashesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeclaredClassHashesResponse) ProtoMessage() {}
+
+func (x *DeclaredClassHashesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_p2p_proto_snapshot_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeclaredClassHashesResponse.ProtoReflect.Descriptor instead.
+func (*DeclaredClassHashesResponse) Descriptor() ([]byte, []int) {
+	return file_p2p_proto_snapshot_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DeclaredClassHashesResponse) GetClassHashes() []*Hash {
+	if x != nil {
+		return x.ClassHashes
+	}
+	return nil
+}
+
+func (x *DeclaredClassHashesResponse) GetMore() bool {
+	if x != nil {
+		return x.More
+	}
+	return false
+}
+
+type PatriciaNode_Edge struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Length uint32   `protobuf:"varint,1,opt,name=length,proto3" json:"length,omitempty"`
+	Path   *Felt252 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Child  *Felt252 `protobuf:"bytes,3,opt,name=child,proto3" json:"child,omitempty"` // child hash
+}
+
+func (x *PatriciaNode_Edge) Reset() {
+	*x = PatriciaNode_Edge{}
+	mi := &file_p2p_proto_snapshot_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PatriciaNode_Edge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatriciaNode_Edge) ProtoMessage() {}
+
+func (x *PatriciaNode_Edge) ProtoReflect() protoreflect.Message {
+	mi := &file_p2p_proto_snapshot_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PatriciaNode_Edge.ProtoReflect.Descriptor instead.
+func (*PatriciaNode_Edge) Descriptor() ([]byte, []int) {
+	return file_p2p_proto_snapshot_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *PatriciaNode_Edge) GetLength() uint32 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *PatriciaNode_Edge) GetPath() *Felt252 {
+	if x != nil {
+		return x.Path
+	}
+	return nil
+}
+
+func (x *PatriciaNode_Edge) GetChild() *Felt252 {
+	if x != nil {
+		return x.Child
+	}
+	return nil
+}
+
+type PatriciaNode_Binary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Left  *Felt252 `protobuf:"bytes,1,opt,name=left,proto3" json:"left,omitempty"`
+	Right *Felt252 `protobuf:"bytes,2,opt,name=right,proto3" json:"right,omitempty"`
+}
+
+func (x *PatriciaNode_Binary) Reset() {
+	*x = PatriciaNode_Binary{}
+	mi := &file_p2p_proto_snapshot_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PatriciaNode_Binary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatriciaNode_Binary) ProtoMessage() {}
+
+func (x *PatriciaNode_Binary) ProtoReflect() protoreflect.Message {
+	mi := &file_p2p_proto_snapshot_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PatriciaNode_Binary.ProtoReflect.Descriptor instead.
+func (*PatriciaNode_Binary) Descriptor() ([]byte, []int) {
+	return file_p2p_proto_snapshot_proto_rawDescGZIP(), []int{0, 1}
+}
+
+func (x *PatriciaNode_Binary) GetLeft() *Felt252 {
+	if x != nil {
+		return x.Left
+	}
+	return nil
+}
+
+func (x *PatriciaNode_Binary) GetRight() *Felt252 {
+	if x != nil {
+		return x.Right
+	}
+	return nil
+}
+
+var File_p2p_proto_snapshot_proto protoreflect.FileDescriptor
+
+var file_p2p_proto_snapshot_proto_rawDesc = []byte{
+	0x0a, 0x18, 0x70, 0x32, 0x70, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x16, 0x70, 0x32, 0x70, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x1a, 0x15, 0x70, 0x32, 0x70, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x96, 0x02, 0x0a, 0x0c, 0x50, 0x61,
+	0x74, 0x72, 0x69, 0x63, 0x69, 0x61, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x28, 0x0a, 0x04, 0x65, 0x64,
+	0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x50, 0x61, 0x74, 0x72, 0x69,
+	0x63, 0x69, 0x61, 0x4e, 0x6f, 0x64, 0x65, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x48, 0x00, 0x52, 0x04,
+	0x65, 0x64, 0x67, 0x65, 0x12, 0x2e, 0x0a, 0x06, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x50, 0x61, 0x74, 0x72, 0x69, 0x63, 0x69, 0x61, 0x4e,
+	0x6f, 0x64, 0x65, 0x2e, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x48, 0x00, 0x52, 0x06, 0x62, 0x69,
+	0x6e, 0x61, 0x72, 0x79, 0x1a, 0x5c, 0x0a, 0x04, 0x45, 0x64, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6c, 0x65,
+	0x6e, 0x67, 0x74, 0x68, 0x12, 0x1c, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x08, 0x2e, 0x46, 0x65, 0x6c, 0x74, 0x32, 0x35, 0x32, 0x52, 0x04, 0x70, 0x61,
+	0x74, 0x68, 0x12, 0x1e, 0x0a, 0x05, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x08, 0x2e, 0x46, 0x65, 0x6c, 0x74, 0x32, 0x35, 0x32, 0x52, 0x05, 0x63, 0x68, 0x69,
+	0x6c, 0x64, 0x1a, 0x46, 0x0a, 0x06, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x12, 0x1c, 0x0a, 0x04,
+	0x6c, 0x65, 0x66, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x46, 0x65, 0x6c,
+	0x74, 0x32, 0x35, 0x32, 0x52, 0x04, 0x6c, 0x65, 0x66, 0x74, 0x12, 0x1e, 0x0a, 0x05, 0x72, 0x69,
+	0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x46, 0x65, 0x6c, 0x74,
+	0x32, 0x35, 0x32, 0x52, 0x05, 0x72, 0x69, 0x67, 0x68, 0x74, 0x42, 0x06, 0x0a, 0x04, 0x6e, 0x6f,
+	0x64, 0x65, 0x22, 0x34, 0x0a, 0x0d, 0x50, 0x61, 0x74, 0x72, 0x69, 0x63, 0x69, 0x61, 0x50, 0x72,
+	0x6f, 0x6f, 0x66, 0x12, 0x23, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x50, 0x61, 0x74, 0x72, 0x69, 0x63, 0x69, 0x61, 0x4e, 0x6f, 0x64,
+	0x65, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x22, 0x74, 0x0a, 0x10, 0x50, 0x61, 0x74, 0x72,
+	0x69, 0x63, 0x69, 0x61, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x61, 0x72, 0x79, 0x12, 0x1a, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x46, 0x65, 0x6c, 0x74,
+	0x32, 0x35, 0x32, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x1e, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x46, 0x65, 0x6c, 0x74, 0x32, 0x35,
+	0x32, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x24, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x6f,
+	0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x50, 0x61, 0x74, 0x72, 0x69, 0x63,
+	0x69, 0x61, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0x64,
+	0x0a, 0x12, 0x50, 0x61, 0x74, 0x72, 0x69, 0x63, 0x69, 0x61, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x50,
+	0x72, 0x6f, 0x6f, 0x66, 0x12, 0x25, 0x0a, 0x04, 0x6c, 0x65, 0x66, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x50, 0x61, 0x74, 0x72, 0x69, 0x63, 0x69, 0x61, 0x42, 0x6f, 0x75,
+	0x6e, 0x64, 0x61, 0x72, 0x79, 0x52, 0x04, 0x6c, 0x65, 0x66, 0x74, 0x12, 0x27, 0x0a, 0x05, 0x72,
+	0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x50, 0x61, 0x74,
+	0x72, 0x69, 0x63, 0x69, 0x61, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x61, 0x72, 0x79, 0x52, 0x05, 0x72,
+	0x69, 0x67, 0x68, 0x74, 0x22, 0xac, 0x01, 0x0a, 0x0c, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x48, 0x65, 0x61, 0x64, 0x12, 0x1e, 0x0a, 0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x52, 0x05,
+	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x24, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x72,
+	0x6f, 0x6f, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x05, 0x2e, 0x48, 0x61, 0x73, 0x68,
+	0x52, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x2c, 0x0a, 0x0e, 0x63,
+	0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x05, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x74,
+	0x72, 0x61, 0x63, 0x74, 0x73, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x28, 0x0a, 0x0c, 0x63, 0x6c, 0x61,
+	0x73, 0x73, 0x65, 0x73, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x05, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x52, 0x0b, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x65, 0x73, 0x52,
+	0x6f, 0x6f, 0x74, 0x22, 0xa3, 0x01, 0x0a, 0x0d, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x22, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x24, 0x0a, 0x0a, 0x63, 0x6c, 0x61,
+	0x73, 0x73, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x05, 0x2e,
+	0x48, 0x61, 0x73, 0x68, 0x52, 0x09, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x48, 0x61, 0x73, 0x68, 0x12,
+	0x28, 0x0a, 0x0c, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x05, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x52, 0x0b, 0x73, 0x74,
+	0x6f, 0x72, 0x61, 0x67, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x1e, 0x0a, 0x05, 0x6e, 0x6f, 0x6e,
+	0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x46, 0x65, 0x6c, 0x74, 0x32,
+	0x35, 0x32, 0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x22, 0x4c, 0x0a, 0x14, 0x43, 0x6f, 0x6e,
+	0x74, 0x72, 0x61, 0x63, 0x74, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1e, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x08, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0xa1, 0x01, 0x0a, 0x15, 0x43, 0x6f, 0x6e, 0x74,
+	0x72, 0x61, 0x63, 0x74, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x21, 0x0a, 0x04, 0x68, 0x65, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0d, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x48, 0x65, 0x61, 0x64, 0x52, 0x04,
+	0x68, 0x65, 0x61, 0x64, 0x12, 0x26, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x65, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x65, 0x73, 0x12, 0x29, 0x0a, 0x05,
+	0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x50, 0x61,
+	0x74, 0x72, 0x69, 0x63, 0x69, 0x61, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x50, 0x72, 0x6f, 0x6f, 0x66,
+	0x52, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x6f, 0x72, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x6d, 0x6f, 0x72, 0x65, 0x22, 0x46, 0x0a, 0x11, 0x43,
+	0x6c, 0x61, 0x73, 0x73, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1b, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x05, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x22, 0xa0, 0x01, 0x0a, 0x12, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x52, 0x61, 0x6e,
+	0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a, 0x04, 0x68, 0x65,
+	0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x48, 0x65, 0x61, 0x64, 0x52, 0x04, 0x68, 0x65, 0x61, 0x64, 0x12, 0x28, 0x0a,
+	0x07, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e,
+	0x2e, 0x44, 0x65, 0x63, 0x6c, 0x61, 0x72, 0x65, 0x64, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x52, 0x07,
+	0x63, 0x6c, 0x61, 0x73, 0x73, 0x65, 0x73, 0x12, 0x29, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x50, 0x61, 0x74, 0x72, 0x69, 0x63, 0x69,
+	0x61, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x05, 0x70, 0x72, 0x6f,
+	0x6f, 0x66, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x6f, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x04, 0x6d, 0x6f, 0x72, 0x65, 0x22, 0x72, 0x0a, 0x16, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61,
+	0x63, 0x74, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x22, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x08, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x07, 0x61, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x12, 0x1e, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x46, 0x65, 0x6c, 0x74, 0x32, 0x35, 0x32, 0x52, 0x05, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0xd3, 0x01, 0x0a, 0x17, 0x43,
+	0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a, 0x04, 0x68, 0x65, 0x61, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x48,
+	0x65, 0x61, 0x64, 0x52, 0x04, 0x68, 0x65, 0x61, 0x64, 0x12, 0x28, 0x0a, 0x0c, 0x73, 0x74, 0x6f,
+	0x72, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x05, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x52, 0x0b, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x52,
+	0x6f, 0x6f, 0x74, 0x12, 0x2c, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x53, 0x74,
+	0x6f, 0x72, 0x65, 0x64, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x73, 0x12, 0x29, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x13, 0x2e, 0x50, 0x61, 0x74, 0x72, 0x69, 0x63, 0x69, 0x61, 0x52, 0x61, 0x6e, 0x67, 0x65,
+	0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x12, 0x0a, 0x04,
+	0x6d, 0x6f, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x6d, 0x6f, 0x72, 0x65,
+	0x22, 0x40, 0x0a, 0x14, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x65, 0x73, 0x42, 0x79, 0x48, 0x61, 0x73,
+	0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x0c, 0x63, 0x6c, 0x61, 0x73,
+	0x73, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x05,
+	0x2e, 0x48, 0x61, 0x73, 0x68, 0x52, 0x0b, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x48, 0x61, 0x73, 0x68,
+	0x65, 0x73, 0x22, 0x4f, 0x0a, 0x1a, 0x44, 0x65, 0x63, 0x6c, 0x61, 0x72, 0x65, 0x64, 0x43, 0x6c,
+	0x61, 0x73, 0x73, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1b, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x05, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x22, 0x5b, 0x0a, 0x1b, 0x44, 0x65, 0x63, 0x6c, 0x61, 0x72, 0x65, 0x64, 0x43,
+	0x6c, 0x61, 0x73, 0x73, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x28, 0x0a, 0x0c, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x5f, 0x68, 0x61, 0x73, 0x68,
+	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x05, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x52,
+	0x0b, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04,
+	0x6d, 0x6f, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x6d, 0x6f, 0x72, 0x65,
+	0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x4e,
+	0x65, 0x74, 0x68, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x64, 0x45, 0x74, 0x68, 0x2f, 0x6a, 0x75, 0x6e,
+	0x6f, 0x2f, 0x70, 0x32, 0x70, 0x2f, 0x73, 0x74, 0x61, 0x72, 0x6b, 0x6e, 0x65, 0x74, 0x2f, 0x73,
+	0x70, 0x65, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_p2p_proto_snapshot_proto_rawDescOnce sync.Once
+	file_p2p_proto_snapshot_proto_rawDescData = file_p2p_proto_snapshot_proto_rawDesc
+)
+
+func file_p2p_proto_snapshot_proto_rawDescGZIP() []byte {
+	file_p2p_proto_snapshot_proto_rawDescOnce.Do(func() {
+		file_p2p_proto_snapshot_proto_rawDescData = protoimpl.X.CompressGZIP(file_p2p_proto_snapshot_proto_rawDescData)
+	})
+	return file_p2p_proto_snapshot_proto_rawDescData
+}
+
+var file_p2p_proto_snapshot_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_p2p_proto_snapshot_proto_goTypes = []any{
+	(*PatriciaNode)(nil),                // 0: PatriciaNode
+	(*PatriciaProof)(nil),               // 1: PatriciaProof
+	(*PatriciaBoundary)(nil),            // 2: PatriciaBoundary
+	(*PatriciaRangeProof)(nil),          // 3: PatriciaRangeProof
+	(*SnapshotHead)(nil),                // 4: SnapshotHead
+	(*ContractState)(nil),               // 5: ContractState
+	(*ContractRangeRequest)(nil),        // 6: ContractRangeRequest
+	(*ContractRangeResponse)(nil),       // 7: ContractRangeResponse
+	(*ClassRangeRequest)(nil),           // 8: ClassRangeRequest
+	(*ClassRangeResponse)(nil),          // 9: ClassRangeResponse
+	(*ContractStorageRequest)(nil),      // 10: ContractStorageRequest
+	(*ContractStorageResponse)(nil),     // 11: ContractStorageResponse
+	(*ClassesByHashRequest)(nil),        // 12: ClassesByHashRequest
+	(*DeclaredClassHashesRequest)(nil),  // 13: DeclaredClassHashesRequest
+	(*DeclaredClassHashesResponse)(nil), // 14: DeclaredClassHashesResponse
+	(*PatriciaNode_Edge)(nil),           // 15: PatriciaNode.Edge
+	(*PatriciaNode_Binary)(nil),         // 16: PatriciaNode.Binary
+	(*Felt252)(nil),                     // 17: Felt252
+	(*BlockID)(nil),                     // 18: BlockID
+	(*Hash)(nil),                        // 19: Hash
+	(*Address)(nil),                     // 20: Address
+	(*DeclaredClass)(nil),               // 21: DeclaredClass
+	(*ContractStoredValue)(nil),         // 22: ContractStoredValue
+}
+var file_p2p_proto_snapshot_proto_depIdxs = []int32{
+	15, // 0: PatriciaNode.edge:type_name -> PatriciaNode.Edge
+	16, // 1: PatriciaNode.binary:type_name -> PatriciaNode.Binary
+	0,  // 2: PatriciaProof.nodes:type_name -> PatriciaNode
+	17, // 3: PatriciaBoundary.key:type_name -> Felt252
+	17, // 4: PatriciaBoundary.value:type_name -> Felt252
+	1,  // 5: PatriciaBoundary.proof:type_name -> PatriciaProof
+	2,  // 6: PatriciaRangeProof.left:type_name -> PatriciaBoundary
+	2,  // 7: PatriciaRangeProof.right:type_name -> PatriciaBoundary
+	18, // 8: SnapshotHead.block:type_name -> BlockID
+	19, // 9: SnapshotHead.state_root:type_name -> Hash
+	19, // 10: SnapshotHead.contracts_root:type_name -> Hash
+	19, // 11: SnapshotHead.classes_root:type_name -> Hash
+	20, // 12: ContractState.address:type_name -> Address
+	19, // 13: ContractState.class_hash:type_name -> Hash
+	19, // 14: ContractState.storage_root:type_name -> Hash
+	17, // 15: ContractState.nonce:type_name -> Felt252
+	20, // 16: ContractRangeRequest.start:type_name -> Address
+	4,  // 17: ContractRangeResponse.head:type_name -> SnapshotHead
+	5,  // 18: ContractRangeResponse.states:type_name -> ContractState
+	3,  // 19: ContractRangeResponse.proof:type_name -> PatriciaRangeProof
+	19, // 20: ClassRangeRequest.start:type_name -> Hash
+	4,  // 21: ClassRangeResponse.head:type_name -> SnapshotHead
+	21, // 22: ClassRangeResponse.classes:type_name -> DeclaredClass
+	3,  // 23: ClassRangeResponse.proof:type_name -> PatriciaRangeProof
+	20, // 24: ContractStorageRequest.address:type_name -> Address
+	17, // 25: ContractStorageRequest.start:type_name -> Felt252
+	4,  // 26: ContractStorageResponse.head:type_name -> SnapshotHead
+	19, // 27: ContractStorageResponse.storage_root:type_name -> Hash
+	22, // 28: ContractStorageResponse.values:type_name -> ContractStoredValue
+	3,  // 29: ContractStorageResponse.proof:type_name -> PatriciaRangeProof
+	19, // 30: ClassesByHashRequest.class_hashes:type_name -> Hash
+	19, // 31: DeclaredClassHashesRequest.start:type_name -> Hash
+	19, // 32: DeclaredClassHashesResponse.class_hashes:type_name -> Hash
+	17, // 33: PatriciaNode.Edge.path:type_name -> Felt252
+	17, // 34: PatriciaNode.Edge.child:type_name -> Felt252
+	17, // 35: PatriciaNode.Binary.left:type_name -> Felt252
+	17, // 36: PatriciaNode.Binary.right:type_name -> Felt252
+	37, // [37:37] is the sub-list for method output_type
+	37, // [37:37] is the sub-list for method input_type
+	37, // [37:37] is the sub-list for extension type_name
+	37, // [37:37] is the sub-list for extension extendee
+	0,  // [0:37] is the sub-list for field type_name
+}
+
+func init() { file_p2p_proto_snapshot_proto_init() }
+func file_p2p_proto_snapshot_proto_init() {
+	if File_p2p_proto_snapshot_proto != nil {
+		return
+	}
+	file_p2p_proto_common_proto_init()
+	file_p2p_proto_state_proto_init()
+	file_p2p_proto_snapshot_proto_msgTypes[0].OneofWrappers = []any{
+		(*PatriciaNode_Edge_)(nil),
+		(*PatriciaNode_Binary_)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_p2p_proto_snapshot_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   17,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_p2p_proto_snapshot_proto_goTypes,
+		DependencyIndexes: file_p2p_proto_snapshot_proto_depIdxs,
+		MessageInfos:      file_p2p_proto_snapshot_proto_msgTypes,
+	}.Build()
+	File_p2p_proto_snapshot_proto = out.File
+	file_p2p_proto_snapshot_proto_rawDesc = nil
+	file_p2p_proto_snapshot_proto_goTypes = nil
+	file_p2p_proto_snapshot_proto_depIdxs = nil
+}
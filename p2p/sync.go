@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/rand"
 	"reflect"
+	"slices"
 	"time"
 
 	"github.com/NethermindEth/juno/adapters/p2p2core"
@@ -15,9 +16,9 @@ import (
 	"github.com/NethermindEth/juno/db"
 	"github.com/NethermindEth/juno/p2p/starknet"
 	"github.com/NethermindEth/juno/p2p/starknet/spec"
+	junoplugin "github.com/NethermindEth/juno/plugin"
 	junoSync "github.com/NethermindEth/juno/sync"
 	"github.com/NethermindEth/juno/utils"
-	"github.com/NethermindEth/juno/utils/pipeline"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -30,9 +31,16 @@ type syncService struct {
 	network *utils.Network
 	client  *starknet.Client // todo: merge all the functionality of Client with p2p SyncService
 
-	blockchain *blockchain.Blockchain
-	listener   junoSync.EventListener
-	log        utils.SimpleLogger
+	blockchain  *blockchain.Blockchain
+	listener    junoSync.EventListener
+	log         utils.SimpleLogger
+	scores      *peerScores
+	plugin      junoplugin.JunoPlugin
+	useSnapSync bool
+
+	// Blocks announced over gossip are passed to the downloader, which stores all blocks.
+	announcements chan announcement
+	announced     *announcedHead
 }
 
 func newSyncService(bc *blockchain.Blockchain, h host.Host, n *utils.Network, log utils.SimpleLogger) *syncService {
@@ -42,6 +50,10 @@ func newSyncService(bc *blockchain.Blockchain, h host.Host, n *utils.Network, lo
 		blockchain: bc,
 		log:        log,
 		listener:   &junoSync.SelectiveListener{},
+		scores:     newPeerScores(),
+
+		announcements: make(chan announcement),
+		announced:     new(announcedHead),
 	}
 }
 
@@ -51,6 +63,17 @@ func (s *syncService) start(ctx context.Context) {
 
 	s.client = starknet.NewClient(s.randomPeerStream, s.network, s.log)
 
+	if s.useSnapSync {
+		if _, err := s.blockchain.Height(); errors.Is(err, db.ErrKeyNotFound) {
+			if err = s.snapSync(ctx); err != nil {
+				if ctx.Err() == nil {
+					s.log.Errorw("Snap sync failed, its state is discarded on the next start", "err", err)
+				}
+				return
+			}
+		}
+	}
+
 	for i := 0; ; i++ {
 		if err := ctx.Err(); err != nil {
 			break
@@ -67,10 +90,9 @@ func (s *syncService) start(ctx context.Context) {
 
 		s.log.Infow("Start Pipeline", "Current height", nextHeight-1, "Start", nextHeight)
 
-		// todo change iteration to fetch several objects uint64(min(blockBehind, maxBlocks))
 		blockNumber := uint64(nextHeight)
-		if err := s.processBlock(iterCtx, blockNumber); err != nil {
-			s.logError("Failed to process block", fmt.Errorf("blockNumber: %d, err: %w", blockNumber, err))
+		if err := s.downloadBlocks(iterCtx, blockNumber); err != nil {
+			s.logError("Failed to download blocks", fmt.Errorf("blockNumber: %d, err: %w", blockNumber, err))
 			cancelIteration()
 			continue
 		}
@@ -89,61 +111,6 @@ func (s *syncService) getNextHeight() (int, error) {
 	return 0, err
 }
 
-func (s *syncService) processBlock(ctx context.Context, blockNumber uint64) error {
-	headersAndSigsCh, err := s.genHeadersAndSigs(ctx, blockNumber)
-	if err != nil {
-		return fmt.Errorf("failed to get block headers parts: %w", err)
-	}
-
-	txsCh, err := s.genTransactions(ctx, blockNumber)
-	if err != nil {
-		return fmt.Errorf("failed to get transactions: %w", err)
-	}
-
-	eventsCh, err := s.genEvents(ctx, blockNumber)
-	if err != nil {
-		return fmt.Errorf("failed to get events: %w", err)
-	}
-
-	classesCh, err := s.genClasses(ctx, blockNumber)
-	if err != nil {
-		return fmt.Errorf("failed to get classes: %w", err)
-	}
-
-	stateDiffsCh, err := s.genStateDiffs(ctx, blockNumber)
-	if err != nil {
-		return fmt.Errorf("failed to get state diffs: %w", err)
-	}
-
-	blocksCh := pipeline.Bridge(ctx, s.processSpecBlockParts(ctx, blockNumber, pipeline.FanIn(ctx,
-		pipeline.Stage(ctx, headersAndSigsCh, specBlockPartsFunc[specBlockHeaderAndSigs]),
-		pipeline.Stage(ctx, classesCh, specBlockPartsFunc[specClasses]),
-		pipeline.Stage(ctx, stateDiffsCh, specBlockPartsFunc[specContractDiffs]),
-		pipeline.Stage(ctx, txsCh, specBlockPartsFunc[specTxWithReceipts]),
-		pipeline.Stage(ctx, eventsCh, specBlockPartsFunc[specEvents]),
-	)))
-
-	for b := range blocksCh {
-		if b.err != nil {
-			return fmt.Errorf("failed to process block: %w", b.err)
-		}
-
-		storeTimer := time.Now()
-		if err := s.blockchain.Store(b.block, b.commitments, b.stateUpdate, b.newClasses); err != nil {
-			return fmt.Errorf("failed to store block: %w", err)
-		}
-
-		s.log.Infow("Stored Block", "number", b.block.Number, "hash", b.block.Hash.ShortString(),
-			"root", b.block.GlobalStateRoot.ShortString())
-		s.listener.OnSyncStepDone(junoSync.OpStore, b.block.Number, time.Since(storeTimer))
-	}
-	return nil
-}
-
-func specBlockPartsFunc[T specBlockHeaderAndSigs | specTxWithReceipts | specEvents | specClasses | specContractDiffs](i T) specBlockParts {
-	return specBlockParts(i)
-}
-
 func (s *syncService) logError(msg string, err error) {
 	if !errors.Is(err, context.Canceled) {
 		var log utils.SimpleLogger
@@ -169,99 +136,6 @@ type blockBody struct {
 	err         error
 }
 
-//nolint:gocyclo
-func (s *syncService) processSpecBlockParts(
-	ctx context.Context, startingBlockNum uint64, specBlockPartsCh <-chan specBlockParts,
-) <-chan <-chan blockBody {
-	orderedBlockBodiesCh := make(chan (<-chan blockBody))
-
-	go func() {
-		defer close(orderedBlockBodiesCh)
-
-		specBlockHeadersAndSigsM := make(map[uint64]specBlockHeaderAndSigs)
-		specClassesM := make(map[uint64]specClasses)
-		specTransactionsM := make(map[uint64]specTxWithReceipts)
-		specEventsM := make(map[uint64]specEvents)
-		specContractDiffsM := make(map[uint64]specContractDiffs)
-
-		curBlockNum := startingBlockNum
-		for part := range specBlockPartsCh {
-			select {
-			case <-ctx.Done():
-			default:
-				switch p := part.(type) {
-				case specBlockHeaderAndSigs:
-					s.log.Debugw("Received Block Header with signatures", "blockNumber", p.blockNumber())
-					if _, ok := specBlockHeadersAndSigsM[part.blockNumber()]; !ok {
-						specBlockHeadersAndSigsM[part.blockNumber()] = p
-					}
-				case specTxWithReceipts:
-					s.log.Debugw("Received Transactions with receipts", "blockNumber", p.blockNumber(), "txLen", len(p.txs))
-					if _, ok := specTransactionsM[part.blockNumber()]; !ok {
-						specTransactionsM[part.blockNumber()] = p
-					}
-				case specEvents:
-					s.log.Debugw("Received Events", "blockNumber", p.blockNumber(), "len", len(p.events))
-					if _, ok := specEventsM[part.blockNumber()]; !ok {
-						specEventsM[part.blockNumber()] = p
-					}
-				case specClasses:
-					s.log.Debugw("Received Classes", "blockNumber", p.blockNumber())
-					if _, ok := specClassesM[part.blockNumber()]; !ok {
-						specClassesM[part.blockNumber()] = p
-					}
-				case specContractDiffs:
-					s.log.Debugw("Received ContractDiffs", "blockNumber", p.blockNumber())
-					if _, ok := specContractDiffsM[part.blockNumber()]; !ok {
-						specContractDiffsM[part.blockNumber()] = p
-					}
-				default:
-					s.log.Warnw("Unsupported part type", "blockNumber", part.blockNumber(), "type", reflect.TypeOf(p))
-				}
-
-				headerAndSig, okHeader := specBlockHeadersAndSigsM[curBlockNum]
-				txs, okTxs := specTransactionsM[curBlockNum]
-				es, okEvents := specEventsM[curBlockNum]
-				cls, okClasses := specClassesM[curBlockNum]
-				diffs, okDiffs := specContractDiffsM[curBlockNum]
-				if okHeader && okTxs && okEvents && okClasses && okDiffs {
-					s.log.Debugw(fmt.Sprintf("----- Received all block parts from peers for block number %d-----", curBlockNum))
-
-					select {
-					case <-ctx.Done():
-					default:
-						prevBlockRoot := &felt.Zero
-						if curBlockNum > 0 {
-							// First check cache if the header is not present, then get it from the db.
-							if oldHeader, ok := specBlockHeadersAndSigsM[curBlockNum-1]; ok {
-								prevBlockRoot = p2p2core.AdaptHash(oldHeader.header.StateRoot)
-							} else {
-								oldHeader, err := s.blockchain.BlockHeaderByNumber(curBlockNum - 1)
-								if err != nil {
-									s.log.Errorw("Failed to get Header", "number", curBlockNum, "err", err)
-									return
-								}
-								prevBlockRoot = oldHeader.GlobalStateRoot
-							}
-						}
-
-						orderedBlockBodiesCh <- s.adaptAndSanityCheckBlock(ctx, headerAndSig.header, diffs.contractDiffs,
-							cls.classes, txs.txs, txs.receipts, es.events, prevBlockRoot)
-					}
-
-					if curBlockNum > 0 {
-						delete(specBlockHeadersAndSigsM, curBlockNum-1)
-					}
-					delete(specTransactionsM, curBlockNum)
-					delete(specEventsM, curBlockNum)
-					curBlockNum++
-				}
-			}
-		}
-	}()
-	return orderedBlockBodiesCh
-}
-
 //nolint:gocyclo
 func (s *syncService) adaptAndSanityCheckBlock(ctx context.Context, header *spec.SignedBlockHeader, contractDiffs []*spec.ContractDiff,
 	classes []*spec.Class, txs []*spec.Transaction, receipts []*spec.Receipt, events []*spec.Event, prevBlockRoot *felt.Felt,
@@ -273,52 +147,9 @@ func (s *syncService) adaptAndSanityCheckBlock(ctx context.Context, header *spec
 		case <-ctx.Done():
 			bodyCh <- blockBody{err: ctx.Err()}
 		default:
-			coreBlock := new(core.Block)
-
-			var coreTxs []core.Transaction
-			for _, tx := range txs {
-				coreTxs = append(coreTxs, p2p2core.AdaptTransaction(tx, s.network))
-			}
-
-			coreBlock.Transactions = coreTxs
-
-			txHashEventsM := make(map[felt.Felt][]*core.Event)
-			for _, event := range events {
-				txH := p2p2core.AdaptHash(event.TransactionHash)
-				txHashEventsM[*txH] = append(txHashEventsM[*txH], p2p2core.AdaptEvent(event))
-			}
-
-			coreReceipts := make([]*core.TransactionReceipt, 0, len(receipts))
-			for i, r := range receipts {
-				coreReceipts = append(coreReceipts, p2p2core.AdaptReceipt(r, coreTxs[i].Hash()))
-			}
-			coreReceipts = utils.Map(coreReceipts, func(r *core.TransactionReceipt) *core.TransactionReceipt {
-				r.Events = txHashEventsM[*r.TransactionHash]
-				return r
-			})
-			coreBlock.Receipts = coreReceipts
-
-			eventsBloom := core.EventsBloom(coreBlock.Receipts)
-			coreBlock.Header = p2p2core.AdaptBlockHeader(header, eventsBloom)
-
-			if int(coreBlock.TransactionCount) != len(coreBlock.Transactions) {
-				s.log.Errorw(
-					"Number of transactions != count",
-					"transactionCount",
-					coreBlock.TransactionCount,
-					"len(transactions)",
-					len(coreBlock.Transactions),
-				)
-				return
-			}
-			if int(coreBlock.EventCount) != len(events) {
-				s.log.Errorw(
-					"Number of events != count",
-					"eventCount",
-					coreBlock.EventCount,
-					"len(events)",
-					len(events),
-				)
+			coreBlock, err := s.adaptBlock(header, txs, receipts, events)
+			if err != nil {
+				bodyCh <- blockBody{err: err}
 				return
 			}
 
@@ -375,16 +206,229 @@ func (s *syncService) adaptAndSanityCheckBlock(ctx context.Context, header *spec
 	return bodyCh
 }
 
-type specBlockParts interface {
-	blockNumber() uint64
+// adaptBlock adapts the header, transactions and receipts of a block served by a peer, along with the events of the
+// receipts.
+func (s *syncService) adaptBlock(header *spec.SignedBlockHeader, txs []*spec.Transaction, receipts []*spec.Receipt,
+	events []*spec.Event,
+) (*core.Block, error) {
+	if len(receipts) != len(txs) {
+		return nil, fmt.Errorf("number of receipts %d != number of transactions %d", len(receipts), len(txs))
+	}
+	for _, tx := range txs {
+		if err := checkTransaction(tx); err != nil {
+			return nil, err
+		}
+	}
+	coreBlock := new(core.Block)
+
+	var coreTxs []core.Transaction
+	for _, tx := range txs {
+		coreTxs = append(coreTxs, p2p2core.AdaptTransaction(tx, s.network))
+	}
+
+	coreBlock.Transactions = coreTxs
+
+	txHashEventsM := make(map[felt.Felt][]*core.Event)
+	for _, event := range events {
+		txH := p2p2core.AdaptHash(event.TransactionHash)
+		txHashEventsM[*txH] = append(txHashEventsM[*txH], p2p2core.AdaptEvent(event))
+	}
+
+	coreReceipts := make([]*core.TransactionReceipt, 0, len(receipts))
+	for i, r := range receipts {
+		coreReceipts = append(coreReceipts, p2p2core.AdaptReceipt(r, coreTxs[i].Hash()))
+	}
+	coreReceipts = utils.Map(coreReceipts, func(r *core.TransactionReceipt) *core.TransactionReceipt {
+		r.Events = txHashEventsM[*r.TransactionHash]
+		return r
+	})
+	coreBlock.Receipts = coreReceipts
+
+	eventsBloom := core.EventsBloom(coreBlock.Receipts)
+	coreBlock.Header = p2p2core.AdaptBlockHeader(header, eventsBloom)
+
+	if int(coreBlock.TransactionCount) != len(coreBlock.Transactions) {
+		return nil, fmt.Errorf("number of transactions %d != count %d", len(coreBlock.Transactions), coreBlock.TransactionCount)
+	}
+	if int(coreBlock.EventCount) != len(events) {
+		return nil, fmt.Errorf("number of events %d != count %d", len(events), coreBlock.EventCount)
+	}
+	return coreBlock, nil
 }
 
-type specBlockHeaderAndSigs struct {
-	header *spec.SignedBlockHeader
+// verifyHeader adapts a header served by a peer, verifying its hash and the sequencer's signature before the rest of
+// the block is downloaded.
+func (s *syncService) verifyHeader(header *spec.SignedBlockHeader) (*core.Header, error) {
+	if err := checkHeader(header); err != nil {
+		return nil, err
+	}
+	coreHeader := p2p2core.AdaptBlockHeader(header, nil)
+	commitments := &core.BlockCommitments{
+		TransactionCommitment: p2p2core.AdaptHash(header.Transactions.Root),
+		EventCommitment:       p2p2core.AdaptHash(header.Events.Root),
+		ReceiptCommitment:     p2p2core.AdaptHash(header.Receipts),
+		StateDiffCommitment:   p2p2core.AdaptHash(header.StateDiffCommitment.GetRoot()),
+	}
+	if err := core.VerifyHeaderHash(coreHeader, commitments, header.StateDiffCommitment.GetStateDiffLength(), s.network); err != nil {
+		return nil, fmt.Errorf("header %d: %w", header.Number, err)
+	}
+	if err := s.blockchain.VerifyHeaderSignature(coreHeader, commitments.StateDiffCommitment); err != nil {
+		return nil, fmt.Errorf("header %d: %w", header.Number, err)
+	}
+	return coreHeader, nil
+}
+
+// checkHeader checks that a header served by a peer has the fields the adapters and hash functions require.
+func checkHeader(header *spec.SignedBlockHeader) error {
+	if header == nil || header.BlockHash == nil || header.ParentHash == nil || header.StateRoot == nil ||
+		header.Transactions == nil || header.Events == nil ||
+		header.GasPriceWei == nil || header.GasPriceFri == nil || header.DataGasPriceWei == nil || header.DataGasPriceFri == nil {
+		return errors.New("incomplete header")
+	}
+	if _, ok := spec.L1DataAvailabilityMode_name[int32(header.L1DataAvailabilityMode)]; !ok {
+		return fmt.Errorf("header %d: unknown data availability mode %d", header.Number, header.L1DataAvailabilityMode)
+	}
+	for _, sig := range header.Signatures {
+		if sig.GetR() == nil || sig.GetS() == nil {
+			return fmt.Errorf("header %d: incomplete signature", header.Number)
+		}
+	}
+	return nil
+}
+
+// checkTransaction checks that a transaction served by a peer has the fields the adapters and hash functions require.
+//
+//nolint:gocyclo
+func checkTransaction(tx *spec.Transaction) error {
+	if tx == nil || tx.TransactionHash == nil {
+		return errors.New("incomplete transaction")
+	}
+
+	var (
+		felts []feltMessage
+		lists [][]*spec.Felt252
+		// Deploy and L1 handler transactions aren't signed.
+		signature = &spec.AccountSignature{}
+		v3        bool
+		bounds    *spec.ResourceBounds
+		daModes   []spec.VolitionDomain
+	)
+	switch t := tx.Txn.(type) {
+	case *spec.Transaction_DeclareV0_:
+		v := t.DeclareV0
+		felts, signature = []feltMessage{v.GetSender(), v.GetMaxFee(), v.GetClassHash()}, v.GetSignature()
+	case *spec.Transaction_DeclareV1_:
+		v := t.DeclareV1
+		felts = []feltMessage{v.GetSender(), v.GetMaxFee(), v.GetClassHash(), v.GetNonce()}
+		signature = v.GetSignature()
+	case *spec.Transaction_DeclareV2_:
+		v := t.DeclareV2
+		felts = []feltMessage{v.GetSender(), v.GetMaxFee(), v.GetClassHash(), v.GetNonce(), v.GetCompiledClassHash()}
+		signature = v.GetSignature()
+	case *spec.Transaction_DeclareV3_:
+		v := t.DeclareV3
+		felts = []feltMessage{v.GetSender(), v.GetClassHash(), v.GetNonce(), v.GetCompiledClassHash()}
+		lists = [][]*spec.Felt252{v.GetPaymasterData(), v.GetAccountDeploymentData()}
+		signature, v3, bounds = v.GetSignature(), true, v.GetResourceBounds()
+		daModes = []spec.VolitionDomain{v.GetNonceDataAvailabilityMode(), v.GetFeeDataAvailabilityMode()}
+	case *spec.Transaction_Deploy_:
+		v := t.Deploy
+		felts, lists = []feltMessage{v.GetClassHash(), v.GetAddressSalt()}, [][]*spec.Felt252{v.GetCalldata()}
+	case *spec.Transaction_DeployAccountV1_:
+		v := t.DeployAccountV1
+		felts = []feltMessage{v.GetMaxFee(), v.GetClassHash(), v.GetNonce(), v.GetAddressSalt()}
+		lists, signature = [][]*spec.Felt252{v.GetCalldata()}, v.GetSignature()
+	case *spec.Transaction_DeployAccountV3_:
+		v := t.DeployAccountV3
+		felts = []feltMessage{v.GetClassHash(), v.GetNonce(), v.GetAddressSalt()}
+		lists = [][]*spec.Felt252{v.GetCalldata(), v.GetPaymasterData()}
+		signature, v3, bounds = v.GetSignature(), true, v.GetResourceBounds()
+		daModes = []spec.VolitionDomain{v.GetNonceDataAvailabilityMode(), v.GetFeeDataAvailabilityMode()}
+	case *spec.Transaction_InvokeV0_:
+		v := t.InvokeV0
+		felts = []feltMessage{v.GetMaxFee(), v.GetAddress(), v.GetEntryPointSelector()}
+		lists, signature = [][]*spec.Felt252{v.GetCalldata()}, v.GetSignature()
+	case *spec.Transaction_InvokeV1_:
+		v := t.InvokeV1
+		felts = []feltMessage{v.GetSender(), v.GetMaxFee(), v.GetNonce()}
+		lists, signature = [][]*spec.Felt252{v.GetCalldata()}, v.GetSignature()
+	case *spec.Transaction_InvokeV3_:
+		v := t.InvokeV3
+		felts = []feltMessage{v.GetSender(), v.GetNonce()}
+		lists = [][]*spec.Felt252{v.GetCalldata(), v.GetPaymasterData(), v.GetAccountDeploymentData()}
+		signature, v3, bounds = v.GetSignature(), true, v.GetResourceBounds()
+		daModes = []spec.VolitionDomain{v.GetNonceDataAvailabilityMode(), v.GetFeeDataAvailabilityMode()}
+	case *spec.Transaction_L1Handler:
+		v := t.L1Handler
+		felts = []feltMessage{v.GetNonce(), v.GetAddress(), v.GetEntryPointSelector()}
+		lists = [][]*spec.Felt252{v.GetCalldata()}
+	default:
+		return fmt.Errorf("unsupported transaction %T", t)
+	}
+
+	if signature == nil {
+		return errors.New("transaction without a signature")
+	}
+	if v3 {
+		for _, limits := range []*spec.ResourceLimits{bounds.GetL1Gas(), bounds.GetL2Gas()} {
+			felts = append(felts, limits.GetMaxAmount(), limits.GetMaxPricePerUnit())
+		}
+	}
+	for _, f := range felts {
+		if f == nil || reflect.ValueOf(f).IsNil() {
+			return errors.New("incomplete transaction")
+		}
+	}
+	for _, list := range append(lists, signature.Parts) {
+		if slices.Contains(list, nil) {
+			return errors.New("incomplete transaction")
+		}
+	}
+	for _, mode := range daModes {
+		if _, ok := spec.VolitionDomain_name[int32(mode)]; !ok {
+			return fmt.Errorf("unknown data availability mode %d", mode)
+		}
+	}
+	return nil
 }
 
-func (s specBlockHeaderAndSigs) blockNumber() uint64 {
-	return s.header.Number
+// feltMessage is a felt, hash or address served by a peer.
+type feltMessage interface {
+	GetElements() []byte
+}
+
+// checkClass checks that a class served by a peer has the fields the adapters and hash functions require.
+func checkClass(class *spec.Class) error {
+	var felts []*spec.Felt252
+	switch c := class.GetClass().(type) {
+	case *spec.Class_Cairo0:
+		if c.Cairo0 == nil {
+			return errors.New("incomplete class")
+		}
+		for _, point := range slices.Concat(c.Cairo0.Externals, c.Cairo0.L1Handlers, c.Cairo0.Constructors) {
+			felts = append(felts, point.GetSelector())
+		}
+	case *spec.Class_Cairo1:
+		points := c.Cairo1.GetEntryPoints()
+		if points == nil {
+			return errors.New("incomplete class")
+		}
+		for _, point := range slices.Concat(points.Externals, points.L1Handlers, points.Constructors) {
+			felts = append(felts, point.GetSelector())
+		}
+		felts = append(felts, c.Cairo1.Program...)
+	default:
+		return fmt.Errorf("unsupported class %T", c)
+	}
+
+	if slices.Contains(felts, nil) {
+		return errors.New("incomplete class")
+	}
+	return nil
+}
+
+type specBlockHeaderAndSigs struct {
+	header *spec.SignedBlockHeader
 }
 
 func (s *syncService) genHeadersAndSigs(ctx context.Context, blockNumber uint64) (<-chan specBlockHeaderAndSigs, error) {
@@ -427,10 +471,6 @@ type specClasses struct {
 	classes []*spec.Class
 }
 
-func (s specClasses) blockNumber() uint64 {
-	return s.number
-}
-
 func (s *syncService) genClasses(ctx context.Context, blockNumber uint64) (<-chan specClasses, error) {
 	it := s.createIteratorForBlock(blockNumber)
 	classesIt, err := s.client.RequestClasses(ctx, &spec.ClassesRequest{Iteration: it})
@@ -473,10 +513,6 @@ type specContractDiffs struct {
 	contractDiffs []*spec.ContractDiff
 }
 
-func (s specContractDiffs) blockNumber() uint64 {
-	return s.number
-}
-
 func (s *syncService) genStateDiffs(ctx context.Context, blockNumber uint64) (<-chan specContractDiffs, error) {
 	it := s.createIteratorForBlock(blockNumber)
 	stateDiffsIt, err := s.client.RequestStateDiffs(ctx, &spec.StateDiffsRequest{Iteration: it})
@@ -521,10 +557,6 @@ type specEvents struct {
 	events []*spec.Event
 }
 
-func (s specEvents) blockNumber() uint64 {
-	return s.number
-}
-
 func (s *syncService) genEvents(ctx context.Context, blockNumber uint64) (<-chan specEvents, error) {
 	it := s.createIteratorForBlock(blockNumber)
 	eventsIt, err := s.client.RequestEvents(ctx, &spec.EventsRequest{Iteration: it})
@@ -568,10 +600,6 @@ type specTxWithReceipts struct {
 	receipts []*spec.Receipt
 }
 
-func (s specTxWithReceipts) blockNumber() uint64 {
-	return s.number
-}
-
 func (s *syncService) genTransactions(ctx context.Context, blockNumber uint64) (<-chan specTxWithReceipts, error) {
 	it := s.createIteratorForBlock(blockNumber)
 	txsIt, err := s.client.RequestTransactions(ctx, &spec.TransactionsRequest{Iteration: it})
@@ -618,11 +646,17 @@ func (s *syncService) genTransactions(ctx context.Context, blockNumber uint64) (
 	return txsCh, nil
 }
 
+// peers returns the peers in the peerstore, other than the host itself.
+func (s *syncService) peers() []peer.ID {
+	return utils.Filter(s.host.Peerstore().Peers(), func(peerID peer.ID) bool {
+		return peerID != s.host.ID()
+	})
+}
+
 func (s *syncService) randomPeer() peer.ID {
 	store := s.host.Peerstore()
-	// todo do not request same block from all peers
-	peers := utils.Filter(store.Peers(), func(peerID peer.ID) bool {
-		return peerID != s.host.ID()
+	peers := utils.Filter(s.peers(), func(peerID peer.ID) bool {
+		return !s.scores.banned(peerID)
 	})
 	if len(peers) == 0 {
 		return ""
@@ -656,6 +690,7 @@ func (s *syncService) removePeer(id peer.ID) {
 	s.log.Debugw("Removing peer", "peerID", id)
 	s.host.Peerstore().RemovePeer(id)
 	s.host.Peerstore().ClearAddrs(id)
+	s.scores.remove(id)
 }
 
 func (s *syncService) createIteratorForBlock(blockNumber uint64) *spec.Iteration {
@@ -0,0 +1,89 @@
+package p2p
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/clients/feeder"
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db/pebble"
+	"github.com/NethermindEth/juno/p2p/starknet"
+	adaptfeeder "github.com/NethermindEth/juno/starknetdata/feeder"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestChain stores the mainnet blocks up to head, along with the classes of the contracts they deploy and the
+// classes they declare. modify, if not nil, changes each block and its state update before they are stored.
+func newTestChain(t *testing.T, head uint64, modify func(*core.Block, *core.StateUpdate)) *blockchain.Blockchain {
+	t.Helper()
+	gw := adaptfeeder.New(feeder.NewTestClient(t, &utils.Mainnet))
+	chain := blockchain.New(pebble.NewMemTest(t), &utils.Mainnet)
+
+	declared := make(map[felt.Felt]struct{})
+	for i := range head + 1 {
+		stateUpdate, block, err := gw.StateUpdateWithBlock(context.Background(), i)
+		require.NoError(t, err)
+		commitments, err := core.VerifyBlockHash(block, &utils.Mainnet, stateUpdate.StateDiff)
+		require.NoError(t, err)
+		// The headers peers serve have gas prices, which the hashes of early blocks don't commit to.
+		block.GasPrice, block.GasPriceSTRK = &felt.Zero, &felt.Zero
+		block.L1DataGasPrice = &core.GasPrice{PriceInWei: &felt.Zero, PriceInFri: &felt.Zero}
+		if modify != nil {
+			modify(block, stateUpdate)
+		}
+
+		classHashes := slices.Clone(stateUpdate.StateDiff.DeclaredV0Classes)
+		for _, classHash := range stateUpdate.StateDiff.DeployedContracts {
+			classHashes = append(classHashes, classHash)
+		}
+		classes := make(map[felt.Felt]core.Class)
+		for _, classHash := range classHashes {
+			if _, ok := declared[*classHash]; ok {
+				continue
+			}
+			classes[*classHash], err = gw.Class(context.Background(), classHash)
+			require.NoError(t, err)
+			declared[*classHash] = struct{}{}
+		}
+		require.NoError(t, chain.Store(block, commitments, stateUpdate, classes))
+	}
+	return chain
+}
+
+// servePeer connects s to a new peer serving chain and returns the peer's ID. The peers listen on the loopback
+// interface, as streams of mock networks don't support deadlines, which clients set.
+func servePeer(t *testing.T, s *syncService, chain *blockchain.Blockchain) peer.ID {
+	t.Helper()
+	h, err := libp2p.New(libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, h.Close()) })
+
+	server, err := NewWithHost(h, "", true, chain, &utils.Mainnet, utils.NewNopZapLogger(), pebble.NewMemTest(t))
+	require.NoError(t, err)
+	server.setProtocolHandlers()
+
+	require.NoError(t, s.host.Connect(context.Background(), peer.AddrInfo{ID: h.ID(), Addrs: h.Addrs()}))
+	return h.ID()
+}
+
+// newTestService returns a sync service with an empty blockchain, connected to a peer serving each of chains.
+func newTestService(t *testing.T, chains ...*blockchain.Blockchain) *syncService {
+	t.Helper()
+	h, err := libp2p.New(libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, h.Close()) })
+
+	log := utils.NewNopZapLogger()
+	s := newSyncService(blockchain.New(pebble.NewMemTest(t), &utils.Mainnet), h, &utils.Mainnet, log)
+	s.client = starknet.NewClient(s.randomPeerStream, &utils.Mainnet, log)
+	for _, chain := range chains {
+		servePeer(t, s, chain)
+	}
+	return s
+}
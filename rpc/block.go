@@ -53,6 +53,18 @@ func (l L1DAMode) MarshalText() ([]byte, error) {
 	}
 }
 
+func (l *L1DAMode) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "BLOB":
+		*l = Blob
+	case "CALLDATA":
+		*l = Calldata
+	default:
+		return fmt.Errorf("unknown L1DAMode value = %s", text)
+	}
+	return nil
+}
+
 // https://github.com/starkware-libs/starknet-specs/blob/a789ccc3432c57777beceaa53a34a7ae2f25fda0/api/starknet_api_openrpc.json#L520-L534
 type BlockHashAndNumber struct {
 	Hash   *felt.Felt `json:"block_hash"`
@@ -171,6 +183,12 @@ func (h *Handler) BlockHashAndNumber() (*BlockHashAndNumber, *jsonrpc.Error) {
 // It follows the specification defined here:
 // https://github.com/starkware-libs/starknet-specs/blob/a789ccc3432c57777beceaa53a34a7ae2f25fda0/api/starknet_api_openrpc.json#L11
 func (h *Handler) BlockWithTxHashes(id BlockID) (*BlockWithTxHashes, *jsonrpc.Error) {
+	return cachedResponse(h, blockResponseCacheKey("starknet_getBlockWithTxHashes", &id, nil),
+		func() (*BlockWithTxHashes, *jsonrpc.Error) { return h.blockWithTxHashes(id) },
+		func(block *BlockWithTxHashes) bool { return block.Status == BlockAcceptedL1 })
+}
+
+func (h *Handler) blockWithTxHashes(id BlockID) (*BlockWithTxHashes, *jsonrpc.Error) {
 	block, rpcErr := h.blockByID(&id)
 	if rpcErr != nil {
 		return nil, rpcErr
@@ -207,6 +225,12 @@ func (h *Handler) BlockTransactionCount(id BlockID) (uint64, *jsonrpc.Error) {
 }
 
 func (h *Handler) BlockWithReceipts(id BlockID) (*BlockWithReceipts, *jsonrpc.Error) {
+	return cachedResponse(h, blockResponseCacheKey("starknet_getBlockWithReceipts", &id, nil),
+		func() (*BlockWithReceipts, *jsonrpc.Error) { return h.blockWithReceipts(id) },
+		func(block *BlockWithReceipts) bool { return block.Status == BlockAcceptedL1 })
+}
+
+func (h *Handler) blockWithReceipts(id BlockID) (*BlockWithReceipts, *jsonrpc.Error) {
 	block, rpcErr := h.blockByID(&id)
 	if rpcErr != nil {
 		return nil, rpcErr
@@ -247,6 +271,12 @@ func (h *Handler) BlockWithReceipts(id BlockID) (*BlockWithReceipts, *jsonrpc.Er
 // It follows the specification defined here:
 // https://github.com/starkware-libs/starknet-specs/blob/a789ccc3432c57777beceaa53a34a7ae2f25fda0/api/starknet_api_openrpc.json#L44
 func (h *Handler) BlockWithTxs(id BlockID) (*BlockWithTxs, *jsonrpc.Error) {
+	return cachedResponse(h, blockResponseCacheKey("starknet_getBlockWithTxs", &id, nil),
+		func() (*BlockWithTxs, *jsonrpc.Error) { return h.blockWithTxs(id) },
+		func(block *BlockWithTxs) bool { return block.Status == BlockAcceptedL1 })
+}
+
+func (h *Handler) blockWithTxs(id BlockID) (*BlockWithTxs, *jsonrpc.Error) {
 	block, rpcErr := h.blockByID(&id)
 	if rpcErr != nil {
 		return nil, rpcErr
@@ -0,0 +1,89 @@
+package rpc
+
+import (
+	"errors"
+
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/vm"
+)
+
+// BlockOverride replaces parts of the block context that simulated transactions are executed in. Fields that
+// are not set are taken from the header of the requested block.
+type BlockOverride struct {
+	BlockNumber      *uint64        `json:"block_number,omitempty"`
+	Timestamp        *uint64        `json:"timestamp,omitempty"`
+	SequencerAddress *felt.Felt     `json:"sequencer_address,omitempty"`
+	L1GasPrice       *ResourcePrice `json:"l1_gas_price,omitempty"`
+	L1DataGasPrice   *ResourcePrice `json:"l1_data_gas_price,omitempty"`
+	L1DAMode         *L1DAMode      `json:"l1_da_mode,omitempty"`
+	StarknetVersion  *string        `json:"starknet_version,omitempty"`
+}
+
+// apply returns a copy of header with the overridden fields replaced.
+func (o *BlockOverride) apply(header *core.Header) *core.Header {
+	overridden := *header
+	if o.BlockNumber != nil {
+		overridden.Number = *o.BlockNumber
+	}
+	if o.Timestamp != nil {
+		overridden.Timestamp = *o.Timestamp
+	}
+	if o.SequencerAddress != nil {
+		overridden.SequencerAddress = o.SequencerAddress
+	}
+	if o.L1GasPrice != nil {
+		if o.L1GasPrice.InWei != nil {
+			overridden.GasPrice = o.L1GasPrice.InWei
+		}
+		if o.L1GasPrice.InFri != nil {
+			overridden.GasPriceSTRK = o.L1GasPrice.InFri
+		}
+	}
+	if o.L1DataGasPrice != nil {
+		dataGasPrice := core.GasPrice{}
+		if header.L1DataGasPrice != nil {
+			dataGasPrice = *header.L1DataGasPrice
+		}
+		if o.L1DataGasPrice.InWei != nil {
+			dataGasPrice.PriceInWei = o.L1DataGasPrice.InWei
+		}
+		if o.L1DataGasPrice.InFri != nil {
+			dataGasPrice.PriceInFri = o.L1DataGasPrice.InFri
+		}
+		overridden.L1DataGasPrice = &dataGasPrice
+	}
+	if o.L1DAMode != nil {
+		switch *o.L1DAMode {
+		case Blob:
+			overridden.L1DAMode = core.Blob
+		case Calldata:
+			overridden.L1DAMode = core.Calldata
+		}
+	}
+	if o.StarknetVersion != nil {
+		overridden.ProtocolVersion = *o.StarknetVersion
+	}
+	return &overridden
+}
+
+// blockInfo builds the block context transactions are executed in on top of header, applying blockOverride
+// if it is set.
+func (h *Handler) blockInfo(header *core.Header, blockOverride *BlockOverride) (*vm.BlockInfo, error) {
+	if blockOverride != nil {
+		header = blockOverride.apply(header)
+	}
+
+	blockHashToBeRevealed, err := h.getRevealedBlockHash(header.Number)
+	if err != nil {
+		// An overridden block number can be far enough in the future for the block to be revealed not to exist.
+		if blockOverride == nil || blockOverride.BlockNumber == nil || !errors.Is(err, db.ErrKeyNotFound) {
+			return nil, err
+		}
+	}
+	return &vm.BlockInfo{
+		Header:                header,
+		BlockHashToBeRevealed: blockHashToBeRevealed,
+	}, nil
+}
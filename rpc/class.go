@@ -84,6 +84,12 @@ func adaptDeclaredClass(declaredClass json.RawMessage) (core.Class, error) {
 // It follows the specification defined here:
 // https://github.com/starkware-libs/starknet-specs/blob/master/api/starknet_api_openrpc.json#L248
 func (h *Handler) Class(id BlockID, classHash felt.Felt) (*Class, *jsonrpc.Error) {
+	return cachedResponse(h, blockResponseCacheKey("starknet_getClass", &id, &classHash),
+		func() (*Class, *jsonrpc.Error) { return h.class(id, classHash) },
+		func(*Class) bool { return h.isBlockL1Verified(&id) })
+}
+
+func (h *Handler) class(id BlockID, classHash felt.Felt) (*Class, *jsonrpc.Error) {
 	state, stateCloser, rpcErr := h.stateByBlockID(&id)
 	if rpcErr != nil {
 		return nil, rpcErr
@@ -0,0 +1,23 @@
+package rpc
+
+type EventListener interface {
+	OnResponseCacheHit(method string)
+	OnResponseCacheMiss(method string)
+}
+
+type SelectiveListener struct {
+	OnResponseCacheHitCb  func(method string)
+	OnResponseCacheMissCb func(method string)
+}
+
+func (l *SelectiveListener) OnResponseCacheHit(method string) {
+	if l.OnResponseCacheHitCb != nil {
+		l.OnResponseCacheHitCb(method)
+	}
+}
+
+func (l *SelectiveListener) OnResponseCacheMiss(method string) {
+	if l.OnResponseCacheMissCb != nil {
+		l.OnResponseCacheMissCb(method)
+	}
+}
@@ -64,9 +64,11 @@ func (h *Handler) SubscribeNewHeads(ctx context.Context) (uint64, *jsonrpc.Error
 	h.subscriptions[id] = sub
 	h.mu.Unlock()
 	headerSub := h.newHeads.Subscribe()
+	reorgSub := h.reorgs.Subscribe()
 	sub.wg.Go(func() {
 		defer func() {
 			headerSub.Unsubscribe()
+			reorgSub.Unsubscribe()
 			h.unsubscribe(sub, id)
 		}()
 		for {
@@ -74,6 +76,15 @@ func (h *Handler) SubscribeNewHeads(ctx context.Context) (uint64, *jsonrpc.Error
 			case <-subscriptionCtx.Done():
 				return
 			case header := <-headerSub.Recv():
+				// The reorg that led to a head is sent before it, notify it first.
+				select {
+				case reorg := <-reorgSub.Recv():
+					if err := sendReorg(w, id, reorg); err != nil {
+						h.log.Warnw("Error sending reorg", "err", err)
+						return
+					}
+				default:
+				}
 				resp, err := json.Marshal(jsonrpc.Request{
 					Version: "2.0",
 					Method:  "juno_subscribeNewHeads",
@@ -90,6 +101,11 @@ func (h *Handler) SubscribeNewHeads(ctx context.Context) (uint64, *jsonrpc.Error
 					h.log.Warnw("Error writing a subscription reply", "err", err)
 					return
 				}
+			case reorg := <-reorgSub.Recv():
+				if err := sendReorg(w, id, reorg); err != nil {
+					h.log.Warnw("Error sending reorg", "err", err)
+					return
+				}
 			}
 		}
 	})
@@ -5,6 +5,7 @@ import (
 	"crypto/rand"
 	"encoding/binary"
 	"encoding/json"
+	"fmt"
 	"log"
 	"math"
 	"strings"
@@ -17,6 +18,7 @@ import (
 	"github.com/NethermindEth/juno/feed"
 	"github.com/NethermindEth/juno/jsonrpc"
 	"github.com/NethermindEth/juno/l1/contract"
+	"github.com/NethermindEth/juno/mempool"
 	"github.com/NethermindEth/juno/sync"
 	"github.com/NethermindEth/juno/utils"
 	"github.com/NethermindEth/juno/vm"
@@ -50,6 +52,7 @@ var (
 	ErrTooManyKeysInFilter             = &jsonrpc.Error{Code: 34, Message: "Too many keys provided in a filter"}
 	ErrContractError                   = &jsonrpc.Error{Code: 40, Message: "Contract error"}
 	ErrTransactionExecutionError       = &jsonrpc.Error{Code: 41, Message: "Transaction execution error"}
+	ErrStorageProofNotSupported        = &jsonrpc.Error{Code: 42, Message: "The node doesn't support storage proofs for blocks that are too far in the past"} //nolint:lll
 	ErrInvalidContractClass            = &jsonrpc.Error{Code: 50, Message: "Invalid contract class"}
 	ErrClassAlreadyDeclared            = &jsonrpc.Error{Code: 51, Message: "Class already declared"}
 	ErrInternal                        = &jsonrpc.Error{Code: jsonrpc.InternalError, Message: "Internal error"}
@@ -65,18 +68,29 @@ var (
 	ErrUnsupportedTxVersion            = &jsonrpc.Error{Code: 61, Message: "the transaction version is not supported"}
 	ErrUnsupportedContractClassVersion = &jsonrpc.Error{Code: 62, Message: "the contract class version is not supported"}
 	ErrUnexpectedError                 = &jsonrpc.Error{Code: 63, Message: "An unexpected error occurred"}
+	ErrTooManyAddressesInFilter        = &jsonrpc.Error{Code: 67, Message: "Too many addresses in filter sender_address filter"}
+	ErrTooManyBlocksBack               = &jsonrpc.Error{Code: 68, Message: fmt.Sprintf("Cannot go back more than %v blocks", maxBlocksBack)}
+	ErrCallOnPending                   = &jsonrpc.Error{Code: 69, Message: "This method does not support being called on a pending block"}
 
-	// These errors can be only be returned by Juno-specific methods.
-	ErrSubscriptionNotFound = &jsonrpc.Error{Code: 100, Message: "Subscription not found"}
+	// These errors are specific to Juno.
+	ErrSubscriptionNotFound  = &jsonrpc.Error{Code: 100, Message: "Subscription not found"}
+	ErrHistoricalStatePruned = &jsonrpc.Error{Code: 101, Message: "Historical state pruned"}
 )
 
 const (
 	maxEventChunkSize  = 10240
 	maxEventFilterKeys = 1024
 	traceCacheSize     = 128
+	maxBlocksBack      = 1024
+	maxSenderAddresses = 1024
 	throttledVMErr     = "VM throughput limit reached"
+
+	maxTraceFilterChunkSize = 1024
 )
 
+// defaultResponseCacheSize is the default total size, in bytes, of the JSON encodings of the cached responses.
+const defaultResponseCacheSize = 64 * utils.Megabyte
+
 type traceCacheKey struct {
 	blockHash felt.Felt
 }
@@ -86,20 +100,31 @@ type Handler struct {
 	syncReader    sync.Reader
 	gatewayClient Gateway
 	feederClient  *feeder.Client
+	mempool       *mempool.Pool
 	vm            vm.VM
 	log           utils.Logger
 
 	version  string
 	newHeads *feed.Feed[*core.Header]
+	reorgs   *feed.Feed[*sync.ReorgBlockRange]
+	l1Heads  *feed.Feed[*core.L1Head]
+
+	pendingTxs *feed.Feed[[]core.Transaction]
 
 	idgen         func() uint64
-	mu            stdsync.Mutex // protects subscriptions.
+	mu            stdsync.Mutex // protects subscriptions and txStatusPolls.
 	subscriptions map[uint64]*subscription
+	txStatusPolls map[felt.Felt]*txStatusPoll
 
 	blockTraceCache *lru.Cache[traceCacheKey, []TracedBlockTransaction]
+	traceStore      TraceStore
+	// responseCache keeps the responses to block, receipt, class and state update queries about L1-verified blocks.
+	responseCache *responseCache
+	listener      EventListener
 
-	filterLimit  uint
-	callMaxSteps uint64
+	filterLimit      uint
+	traceFilterLimit uint
+	callMaxSteps     uint64
 
 	l1Client        l1Client
 	coreContractABI abi.ABI
@@ -131,11 +156,18 @@ func New(bcReader blockchain.Reader, syncReader sync.Reader, virtualMachine vm.V
 		},
 		version:       version,
 		newHeads:      feed.New[*core.Header](),
+		reorgs:        feed.New[*sync.ReorgBlockRange](),
+		l1Heads:       feed.New[*core.L1Head](),
+		pendingTxs:    feed.New[[]core.Transaction](),
 		subscriptions: make(map[uint64]*subscription),
+		txStatusPolls: make(map[felt.Felt]*txStatusPoll),
 
-		blockTraceCache: lru.NewCache[traceCacheKey, []TracedBlockTransaction](traceCacheSize),
-		filterLimit:     math.MaxUint,
-		coreContractABI: contractABI,
+		blockTraceCache:  lru.NewCache[traceCacheKey, []TracedBlockTransaction](traceCacheSize),
+		responseCache:    newResponseCache(defaultResponseCacheSize),
+		listener:         &SelectiveListener{},
+		filterLimit:      math.MaxUint,
+		traceFilterLimit: math.MaxUint,
+		coreContractABI:  contractABI,
 	}
 }
 
@@ -145,6 +177,12 @@ func (h *Handler) WithFilterLimit(limit uint) *Handler {
 	return h
 }
 
+// WithTraceFilterLimit sets the maximum number of blocks to trace in a single call for trace filtering.
+func (h *Handler) WithTraceFilterLimit(limit uint) *Handler {
+	h.traceFilterLimit = limit
+	return h
+}
+
 func (h *Handler) WithL1Client(l1Client l1Client) *Handler {
 	h.l1Client = l1Client
 	return h
@@ -155,6 +193,23 @@ func (h *Handler) WithCallMaxSteps(maxSteps uint64) *Handler {
 	return h
 }
 
+// WithResponseCacheSize bounds the total size of the JSON encodings of the cached responses to maxSize bytes. 0
+// disables the response cache.
+func (h *Handler) WithResponseCacheSize(maxSize uint64) *Handler {
+	if maxSize == 0 {
+		h.responseCache = nil
+	} else {
+		h.responseCache = newResponseCache(maxSize)
+	}
+	return h
+}
+
+// WithListener registers an EventListener
+func (h *Handler) WithListener(listener EventListener) *Handler {
+	h.listener = listener
+	return h
+}
+
 func (h *Handler) WithIDGen(idgen func() uint64) *Handler {
 	h.idgen = idgen
 	return h
@@ -170,15 +225,43 @@ func (h *Handler) WithGateway(gatewayClient Gateway) *Handler {
 	return h
 }
 
+// WithMempool makes the handler validate submitted transactions and keep them in pool,
+// which relays them to the gateway.
+func (h *Handler) WithMempool(pool *mempool.Pool) *Handler {
+	h.mempool = pool
+	return h
+}
+
 func (h *Handler) Run(ctx context.Context) error {
 	newHeadsSub := h.syncReader.SubscribeNewHeads().Subscription
+	reorgsSub := h.syncReader.SubscribeReorg().Subscription
+	l1HeadsSub := h.bcReader.SubscribeL1Head().Subscription
+	pendingTxsSub := h.syncReader.SubscribePendingTxs().Subscription
 	defer newHeadsSub.Unsubscribe()
+	defer reorgsSub.Unsubscribe()
+	defer l1HeadsSub.Unsubscribe()
+	defer pendingTxsSub.Unsubscribe()
 	feed.Tee[*core.Header](newHeadsSub, h.newHeads)
-	<-ctx.Done()
-	for _, sub := range h.subscriptions {
-		sub.wg.Wait()
+	feed.Tee[*sync.ReorgBlockRange](reorgsSub, h.reorgs)
+	feed.Tee[*core.L1Head](l1HeadsSub, h.l1Heads)
+	feed.Tee[[]core.Transaction](pendingTxsSub, h.pendingTxs)
+
+	// A reorg reverts blocks with RevertHead, after which the cached responses about them would be stale.
+	cacheReorgsSub := h.reorgs.Subscribe()
+	defer cacheReorgsSub.Unsubscribe()
+	for {
+		select {
+		case <-ctx.Done():
+			for _, sub := range h.subscriptions {
+				sub.wg.Wait()
+			}
+			return nil
+		case <-cacheReorgsSub.Recv():
+			if h.responseCache != nil {
+				h.responseCache.purge()
+			}
+		}
 	}
-	return nil
 }
 
 func (h *Handler) Version() (string, *jsonrpc.Error) {
@@ -302,12 +385,14 @@ func (h *Handler) Methods() ([]jsonrpc.Method, string) { //nolint: funlen
 		},
 		{
 			Name:    "starknet_call",
-			Params:  []jsonrpc.Parameter{{Name: "request"}, {Name: "block_id"}},
+			Params:  []jsonrpc.Parameter{{Name: "request"}, {Name: "block_id"}, {Name: "state_override", Optional: true}},
 			Handler: h.Call,
 		},
 		{
-			Name:    "starknet_estimateFee",
-			Params:  []jsonrpc.Parameter{{Name: "request"}, {Name: "simulation_flags"}, {Name: "block_id"}},
+			Name: "starknet_estimateFee",
+			Params: []jsonrpc.Parameter{
+				{Name: "request"}, {Name: "simulation_flags"}, {Name: "block_id"}, {Name: "state_override", Optional: true},
+			},
 			Handler: h.EstimateFee,
 		},
 		{
@@ -317,19 +402,34 @@ func (h *Handler) Methods() ([]jsonrpc.Method, string) { //nolint: funlen
 		},
 		{
 			Name:    "starknet_traceTransaction",
-			Params:  []jsonrpc.Parameter{{Name: "transaction_hash"}},
+			Params:  []jsonrpc.Parameter{{Name: "transaction_hash"}, {Name: "trace_options", Optional: true}},
 			Handler: h.TraceTransaction,
 		},
 		{
-			Name:    "starknet_simulateTransactions",
-			Params:  []jsonrpc.Parameter{{Name: "block_id"}, {Name: "transactions"}, {Name: "simulation_flags"}},
+			Name: "starknet_simulateTransactions",
+			Params: []jsonrpc.Parameter{
+				{Name: "block_id"}, {Name: "transactions"}, {Name: "simulation_flags"},
+				{Name: "state_override", Optional: true}, {Name: "block_override", Optional: true},
+			},
 			Handler: h.SimulateTransactions,
 		},
 		{
 			Name:    "starknet_traceBlockTransactions",
-			Params:  []jsonrpc.Parameter{{Name: "block_id"}},
+			Params:  []jsonrpc.Parameter{{Name: "block_id"}, {Name: "trace_options", Optional: true}},
 			Handler: h.TraceBlockTransactions,
 		},
+		{
+			Name: "juno_simulateBlocks",
+			Params: []jsonrpc.Parameter{
+				{Name: "block_id"}, {Name: "blocks"}, {Name: "simulation_flags"}, {Name: "state_override", Optional: true},
+			},
+			Handler: h.SimulateBlocks,
+		},
+		{
+			Name:    "juno_traceFilter",
+			Params:  []jsonrpc.Parameter{{Name: "filter"}},
+			Handler: h.TraceFilter,
+		},
 		{
 			Name:    "starknet_specVersion",
 			Handler: h.SpecVersion,
@@ -358,6 +458,43 @@ func (h *Handler) Methods() ([]jsonrpc.Method, string) { //nolint: funlen
 			Params:  []jsonrpc.Parameter{{Name: "transaction_hash"}},
 			Handler: h.GetMessageStatus,
 		},
+		{
+			Name: "starknet_getStorageProof",
+			Params: []jsonrpc.Parameter{
+				{Name: "block_id"},
+				{Name: "class_hashes", Optional: true},
+				{Name: "contract_addresses", Optional: true},
+				{Name: "contracts_storage_keys", Optional: true},
+			},
+			Handler: h.StorageProof,
+		},
+		{
+			Name: "starknet_subscribeEvents",
+			Params: []jsonrpc.Parameter{
+				{Name: "from_address", Optional: true},
+				{Name: "keys", Optional: true},
+				{Name: "block_id", Optional: true},
+			},
+			Handler: h.SubscribeEvents,
+		},
+		{
+			Name:    "starknet_subscribeTransactionStatus",
+			Params:  []jsonrpc.Parameter{{Name: "transaction_hash"}},
+			Handler: h.SubscribeTransactionStatus,
+		},
+		{
+			Name: "starknet_subscribePendingTransactions",
+			Params: []jsonrpc.Parameter{
+				{Name: "transaction_details", Optional: true},
+				{Name: "sender_address", Optional: true},
+			},
+			Handler: h.SubscribePendingTxs,
+		},
+		{
+			Name:    "starknet_unsubscribe",
+			Params:  []jsonrpc.Parameter{{Name: "subscription_id"}},
+			Handler: h.Unsubscribe,
+		},
 	}, "/v0_8"
 }
 
@@ -470,12 +607,14 @@ func (h *Handler) MethodsV0_7() ([]jsonrpc.Method, string) { //nolint: funlen
 		},
 		{
 			Name:    "starknet_call",
-			Params:  []jsonrpc.Parameter{{Name: "request"}, {Name: "block_id"}},
+			Params:  []jsonrpc.Parameter{{Name: "request"}, {Name: "block_id"}, {Name: "state_override", Optional: true}},
 			Handler: h.Call,
 		},
 		{
-			Name:    "starknet_estimateFee",
-			Params:  []jsonrpc.Parameter{{Name: "request"}, {Name: "simulation_flags"}, {Name: "block_id"}},
+			Name: "starknet_estimateFee",
+			Params: []jsonrpc.Parameter{
+				{Name: "request"}, {Name: "simulation_flags"}, {Name: "block_id"}, {Name: "state_override", Optional: true},
+			},
 			Handler: h.EstimateFee,
 		},
 		{
@@ -485,19 +624,34 @@ func (h *Handler) MethodsV0_7() ([]jsonrpc.Method, string) { //nolint: funlen
 		},
 		{
 			Name:    "starknet_traceTransaction",
-			Params:  []jsonrpc.Parameter{{Name: "transaction_hash"}},
+			Params:  []jsonrpc.Parameter{{Name: "transaction_hash"}, {Name: "trace_options", Optional: true}},
 			Handler: h.TraceTransaction,
 		},
 		{
-			Name:    "starknet_simulateTransactions",
-			Params:  []jsonrpc.Parameter{{Name: "block_id"}, {Name: "transactions"}, {Name: "simulation_flags"}},
+			Name: "starknet_simulateTransactions",
+			Params: []jsonrpc.Parameter{
+				{Name: "block_id"}, {Name: "transactions"}, {Name: "simulation_flags"},
+				{Name: "state_override", Optional: true}, {Name: "block_override", Optional: true},
+			},
 			Handler: h.SimulateTransactions,
 		},
 		{
 			Name:    "starknet_traceBlockTransactions",
-			Params:  []jsonrpc.Parameter{{Name: "block_id"}},
+			Params:  []jsonrpc.Parameter{{Name: "block_id"}, {Name: "trace_options", Optional: true}},
 			Handler: h.TraceBlockTransactions,
 		},
+		{
+			Name: "juno_simulateBlocks",
+			Params: []jsonrpc.Parameter{
+				{Name: "block_id"}, {Name: "blocks"}, {Name: "simulation_flags"}, {Name: "state_override", Optional: true},
+			},
+			Handler: h.SimulateBlocks,
+		},
+		{
+			Name:    "juno_traceFilter",
+			Params:  []jsonrpc.Parameter{{Name: "filter"}},
+			Handler: h.TraceFilter,
+		},
 		{
 			Name:    "starknet_specVersion",
 			Handler: h.SpecVersionV0_7,
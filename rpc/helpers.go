@@ -166,6 +166,8 @@ func (h *Handler) stateByBlockID(id *BlockID) (core.StateReader, blockchain.Stat
 	if err != nil {
 		if errors.Is(err, db.ErrKeyNotFound) {
 			return nil, nil, ErrBlockNotFound
+		} else if errors.Is(err, blockchain.ErrHistoryPruned) {
+			return nil, nil, ErrHistoricalStatePruned
 		}
 		return nil, nil, ErrInternal.CloneWithData(err)
 	}
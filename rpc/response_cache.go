@@ -0,0 +1,146 @@
+package rpc
+
+import (
+	"encoding/json"
+	"math"
+	stdsync "sync"
+
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/jsonrpc"
+	"github.com/ethereum/go-ethereum/common/lru"
+)
+
+// responseCacheKey identifies a query by its method and its parameters. A block is identified by either blockHash or
+// blockNumber, as the request did, and hash is the class or transaction hash that the method takes, if any.
+type responseCacheKey struct {
+	method      string
+	blockHash   felt.Felt
+	blockNumber uint64
+	hash        felt.Felt
+}
+
+// sizedResponse is a cached response along with the size of its JSON encoding.
+type sizedResponse struct {
+	response any
+	size     uint64
+}
+
+// responseCache is an LRU cache of responses, bounded by the total size of their JSON encodings rather than their
+// number, as a block with receipts or a class can be thousands of times larger than a small block.
+type responseCache struct {
+	mu      stdsync.Mutex // protects the fields below.
+	lru     lru.BasicLRU[responseCacheKey, sizedResponse]
+	size    uint64
+	maxSize uint64
+}
+
+func newResponseCache(maxSize uint64) *responseCache {
+	return &responseCache{
+		lru:     lru.NewBasicLRU[responseCacheKey, sizedResponse](math.MaxInt),
+		maxSize: maxSize,
+	}
+}
+
+func (c *responseCache) get(key responseCacheKey) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, ok := c.lru.Get(key)
+	return cached.response, ok
+}
+
+// add caches response, whose JSON encoding is size bytes long, evicting the least recently used responses until the
+// cache fits in its maximum size. Responses larger than the whole cache aren't cached.
+func (c *responseCache) add(key responseCacheKey, response any, size uint64) {
+	if size > c.maxSize {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if old, ok := c.lru.Peek(key); ok {
+		c.size -= old.size
+	}
+	for c.size+size > c.maxSize {
+		_, evicted, ok := c.lru.RemoveOldest()
+		if !ok {
+			break
+		}
+		c.size -= evicted.size
+	}
+	c.lru.Add(key, sizedResponse{response: response, size: size})
+	c.size += size
+}
+
+func (c *responseCache) purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.lru.Purge()
+	c.size = 0
+}
+
+// blockResponseCacheKey returns the cache key of the query for method about the block id, or nil if the block it
+// refers to can change, which is the case for the latest and pending blocks.
+func blockResponseCacheKey(method string, id *BlockID, hash *felt.Felt) *responseCacheKey {
+	if id.Latest || id.Pending {
+		return nil
+	}
+
+	key := &responseCacheKey{
+		method:      method,
+		blockNumber: id.Number,
+	}
+	if id.Hash != nil {
+		key.blockHash = *id.Hash
+	}
+	if hash != nil {
+		key.hash = *hash
+	}
+	return key
+}
+
+// cachedResponse serves the response to the query identified by key from the response cache. On a miss, it responds
+// and caches the response if final reports that it can't change anymore, which is the case once its block is
+// verified on L1. A nil key bypasses the cache, as does a disabled cache.
+func cachedResponse[T any](h *Handler, key *responseCacheKey, respond func() (T, *jsonrpc.Error),
+	final func(T) bool,
+) (T, *jsonrpc.Error) {
+	if key == nil || h.responseCache == nil {
+		return respond()
+	}
+
+	if cached, ok := h.responseCache.get(*key); ok {
+		h.listener.OnResponseCacheHit(key.method)
+		return cached.(T), nil
+	}
+	h.listener.OnResponseCacheMiss(key.method)
+
+	response, rpcErr := respond()
+	if rpcErr == nil && final(response) {
+		// The size of the JSON encoding approximates the memory the response takes up.
+		if encoded, err := json.Marshal(response); err == nil {
+			h.responseCache.add(*key, response, uint64(len(encoded)))
+		}
+	}
+	return response, rpcErr
+}
+
+// isBlockL1Verified reports whether the block id refers to is verified on L1.
+func (h *Handler) isBlockL1Verified(id *BlockID) bool {
+	l1Head, rpcErr := h.l1Head()
+	if rpcErr != nil || l1Head == nil {
+		return false
+	}
+
+	number := id.Number
+	if id.Hash != nil {
+		header, rpcErr := h.blockHeaderByID(id)
+		if rpcErr != nil {
+			return false
+		}
+		number = header.Number
+	}
+	return isL1Verified(number, l1Head)
+}
@@ -0,0 +1,200 @@
+package rpc_test
+
+import (
+	"context"
+	"encoding/json"
+	stdsync "sync"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/clients/feeder"
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/feed"
+	"github.com/NethermindEth/juno/mocks"
+	"github.com/NethermindEth/juno/rpc"
+	adaptfeeder "github.com/NethermindEth/juno/starknetdata/feeder"
+	"github.com/NethermindEth/juno/sync"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type countingCacheListener struct {
+	mu     stdsync.Mutex
+	hits   int
+	misses int
+}
+
+func (l *countingCacheListener) OnResponseCacheHit(string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.hits++
+}
+
+func (l *countingCacheListener) OnResponseCacheMiss(string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.misses++
+}
+
+func (l *countingCacheListener) counts() (int, int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.hits, l.misses
+}
+
+func TestResponseCache(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	t.Cleanup(mockCtrl.Finish)
+	mockReader := mocks.NewMockReader(mockCtrl)
+
+	n := utils.Ptr(utils.Mainnet)
+	gw := adaptfeeder.New(feeder.NewTestClient(t, n))
+	block0, err := gw.BlockByNumber(context.Background(), 0)
+	require.NoError(t, err)
+	block1, err := gw.BlockByNumber(context.Background(), 1)
+	require.NoError(t, err)
+
+	mockReader.EXPECT().L1Head().Return(&core.L1Head{BlockNumber: 0}, nil).AnyTimes()
+	mockReader.EXPECT().SubscribeL1Head().Return(blockchain.L1HeadSubscription{
+		Subscription: feed.New[*core.L1Head]().Subscribe(),
+	}).AnyTimes()
+	syncReader, _, reorgs := newTestSyncReader(t)
+
+	listener := new(countingCacheListener)
+	handler := rpc.New(mockReader, syncReader, nil, "", utils.NewNopZapLogger()).WithListener(listener)
+
+	t.Run("L1-verified block is read once", func(t *testing.T) {
+		mockReader.EXPECT().BlockByNumber(uint64(0)).Return(block0, nil).Times(1)
+
+		first, rpcErr := handler.BlockWithTxs(rpc.BlockID{Number: 0})
+		require.Nil(t, rpcErr)
+		second, rpcErr := handler.BlockWithTxs(rpc.BlockID{Number: 0})
+		require.Nil(t, rpcErr)
+		assert.Equal(t, first, second)
+
+		hits, misses := listener.counts()
+		assert.Equal(t, 1, hits)
+		assert.Equal(t, 1, misses)
+	})
+
+	t.Run("block above the L1 head isn't cached", func(t *testing.T) {
+		mockReader.EXPECT().BlockByNumber(uint64(1)).Return(block1, nil).Times(2)
+
+		for range 2 {
+			_, rpcErr := handler.BlockWithTxs(rpc.BlockID{Number: 1})
+			require.Nil(t, rpcErr)
+		}
+	})
+
+	t.Run("latest block bypasses the cache", func(t *testing.T) {
+		mockReader.EXPECT().Head().Return(block0, nil).Times(2)
+		hits, misses := listener.counts()
+
+		for range 2 {
+			_, rpcErr := handler.BlockWithTxs(rpc.BlockID{Latest: true})
+			require.Nil(t, rpcErr)
+		}
+
+		newHits, newMisses := listener.counts()
+		assert.Equal(t, hits, newHits)
+		assert.Equal(t, misses, newMisses)
+	})
+
+	t.Run("reorg invalidates the cache", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+		go func() {
+			require.NoError(t, handler.Run(ctx))
+		}()
+		// Give Run a moment to subscribe to the reorgs.
+		time.Sleep(50 * time.Millisecond)
+
+		reorgs.Send(&sync.ReorgBlockRange{})
+		_, misses := listener.counts()
+		mockReader.EXPECT().BlockByNumber(uint64(0)).Return(block0, nil).Times(1)
+		assert.Eventually(t, func() bool {
+			_, rpcErr := handler.BlockWithTxs(rpc.BlockID{Number: 0})
+			require.Nil(t, rpcErr)
+			_, newMisses := listener.counts()
+			return newMisses > misses
+		}, time.Second, 10*time.Millisecond)
+	})
+}
+
+func TestResponseCacheSize(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	t.Cleanup(mockCtrl.Finish)
+	mockReader := mocks.NewMockReader(mockCtrl)
+
+	n := utils.Ptr(utils.Mainnet)
+	gw := adaptfeeder.New(feeder.NewTestClient(t, n))
+	block0, err := gw.BlockByNumber(context.Background(), 0)
+	require.NoError(t, err)
+	mockReader.EXPECT().L1Head().Return(&core.L1Head{BlockNumber: 0}, nil).AnyTimes()
+	syncReader, _, _ := newTestSyncReader(t)
+
+	newHandler := func(maxSize uint64) (*rpc.Handler, *countingCacheListener) {
+		listener := new(countingCacheListener)
+		return rpc.New(mockReader, syncReader, nil, "", utils.NewNopZapLogger()).WithListener(listener).
+			WithResponseCacheSize(maxSize), listener
+	}
+
+	// The sizes of the JSON encodings of the responses bound the cache.
+	mockReader.EXPECT().BlockByNumber(uint64(0)).Return(block0, nil).Times(2)
+	handler, _ := newHandler(0)
+	withTxs, rpcErr := handler.BlockWithTxs(rpc.BlockID{Number: 0})
+	require.Nil(t, rpcErr)
+	withTxHashes, rpcErr := handler.BlockWithTxHashes(rpc.BlockID{Number: 0})
+	require.Nil(t, rpcErr)
+	withTxsJSON, err := json.Marshal(withTxs)
+	require.NoError(t, err)
+	withTxHashesJSON, err := json.Marshal(withTxHashes)
+	require.NoError(t, err)
+
+	t.Run("disabled cache", func(t *testing.T) {
+		handler, listener := newHandler(0)
+		mockReader.EXPECT().BlockByNumber(uint64(0)).Return(block0, nil).Times(2)
+
+		for range 2 {
+			_, rpcErr := handler.BlockWithTxs(rpc.BlockID{Number: 0})
+			require.Nil(t, rpcErr)
+		}
+		hits, misses := listener.counts()
+		assert.Zero(t, hits)
+		assert.Zero(t, misses)
+	})
+
+	t.Run("least recently used responses are evicted to make room", func(t *testing.T) {
+		handler, listener := newHandler(uint64(len(withTxsJSON) + len(withTxHashesJSON) - 1))
+		mockReader.EXPECT().BlockByNumber(uint64(0)).Return(block0, nil).Times(3)
+
+		_, rpcErr := handler.BlockWithTxs(rpc.BlockID{Number: 0})
+		require.Nil(t, rpcErr)
+		_, rpcErr = handler.BlockWithTxHashes(rpc.BlockID{Number: 0})
+		require.Nil(t, rpcErr)
+		_, rpcErr = handler.BlockWithTxHashes(rpc.BlockID{Number: 0})
+		require.Nil(t, rpcErr)
+		_, rpcErr = handler.BlockWithTxs(rpc.BlockID{Number: 0})
+		require.Nil(t, rpcErr)
+
+		hits, misses := listener.counts()
+		assert.Equal(t, 1, hits)
+		assert.Equal(t, 3, misses)
+	})
+
+	t.Run("responses larger than the cache aren't cached", func(t *testing.T) {
+		handler, listener := newHandler(uint64(len(withTxsJSON) - 1))
+		mockReader.EXPECT().BlockByNumber(uint64(0)).Return(block0, nil).Times(2)
+
+		for range 2 {
+			_, rpcErr := handler.BlockWithTxs(rpc.BlockID{Number: 0})
+			require.Nil(t, rpcErr)
+		}
+		hits, misses := listener.counts()
+		assert.Zero(t, hits)
+		assert.Equal(t, 2, misses)
+	})
+}
@@ -0,0 +1,139 @@
+package rpc
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/jsonrpc"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/NethermindEth/juno/vm"
+)
+
+// SimulationBlock is a batch of transactions that juno_simulateBlocks executes as one virtual block.
+type SimulationBlock struct {
+	Transactions  []BroadcastedTransaction `json:"transactions" validate:"dive"`
+	BlockOverride *BlockOverride           `json:"block_override,omitempty"`
+}
+
+type SimulatedBlock struct {
+	BlockNumber  uint64                 `json:"block_number"`
+	Transactions []SimulatedTransaction `json:"transactions"`
+}
+
+type SimulatedBlocks struct {
+	Blocks []SimulatedBlock `json:"blocks"`
+	// StateDiff is the combined state diff of all the simulated blocks.
+	StateDiff *StateDiff `json:"state_diff"`
+}
+
+// SimulateBlocks executes blocks as consecutive virtual blocks on top of the block identified by id. Each block
+// sees the state changes of the previous ones, and unless its block override sets one, is numbered after the
+// previous block.
+func (h *Handler) SimulateBlocks(id BlockID, blocks []SimulationBlock, simulationFlags []SimulationFlag,
+	stateOverride *StateOverride,
+) (*SimulatedBlocks, http.Header, *jsonrpc.Error) {
+	skipFeeCharge := slices.Contains(simulationFlags, SkipFeeChargeFlag)
+	skipValidate := slices.Contains(simulationFlags, SkipValidateFlag)
+
+	httpHeader := http.Header{}
+	httpHeader.Set(ExecutionStepsHeader, "0")
+
+	state, closer, rpcErr := h.stateByBlockID(&id)
+	if rpcErr != nil {
+		return nil, httpHeader, rpcErr
+	}
+	defer h.callAndLogErr(closer, "Failed to close state in juno_simulateBlocks")
+
+	state, rpcErr = applyStateOverride(state, stateOverride)
+	if rpcErr != nil {
+		return nil, httpHeader, rpcErr
+	}
+
+	header, rpcErr := h.blockHeaderByID(&id)
+	if rpcErr != nil {
+		return nil, httpHeader, rpcErr
+	}
+
+	// The changes of the blocks simulated so far are accumulated in stateDiff and newClasses, which the
+	// next block is executed on top of.
+	stateDiff := core.EmptyStateDiff()
+	newClasses := make(map[felt.Felt]core.Class)
+	simulatedState := blockchain.NewPendingState(stateDiff, newClasses, state)
+
+	result := &SimulatedBlocks{Blocks: make([]SimulatedBlock, 0, len(blocks))}
+	blockNumber := header.Number
+	var totalSteps uint64
+	for i := range blocks {
+		var blockOverride BlockOverride
+		if blocks[i].BlockOverride != nil {
+			blockOverride = *blocks[i].BlockOverride
+		}
+		if blockOverride.BlockNumber == nil {
+			blockOverride.BlockNumber = utils.Ptr(blockNumber + 1)
+		}
+		blockNumber = *blockOverride.BlockNumber
+
+		simulated, declaredClasses, numSteps, rpcErr := h.executeSimulation(simulatedState, header, &blockOverride,
+			blocks[i].Transactions, skipFeeCharge, skipValidate, false)
+		totalSteps += numSteps
+		httpHeader.Set(ExecutionStepsHeader, strconv.FormatUint(totalSteps, 10))
+		if rpcErr != nil {
+			return nil, httpHeader, rpcErr
+		}
+
+		for _, txn := range simulated {
+			if txn.TransactionTrace.StateDiff != nil {
+				mergeTraceStateDiff(stateDiff, newClasses, txn.TransactionTrace.StateDiff, declaredClasses)
+			}
+		}
+		result.Blocks = append(result.Blocks, SimulatedBlock{
+			BlockNumber:  blockNumber,
+			Transactions: simulated,
+		})
+	}
+
+	result.StateDiff = adaptStateDiff(stateDiff)
+	return result, httpHeader, nil
+}
+
+// mergeTraceStateDiff applies the state changes of a simulated transaction to stateDiff, and adds the classes it
+// declared to newClasses.
+func mergeTraceStateDiff(stateDiff *core.StateDiff, newClasses map[felt.Felt]core.Class, traceDiff *vm.StateDiff,
+	declaredClasses map[felt.Felt]core.Class,
+) {
+	for _, diff := range traceDiff.StorageDiffs {
+		storage := stateDiff.StorageDiffs[diff.Address]
+		if storage == nil {
+			storage = make(map[felt.Felt]*felt.Felt, len(diff.StorageEntries))
+			stateDiff.StorageDiffs[diff.Address] = storage
+		}
+		for _, entry := range diff.StorageEntries {
+			storage[entry.Key] = utils.Ptr(entry.Value)
+		}
+	}
+	for _, nonce := range traceDiff.Nonces {
+		stateDiff.Nonces[nonce.ContractAddress] = utils.Ptr(nonce.Nonce)
+	}
+	for _, deployed := range traceDiff.DeployedContracts {
+		stateDiff.DeployedContracts[deployed.Address] = utils.Ptr(deployed.ClassHash)
+	}
+	for _, replaced := range traceDiff.ReplacedClasses {
+		stateDiff.ReplacedClasses[replaced.ContractAddress] = utils.Ptr(replaced.ClassHash)
+	}
+	for _, classHash := range traceDiff.DeprecatedDeclaredClasses {
+		stateDiff.DeclaredV0Classes = append(stateDiff.DeclaredV0Classes, classHash)
+		if class, ok := declaredClasses[*classHash]; ok {
+			newClasses[*classHash] = class
+		}
+	}
+	for _, declared := range traceDiff.DeclaredClasses {
+		stateDiff.DeclaredV1Classes[declared.ClassHash] = utils.Ptr(declared.CompiledClassHash)
+		if class, ok := declaredClasses[declared.ClassHash]; ok {
+			newClasses[declared.ClassHash] = class
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package rpc_test
+
+import (
+	"testing"
+
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/mocks"
+	"github.com/NethermindEth/juno/rpc"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/NethermindEth/juno/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSimulateBlocks(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	t.Cleanup(mockCtrl.Finish)
+
+	n := utils.Ptr(utils.Mainnet)
+	mockReader := mocks.NewMockReader(mockCtrl)
+	mockReader.EXPECT().Network().Return(n).AnyTimes()
+	mockVM := mocks.NewMockVM(mockCtrl)
+	handler := rpc.New(mockReader, nil, mockVM, "", utils.NewNopZapLogger())
+
+	mockState := mocks.NewMockStateHistoryReader(mockCtrl)
+	mockReader.EXPECT().HeadState().Return(mockState, nopCloser, nil)
+	headsHeader := &core.Header{
+		Number:   5,
+		GasPrice: new(felt.Felt).SetUint64(1),
+	}
+	mockReader.EXPECT().HeadsHeader().Return(headsHeader, nil)
+
+	sender := utils.HexToFelt(t, "0x2")
+	storageKey := new(felt.Felt).SetUint64(3)
+	storageValue := new(felt.Felt).SetUint64(4)
+	invoke := rpc.BroadcastedTransaction{
+		Transaction: rpc.Transaction{
+			Type:          rpc.TxnInvoke,
+			Version:       utils.HexToFelt(t, "0x1"),
+			Nonce:         &felt.Zero,
+			MaxFee:        utils.HexToFelt(t, "0x1"),
+			SenderAddress: sender,
+			Signature:     &[]*felt.Felt{},
+			CallData:      &[]*felt.Felt{},
+		},
+	}
+
+	// The first block writes to storage and bumps the nonce of the sender.
+	firstTrace := vm.TransactionTrace{
+		Type: vm.TxnInvoke,
+		StateDiff: &vm.StateDiff{
+			StorageDiffs: []vm.StorageDiff{{Address: *sender, StorageEntries: []vm.Entry{{Key: *storageKey, Value: *storageValue}}}},
+			Nonces:       []vm.Nonce{{ContractAddress: *sender, Nonce: *new(felt.Felt).SetUint64(1)}},
+		},
+	}
+	mockVM.EXPECT().Execute(gomock.Any(), nil, []*felt.Felt{}, gomock.Any(), gomock.Any(), n, false, false, false).DoAndReturn(
+		func(_ []core.Transaction, _ []core.Class, _ []*felt.Felt, blockInfo *vm.BlockInfo, _ core.StateReader,
+			_ *utils.Network, _, _, _ bool,
+		) ([]*felt.Felt, []core.GasConsumed, []vm.TransactionTrace, uint64, error) {
+			assert.Equal(t, uint64(6), blockInfo.Header.Number)
+			return []*felt.Felt{new(felt.Felt).SetUint64(10)}, []core.GasConsumed{{}}, []vm.TransactionTrace{firstTrace}, 10, nil
+		})
+
+	// The second block sees the changes of the first one.
+	mockVM.EXPECT().Execute(gomock.Any(), nil, []*felt.Felt{}, gomock.Any(), gomock.Any(), n, false, false, false).DoAndReturn(
+		func(_ []core.Transaction, _ []core.Class, _ []*felt.Felt, blockInfo *vm.BlockInfo, state core.StateReader,
+			_ *utils.Network, _, _, _ bool,
+		) ([]*felt.Felt, []core.GasConsumed, []vm.TransactionTrace, uint64, error) {
+			assert.Equal(t, uint64(7), blockInfo.Header.Number)
+			assert.Equal(t, uint64(1_000), blockInfo.Header.Timestamp)
+
+			value, err := state.ContractStorage(sender, storageKey)
+			require.NoError(t, err)
+			assert.Equal(t, storageValue, value)
+			nonce, err := state.ContractNonce(sender)
+			require.NoError(t, err)
+			assert.Equal(t, new(felt.Felt).SetUint64(1), nonce)
+			return []*felt.Felt{new(felt.Felt).SetUint64(10)}, []core.GasConsumed{{}}, []vm.TransactionTrace{{Type: vm.TxnInvoke}}, 5, nil
+		})
+
+	secondInvoke := invoke
+	secondInvoke.Nonce = new(felt.Felt).SetUint64(1)
+	result, httpHeader, rpcErr := handler.SimulateBlocks(rpc.BlockID{Latest: true}, []rpc.SimulationBlock{
+		{Transactions: []rpc.BroadcastedTransaction{invoke}},
+		{Transactions: []rpc.BroadcastedTransaction{secondInvoke}, BlockOverride: &rpc.BlockOverride{Timestamp: utils.Ptr(uint64(1_000))}},
+	}, nil, nil)
+	require.Nil(t, rpcErr)
+	assert.Equal(t, "15", httpHeader.Get(rpc.ExecutionStepsHeader))
+
+	require.Len(t, result.Blocks, 2)
+	assert.Equal(t, uint64(6), result.Blocks[0].BlockNumber)
+	assert.Equal(t, uint64(7), result.Blocks[1].BlockNumber)
+	assert.Len(t, result.Blocks[1].Transactions, 1)
+
+	assert.Equal(t, []rpc.StorageDiff{{
+		Address:        *sender,
+		StorageEntries: []rpc.Entry{{Key: *storageKey, Value: *storageValue}},
+	}}, result.StateDiff.StorageDiffs)
+	assert.Equal(t, []rpc.Nonce{{ContractAddress: *sender, Nonce: *new(felt.Felt).SetUint64(1)}}, result.StateDiff.Nonces)
+}
@@ -51,14 +51,13 @@ type TracedBlockTransaction struct {
 *****************************************************/
 
 func (h *Handler) SimulateTransactions(id BlockID, transactions []BroadcastedTransaction,
-	simulationFlags []SimulationFlag,
+	simulationFlags []SimulationFlag, stateOverride *StateOverride, blockOverride *BlockOverride,
 ) ([]SimulatedTransaction, http.Header, *jsonrpc.Error) {
-	return h.simulateTransactions(id, transactions, simulationFlags, false)
+	return h.simulateTransactions(id, transactions, simulationFlags, stateOverride, blockOverride, false)
 }
 
-//nolint:funlen,gocyclo
 func (h *Handler) simulateTransactions(id BlockID, transactions []BroadcastedTransaction,
-	simulationFlags []SimulationFlag, errOnRevert bool,
+	simulationFlags []SimulationFlag, stateOverride *StateOverride, blockOverride *BlockOverride, errOnRevert bool,
 ) ([]SimulatedTransaction, http.Header, *jsonrpc.Error) {
 	skipFeeCharge := slices.Contains(simulationFlags, SkipFeeChargeFlag)
 	skipValidate := slices.Contains(simulationFlags, SkipValidateFlag)
@@ -72,19 +71,42 @@ func (h *Handler) simulateTransactions(id BlockID, transactions []BroadcastedTra
 	}
 	defer h.callAndLogErr(closer, "Failed to close state in starknet_estimateFee")
 
+	state, rpcErr = applyStateOverride(state, stateOverride)
+	if rpcErr != nil {
+		return nil, httpHeader, rpcErr
+	}
+
 	header, rpcErr := h.blockHeaderByID(&id)
 	if rpcErr != nil {
 		return nil, httpHeader, rpcErr
 	}
 
+	result, _, numSteps, rpcErr := h.executeSimulation(state, header, blockOverride, transactions,
+		skipFeeCharge, skipValidate, errOnRevert)
+	httpHeader.Set(ExecutionStepsHeader, strconv.FormatUint(numSteps, 10))
+	if rpcErr != nil {
+		return nil, httpHeader, rpcErr
+	}
+	return result, httpHeader, nil
+}
+
+// executeSimulation executes transactions on top of state, in the block context of header with blockOverride
+// applied. Along with the simulated transactions and the number of steps used, it returns the classes declared
+// by the transactions.
+//
+//nolint:funlen,gocyclo
+func (h *Handler) executeSimulation(state core.StateReader, header *core.Header, blockOverride *BlockOverride,
+	transactions []BroadcastedTransaction, skipFeeCharge, skipValidate, errOnRevert bool,
+) ([]SimulatedTransaction, map[felt.Felt]core.Class, uint64, *jsonrpc.Error) {
 	txns := make([]core.Transaction, 0, len(transactions))
 	var classes []core.Class
+	declaredClasses := make(map[felt.Felt]core.Class)
 
 	paidFeesOnL1 := make([]*felt.Felt, 0)
 	for idx := range transactions {
 		txn, declaredClass, paidFeeOnL1, aErr := adaptBroadcastedTransaction(&transactions[idx], h.bcReader.Network())
 		if aErr != nil {
-			return nil, httpHeader, jsonrpc.Err(jsonrpc.InvalidParams, aErr.Error())
+			return nil, nil, 0, jsonrpc.Err(jsonrpc.InvalidParams, aErr.Error())
 		}
 
 		if paidFeeOnL1 != nil {
@@ -94,31 +116,29 @@ func (h *Handler) simulateTransactions(id BlockID, transactions []BroadcastedTra
 		txns = append(txns, txn)
 		if declaredClass != nil {
 			classes = append(classes, declaredClass)
+			if declareTxn, ok := txn.(*core.DeclareTransaction); ok {
+				declaredClasses[*declareTxn.ClassHash] = declaredClass
+			}
 		}
 	}
 
-	blockHashToBeRevealed, err := h.getRevealedBlockHash(header.Number)
+	blockInfo, err := h.blockInfo(header, blockOverride)
 	if err != nil {
-		return nil, httpHeader, ErrInternal.CloneWithData(err)
-	}
-	blockInfo := vm.BlockInfo{
-		Header:                header,
-		BlockHashToBeRevealed: blockHashToBeRevealed,
+		return nil, nil, 0, ErrInternal.CloneWithData(err)
 	}
-	overallFees, daGas, traces, numSteps, err := h.vm.Execute(txns, classes, paidFeesOnL1, &blockInfo,
+	// Fees are estimated with the gas prices of the block the transactions are executed in.
+	header = blockInfo.Header
+	overallFees, daGas, traces, numSteps, err := h.vm.Execute(txns, classes, paidFeesOnL1, blockInfo,
 		state, h.bcReader.Network(), skipFeeCharge, skipValidate, errOnRevert)
-
-	httpHeader.Set(ExecutionStepsHeader, strconv.FormatUint(numSteps, 10))
-
 	if err != nil {
 		if errors.Is(err, utils.ErrResourceBusy) {
-			return nil, httpHeader, ErrInternal.CloneWithData(throttledVMErr)
+			return nil, nil, numSteps, ErrInternal.CloneWithData(throttledVMErr)
 		}
 		var txnExecutionError vm.TransactionExecutionError
 		if errors.As(err, &txnExecutionError) {
-			return nil, httpHeader, makeTransactionExecutionError(&txnExecutionError)
+			return nil, nil, numSteps, makeTransactionExecutionError(&txnExecutionError)
 		}
-		return nil, httpHeader, ErrUnexpectedError.CloneWithData(err.Error())
+		return nil, nil, numSteps, ErrUnexpectedError.CloneWithData(err.Error())
 	}
 
 	result := make([]SimulatedTransaction, 0, len(overallFees))
@@ -171,7 +191,7 @@ func (h *Handler) simulateTransactions(id BlockID, transactions []BroadcastedTra
 		})
 	}
 
-	return result, httpHeader, nil
+	return result, declaredClasses, numSteps, nil
 }
 
 type TransactionExecutionErrorData struct {
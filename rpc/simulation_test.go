@@ -6,6 +6,7 @@ import (
 
 	"github.com/NethermindEth/juno/core"
 	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db"
 	"github.com/NethermindEth/juno/mocks"
 	"github.com/NethermindEth/juno/rpc"
 	"github.com/NethermindEth/juno/utils"
@@ -41,7 +42,7 @@ func TestSimulateTransactions(t *testing.T) {
 		}, mockState, n, true, false, false).
 			Return([]*felt.Felt{}, []core.GasConsumed{}, []vm.TransactionTrace{}, stepsUsed, nil)
 
-		_, httpHeader, err := handler.SimulateTransactions(rpc.BlockID{Latest: true}, []rpc.BroadcastedTransaction{}, []rpc.SimulationFlag{rpc.SkipFeeChargeFlag})
+		_, httpHeader, err := handler.SimulateTransactions(rpc.BlockID{Latest: true}, []rpc.BroadcastedTransaction{}, []rpc.SimulationFlag{rpc.SkipFeeChargeFlag}, nil, nil)
 		require.Nil(t, err)
 		assert.Equal(t, httpHeader.Get(rpc.ExecutionStepsHeader), "123")
 	})
@@ -53,7 +54,7 @@ func TestSimulateTransactions(t *testing.T) {
 		}, mockState, n, false, true, false).
 			Return([]*felt.Felt{}, []core.GasConsumed{}, []vm.TransactionTrace{}, stepsUsed, nil)
 
-		_, httpHeader, err := handler.SimulateTransactions(rpc.BlockID{Latest: true}, []rpc.BroadcastedTransaction{}, []rpc.SimulationFlag{rpc.SkipValidateFlag})
+		_, httpHeader, err := handler.SimulateTransactions(rpc.BlockID{Latest: true}, []rpc.BroadcastedTransaction{}, []rpc.SimulationFlag{rpc.SkipValidateFlag}, nil, nil)
 		require.Nil(t, err)
 		assert.Equal(t, httpHeader.Get(rpc.ExecutionStepsHeader), "123")
 	})
@@ -68,7 +69,7 @@ func TestSimulateTransactions(t *testing.T) {
 					Cause: errors.New("oops"),
 				})
 
-			_, httpHeader, err := handler.SimulateTransactions(rpc.BlockID{Latest: true}, []rpc.BroadcastedTransaction{}, []rpc.SimulationFlag{rpc.SkipValidateFlag})
+			_, httpHeader, err := handler.SimulateTransactions(rpc.BlockID{Latest: true}, []rpc.BroadcastedTransaction{}, []rpc.SimulationFlag{rpc.SkipValidateFlag}, nil, nil)
 			require.Equal(t, rpc.ErrTransactionExecutionError.CloneWithData(rpc.TransactionExecutionErrorData{
 				TransactionIndex: 44,
 				ExecutionError:   "oops",
@@ -76,4 +77,36 @@ func TestSimulateTransactions(t *testing.T) {
 			require.Equal(t, httpHeader.Get(rpc.ExecutionStepsHeader), "0")
 		})
 	})
+
+	t.Run("block override", func(t *testing.T) {
+		blockNumber := uint64(100)
+		timestamp := uint64(1_700_000_000)
+		gasPrice := new(felt.Felt).SetUint64(5)
+		dataGasPrice := new(felt.Felt).SetUint64(6)
+		blobMode := rpc.Blob
+		// The block whose hash is revealed to the overridden block doesn't exist yet.
+		mockReader.EXPECT().BlockHeaderByNumber(blockNumber-10).Return(nil, db.ErrKeyNotFound)
+
+		overriddenHeader := *headsHeader
+		overriddenHeader.Number = blockNumber
+		overriddenHeader.Timestamp = timestamp
+		overriddenHeader.GasPriceSTRK = gasPrice
+		overriddenHeader.L1DataGasPrice = &core.GasPrice{PriceInFri: dataGasPrice}
+		overriddenHeader.L1DAMode = core.Blob
+		overriddenHeader.ProtocolVersion = "0.14.0"
+		mockVM.EXPECT().Execute([]core.Transaction{}, nil, []*felt.Felt{}, &vm.BlockInfo{
+			Header: &overriddenHeader,
+		}, mockState, n, false, false, false).
+			Return([]*felt.Felt{}, []core.GasConsumed{}, []vm.TransactionTrace{}, uint64(0), nil)
+
+		_, _, err := handler.SimulateTransactions(rpc.BlockID{Latest: true}, []rpc.BroadcastedTransaction{}, nil, nil, &rpc.BlockOverride{
+			BlockNumber:     &blockNumber,
+			Timestamp:       &timestamp,
+			L1GasPrice:      &rpc.ResourcePrice{InFri: gasPrice},
+			L1DataGasPrice:  &rpc.ResourcePrice{InFri: dataGasPrice},
+			L1DAMode:        &blobMode,
+			StarknetVersion: utils.Ptr("0.14.0"),
+		})
+		require.Nil(t, err)
+	})
 }
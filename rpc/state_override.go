@@ -0,0 +1,83 @@
+package rpc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/jsonrpc"
+)
+
+// StateOverride changes the state that calls and transactions are executed against, without modifying the
+// node's state. It is accepted as an optional last parameter by starknet_call, starknet_estimateFee and
+// starknet_simulateTransactions.
+type StateOverride struct {
+	Contracts []ContractOverride `json:"contracts,omitempty"`
+	// Classes are made available as if they had been declared, so that a contract can be pointed to a class
+	// that doesn't exist on chain yet.
+	Classes []json.RawMessage `json:"classes,omitempty"`
+}
+
+// ContractOverride replaces the class hash, nonce or storage slots of a contract. Overriding the class hash of
+// an address that has no contract deploys one there, with a zero nonce and empty storage.
+type ContractOverride struct {
+	Address   felt.Felt  `json:"address"`
+	ClassHash *felt.Felt `json:"class_hash,omitempty"`
+	Nonce     *felt.Felt `json:"nonce,omitempty"`
+	Storage   []Entry    `json:"storage,omitempty"`
+}
+
+// applyStateOverride layers stateOverride over state. It returns state unchanged if there is nothing to override.
+func applyStateOverride(state core.StateReader, stateOverride *StateOverride) (core.StateReader, *jsonrpc.Error) {
+	if stateOverride == nil || (len(stateOverride.Contracts) == 0 && len(stateOverride.Classes) == 0) {
+		return state, nil
+	}
+
+	newClasses := make(map[felt.Felt]core.Class, len(stateOverride.Classes))
+	for i, classDefinition := range stateOverride.Classes {
+		class, err := adaptDeclaredClass(classDefinition)
+		if err != nil {
+			return nil, jsonrpc.Err(jsonrpc.InvalidParams, fmt.Sprintf("invalid class at index %d: %v", i, err))
+		}
+		classHash, err := class.Hash()
+		if err != nil {
+			return nil, jsonrpc.Err(jsonrpc.InvalidParams, fmt.Sprintf("invalid class at index %d: %v", i, err))
+		}
+		newClasses[*classHash] = class
+	}
+
+	stateDiff := core.EmptyStateDiff()
+	for i := range stateOverride.Contracts {
+		contract := &stateOverride.Contracts[i]
+		if contract.ClassHash != nil {
+			_, err := state.ContractClassHash(&contract.Address)
+			switch {
+			case err == nil:
+				stateDiff.ReplacedClasses[contract.Address] = contract.ClassHash
+			case errors.Is(err, db.ErrKeyNotFound):
+				stateDiff.DeployedContracts[contract.Address] = contract.ClassHash
+			default:
+				return nil, ErrInternal.CloneWithData(err)
+			}
+		}
+		if contract.Nonce != nil {
+			stateDiff.Nonces[contract.Address] = contract.Nonce
+		}
+		if len(contract.Storage) > 0 {
+			storage := stateDiff.StorageDiffs[contract.Address]
+			if storage == nil {
+				storage = make(map[felt.Felt]*felt.Felt, len(contract.Storage))
+				stateDiff.StorageDiffs[contract.Address] = storage
+			}
+			for j := range contract.Storage {
+				storage[contract.Storage[j].Key] = &contract.Storage[j].Value
+			}
+		}
+	}
+
+	return blockchain.NewPendingState(stateDiff, newClasses, state), nil
+}
@@ -66,6 +66,12 @@ type DeclaredClass struct {
 // It follows the specification defined here:
 // https://github.com/starkware-libs/starknet-specs/blob/master/api/starknet_api_openrpc.json#L77
 func (h *Handler) StateUpdate(id BlockID) (*StateUpdate, *jsonrpc.Error) {
+	return cachedResponse(h, blockResponseCacheKey("starknet_getStateUpdate", &id, nil),
+		func() (*StateUpdate, *jsonrpc.Error) { return h.stateUpdate(id) },
+		func(*StateUpdate) bool { return h.isBlockL1Verified(&id) })
+}
+
+func (h *Handler) stateUpdate(id BlockID) (*StateUpdate, *jsonrpc.Error) {
 	var update *core.StateUpdate
 	var err error
 	if id.Latest {
@@ -92,13 +98,22 @@ func (h *Handler) StateUpdate(id BlockID) (*StateUpdate, *jsonrpc.Error) {
 		return nil, ErrInternal.CloneWithData(err)
 	}
 
-	nonces := make([]Nonce, 0, len(update.StateDiff.Nonces))
-	for addr, nonce := range update.StateDiff.Nonces {
+	return &StateUpdate{
+		BlockHash: update.BlockHash,
+		OldRoot:   update.OldRoot,
+		NewRoot:   update.NewRoot,
+		StateDiff: adaptStateDiff(update.StateDiff),
+	}, nil
+}
+
+func adaptStateDiff(stateDiff *core.StateDiff) *StateDiff {
+	nonces := make([]Nonce, 0, len(stateDiff.Nonces))
+	for addr, nonce := range stateDiff.Nonces {
 		nonces = append(nonces, Nonce{ContractAddress: addr, Nonce: *nonce})
 	}
 
-	storageDiffs := make([]StorageDiff, 0, len(update.StateDiff.StorageDiffs))
-	for addr, diffs := range update.StateDiff.StorageDiffs {
+	storageDiffs := make([]StorageDiff, 0, len(stateDiff.StorageDiffs))
+	for addr, diffs := range stateDiff.StorageDiffs {
 		entries := make([]Entry, 0, len(diffs))
 		for key, value := range diffs {
 			entries = append(entries, Entry{
@@ -113,41 +128,36 @@ func (h *Handler) StateUpdate(id BlockID) (*StateUpdate, *jsonrpc.Error) {
 		})
 	}
 
-	deployedContracts := make([]DeployedContract, 0, len(update.StateDiff.DeployedContracts))
-	for addr, classHash := range update.StateDiff.DeployedContracts {
+	deployedContracts := make([]DeployedContract, 0, len(stateDiff.DeployedContracts))
+	for addr, classHash := range stateDiff.DeployedContracts {
 		deployedContracts = append(deployedContracts, DeployedContract{
 			Address:   addr,
 			ClassHash: *classHash,
 		})
 	}
 
-	declaredClasses := make([]DeclaredClass, 0, len(update.StateDiff.DeclaredV1Classes))
-	for classHash, compiledClassHash := range update.StateDiff.DeclaredV1Classes {
+	declaredClasses := make([]DeclaredClass, 0, len(stateDiff.DeclaredV1Classes))
+	for classHash, compiledClassHash := range stateDiff.DeclaredV1Classes {
 		declaredClasses = append(declaredClasses, DeclaredClass{
 			ClassHash:         classHash,
 			CompiledClassHash: *compiledClassHash,
 		})
 	}
 
-	replacedClasses := make([]ReplacedClass, 0, len(update.StateDiff.ReplacedClasses))
-	for addr, classHash := range update.StateDiff.ReplacedClasses {
+	replacedClasses := make([]ReplacedClass, 0, len(stateDiff.ReplacedClasses))
+	for addr, classHash := range stateDiff.ReplacedClasses {
 		replacedClasses = append(replacedClasses, ReplacedClass{
 			ClassHash:       *classHash,
 			ContractAddress: addr,
 		})
 	}
 
-	return &StateUpdate{
-		BlockHash: update.BlockHash,
-		OldRoot:   update.OldRoot,
-		NewRoot:   update.NewRoot,
-		StateDiff: &StateDiff{
-			DeprecatedDeclaredClasses: update.StateDiff.DeclaredV0Classes,
-			DeclaredClasses:           declaredClasses,
-			ReplacedClasses:           replacedClasses,
-			Nonces:                    nonces,
-			StorageDiffs:              storageDiffs,
-			DeployedContracts:         deployedContracts,
-		},
-	}, nil
+	return &StateDiff{
+		DeprecatedDeclaredClasses: stateDiff.DeclaredV0Classes,
+		DeclaredClasses:           declaredClasses,
+		ReplacedClasses:           replacedClasses,
+		Nonces:                    nonces,
+		StorageDiffs:              storageDiffs,
+		DeployedContracts:         deployedContracts,
+	}
 }
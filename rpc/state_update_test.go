@@ -8,6 +8,7 @@ import (
 	"github.com/NethermindEth/juno/clients/feeder"
 	"github.com/NethermindEth/juno/core"
 	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db"
 	"github.com/NethermindEth/juno/db/pebble"
 	"github.com/NethermindEth/juno/mocks"
 	"github.com/NethermindEth/juno/rpc"
@@ -40,6 +41,8 @@ func TestStateUpdate(t *testing.T) {
 
 	mockCtrl := gomock.NewController(t)
 	mockReader := mocks.NewMockReader(mockCtrl)
+	// Without an L1 head no state update is final, so none is cached.
+	mockReader.EXPECT().L1Head().Return(nil, db.ErrKeyNotFound).AnyTimes()
 	handler := rpc.New(mockReader, nil, nil, "", nil)
 
 	client := feeder.NewTestClient(t, n)
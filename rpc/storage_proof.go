@@ -0,0 +1,293 @@
+package rpc
+
+import (
+	"errors"
+
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/core/trie"
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/jsonrpc"
+)
+
+// storageProofAttempts is the number of times that StorageProof reads the head's state before giving up on the head
+// moving.
+const storageProofAttempts = 3
+
+type StorageKeys struct {
+	Contract *felt.Felt  `json:"contract_address"`
+	Keys     []felt.Felt `json:"storage_keys"`
+}
+
+// MerkleNode is either a [BinaryNode] or an [EdgeNode].
+//
+// https://github.com/starkware-libs/starknet-specs/blob/c2e93098b9c2ca0423b7f4d15b201f52f22d8c36/api/starknet_api_openrpc.json#L3993
+type MerkleNode interface {
+	merkleNode()
+}
+
+type BinaryNode struct {
+	Left  *felt.Felt `json:"left"`
+	Right *felt.Felt `json:"right"`
+}
+
+func (*BinaryNode) merkleNode() {}
+
+type EdgeNode struct {
+	Path   *felt.Felt `json:"path"`
+	Length uint8      `json:"length"`
+	Child  *felt.Felt `json:"child"`
+}
+
+func (*EdgeNode) merkleNode() {}
+
+type HashToNode struct {
+	Hash *felt.Felt `json:"node_hash"`
+	Node MerkleNode `json:"node"`
+}
+
+type LeafData struct {
+	Nonce       *felt.Felt `json:"nonce"`
+	ClassHash   *felt.Felt `json:"class_hash"`
+	StorageRoot *felt.Felt `json:"storage_root"`
+}
+
+type ContractProof struct {
+	Nodes      []*HashToNode `json:"nodes"`
+	LeavesData []*LeafData   `json:"contract_leaves_data"`
+}
+
+type GlobalRoots struct {
+	ContractsTreeRoot *felt.Felt `json:"contracts_tree_root"`
+	ClassesTreeRoot   *felt.Felt `json:"classes_tree_root"`
+	BlockHash         *felt.Felt `json:"block_hash"`
+}
+
+type StorageProofResult struct {
+	ClassesProof           []*HashToNode   `json:"classes_proof"`
+	ContractsProof         *ContractProof  `json:"contracts_proof"`
+	ContractsStorageProofs [][]*HashToNode `json:"contracts_storage_proofs"`
+	GlobalRoots            *GlobalRoots    `json:"global_roots"`
+}
+
+/****************************************************
+		Storage Proof Handlers
+*****************************************************/
+
+// StorageProof returns the Merkle paths in the classes trie, the contracts trie and the storage tries of the
+// given contracts, together with the roots they lead to. Only proofs against the latest block are supported,
+// since the tries of older blocks are not kept.
+//
+// It follows the specification defined here:
+// https://github.com/starkware-libs/starknet-specs/blob/c2e93098b9c2ca0423b7f4d15b201f52f22d8c36/api/starknet_api_openrpc.json#L910
+func (h *Handler) StorageProof(id BlockID, classes, contracts []felt.Felt, storageKeys []StorageKeys) (
+	*StorageProofResult, *jsonrpc.Error,
+) {
+	if id.Pending {
+		return nil, ErrCallOnPending
+	}
+
+	// The state and the head are read separately, and a block stored in between makes them disagree, read them
+	// again then.
+	for range storageProofAttempts {
+		proof, headMoved, rpcErr := h.storageProof(&id, classes, contracts, storageKeys)
+		if !headMoved {
+			return proof, rpcErr
+		}
+	}
+	return nil, ErrInternal.CloneWithData("head moved while reading its state")
+}
+
+// storageProof returns the proofs against the head's state, and whether the head moved while reading it.
+func (h *Handler) storageProof(id *BlockID, classes, contracts []felt.Felt, storageKeys []StorageKeys) (
+	*StorageProofResult, bool, *jsonrpc.Error,
+) {
+	state, closer, err := h.bcReader.HeadState()
+	if err != nil {
+		return nil, false, ErrInternal.CloneWithData(err)
+	}
+	defer h.callAndLogErr(closer, "Error closing state reader in getStorageProof")
+
+	trieReader, ok := state.(core.TrieReader)
+	if !ok {
+		return nil, false, ErrStorageProofNotSupported
+	}
+
+	classTrie, err := trieReader.ClassTrie()
+	if err != nil {
+		return nil, false, ErrInternal.CloneWithData(err)
+	}
+	contractTrie, err := trieReader.ContractTrie()
+	if err != nil {
+		return nil, false, ErrInternal.CloneWithData(err)
+	}
+	classesRoot, err := classTrie.Root()
+	if err != nil {
+		return nil, false, ErrInternal.CloneWithData(err)
+	}
+	contractsRoot, err := contractTrie.Root()
+	if err != nil {
+		return nil, false, ErrInternal.CloneWithData(err)
+	}
+
+	// The head is read after the state, so it may be a later block if one was stored in between.
+	head, err := h.bcReader.HeadsHeader()
+	if err != nil {
+		if errors.Is(err, db.ErrKeyNotFound) {
+			return nil, false, ErrBlockNotFound
+		}
+		return nil, false, ErrInternal.CloneWithData(err)
+	}
+	if !core.StateCommitment(contractsRoot, classesRoot).Equal(head.GlobalStateRoot) {
+		return nil, true, nil
+	}
+
+	if !id.Latest {
+		header, rpcErr := h.blockHeaderByID(id)
+		if rpcErr != nil {
+			return nil, false, rpcErr
+		}
+		if header.Number != head.Number {
+			return nil, false, ErrStorageProofNotSupported
+		}
+	}
+
+	classProof, err := getProofs(classTrie, classes)
+	if err != nil {
+		return nil, false, ErrInternal.CloneWithData(err)
+	}
+	contractProof, err := getContractProof(contractTrie, state, trieReader, contracts)
+	if err != nil {
+		return nil, false, ErrInternal.CloneWithData(err)
+	}
+	contractStorageProofs, err := getContractStorageProofs(trieReader, storageKeys)
+	if err != nil {
+		return nil, false, ErrInternal.CloneWithData(err)
+	}
+
+	return &StorageProofResult{
+		ClassesProof:           classProof,
+		ContractsProof:         contractProof,
+		ContractsStorageProofs: contractStorageProofs,
+		GlobalRoots: &GlobalRoots{
+			ContractsTreeRoot: contractsRoot,
+			ClassesTreeRoot:   classesRoot,
+			BlockHash:         head.Hash,
+		},
+	}, false, nil
+}
+
+func getContractProof(tr *trie.Trie, state core.StateReader, trieReader core.TrieReader, contracts []felt.Felt) (
+	*ContractProof, error,
+) {
+	nodes, err := getProofs(tr, contracts)
+	if err != nil {
+		return nil, err
+	}
+
+	leavesData := make([]*LeafData, len(contracts))
+	for i := range contracts {
+		leafData, err := getLeafData(state, trieReader, &contracts[i])
+		if err != nil {
+			return nil, err
+		}
+		leavesData[i] = leafData
+	}
+
+	return &ContractProof{
+		Nodes:      nodes,
+		LeavesData: leavesData,
+	}, nil
+}
+
+// getLeafData returns the preimage of the leaf of the contract at addr, or nil if the contract doesn't exist.
+func getLeafData(state core.StateReader, trieReader core.TrieReader, addr *felt.Felt) (*LeafData, error) {
+	classHash, err := state.ContractClassHash(addr)
+	if err != nil {
+		if errors.Is(err, db.ErrKeyNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	nonce, err := state.ContractNonce(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	storageTrie, err := trieReader.ContractStorageTrie(addr)
+	if err != nil {
+		return nil, err
+	}
+	storageRoot, err := storageTrie.Root()
+	if err != nil {
+		return nil, err
+	}
+
+	return &LeafData{
+		Nonce:       nonce,
+		ClassHash:   classHash,
+		StorageRoot: storageRoot,
+	}, nil
+}
+
+func getContractStorageProofs(trieReader core.TrieReader, storageKeys []StorageKeys) ([][]*HashToNode, error) {
+	proofs := make([][]*HashToNode, len(storageKeys))
+	for i, storageKey := range storageKeys {
+		storageTrie, err := trieReader.ContractStorageTrie(storageKey.Contract)
+		if err != nil {
+			return nil, err
+		}
+
+		proofs[i], err = getProofs(storageTrie, storageKey.Keys)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return proofs, nil
+}
+
+// getProofs returns the union of the proofs of all keys in tr, with every node listed once.
+func getProofs(tr *trie.Trie, keys []felt.Felt) ([]*HashToNode, error) {
+	hashFunc := tr.HashFunc()
+	seen := make(map[felt.Felt]struct{})
+	nodes := []*HashToNode{}
+	for i := range keys {
+		key := tr.FeltToKey(&keys[i])
+		proof, err := trie.GetProof(&key, tr)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, node := range proof {
+			hash := node.Hash(hashFunc)
+			if _, ok := seen[*hash]; ok {
+				continue
+			}
+			seen[*hash] = struct{}{}
+			nodes = append(nodes, &HashToNode{
+				Hash: hash,
+				Node: adaptProofNode(node),
+			})
+		}
+	}
+	return nodes, nil
+}
+
+func adaptProofNode(node trie.ProofNode) MerkleNode {
+	switch n := node.(type) {
+	case *trie.Binary:
+		return &BinaryNode{
+			Left:  n.LeftHash,
+			Right: n.RightHash,
+		}
+	case *trie.Edge:
+		path := n.Path.Felt()
+		return &EdgeNode{
+			Path:   &path,
+			Length: n.Path.Len(),
+			Child:  n.Child,
+		}
+	}
+	panic("unknown proof node type")
+}
@@ -0,0 +1,192 @@
+package rpc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/clients/feeder"
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/crypto"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/core/trie"
+	"github.com/NethermindEth/juno/db/pebble"
+	"github.com/NethermindEth/juno/rpc"
+	adaptfeeder "github.com/NethermindEth/juno/starknetdata/feeder"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageProof(t *testing.T) {
+	n := utils.Ptr(utils.Sepolia)
+	chain := blockchain.New(pebble.NewMemTest(t), n)
+	gw := adaptfeeder.New(feeder.NewTestClient(t, n))
+
+	var head *core.Block
+	// the latest value of every storage slot written by the stored blocks
+	storage := make(map[felt.Felt]map[felt.Felt]*felt.Felt)
+	for i := range uint64(3) {
+		b, err := gw.BlockByNumber(context.Background(), i)
+		require.NoError(t, err)
+		s, err := gw.StateUpdate(context.Background(), i)
+		require.NoError(t, err)
+		require.NoError(t, chain.Store(b, &core.BlockCommitments{}, s, nil))
+		head = b
+
+		for addr, diff := range s.StateDiff.StorageDiffs {
+			if storage[addr] == nil {
+				storage[addr] = make(map[felt.Felt]*felt.Felt)
+			}
+			for key, value := range diff {
+				storage[addr][key] = value
+			}
+		}
+	}
+	require.NotEmpty(t, storage)
+
+	handler := rpc.New(chain, nil, nil, "", utils.NewNopZapLogger())
+
+	t.Run("pending block", func(t *testing.T) {
+		proof, rpcErr := handler.StorageProof(rpc.BlockID{Pending: true}, nil, nil, nil)
+		assert.Nil(t, proof)
+		assert.Equal(t, rpc.ErrCallOnPending, rpcErr)
+	})
+
+	t.Run("historical block", func(t *testing.T) {
+		proof, rpcErr := handler.StorageProof(rpc.BlockID{Number: 0}, nil, nil, nil)
+		assert.Nil(t, proof)
+		assert.Equal(t, rpc.ErrStorageProofNotSupported, rpcErr)
+	})
+
+	t.Run("non-existent block", func(t *testing.T) {
+		proof, rpcErr := handler.StorageProof(rpc.BlockID{Number: head.Number + 1}, nil, nil, nil)
+		assert.Nil(t, proof)
+		assert.Equal(t, rpc.ErrBlockNotFound, rpcErr)
+	})
+
+	for name, id := range map[string]rpc.BlockID{
+		"latest":       {Latest: true},
+		"head by hash": {Hash: head.Hash},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var contracts []felt.Felt
+			var storageKeys []rpc.StorageKeys
+			for addr, diff := range storage {
+				contracts = append(contracts, addr)
+				keys := make([]felt.Felt, 0, len(diff))
+				for key := range diff {
+					keys = append(keys, key)
+				}
+				storageKeys = append(storageKeys, rpc.StorageKeys{Contract: utils.Ptr(addr), Keys: keys})
+			}
+
+			proof, rpcErr := handler.StorageProof(id, nil, contracts, storageKeys)
+			require.Nil(t, rpcErr)
+
+			roots := proof.GlobalRoots
+			assert.Equal(t, head.Hash, roots.BlockHash)
+			if roots.ClassesTreeRoot.IsZero() {
+				assert.Equal(t, head.GlobalStateRoot, roots.ContractsTreeRoot)
+			} else {
+				stateVersion := new(felt.Felt).SetBytes([]byte(`STARKNET_STATE_V0`))
+				assert.Equal(t, head.GlobalStateRoot, crypto.PoseidonArray(stateVersion, roots.ContractsTreeRoot, roots.ClassesTreeRoot))
+			}
+
+			require.Len(t, proof.ContractsProof.LeavesData, len(contracts))
+			require.Len(t, proof.ContractsStorageProofs, len(storageKeys))
+			for i := range contracts {
+				leaf := proof.ContractsProof.LeavesData[i]
+				require.NotNil(t, leaf)
+				commitment := crypto.Pedersen(crypto.Pedersen(crypto.Pedersen(leaf.ClassHash, leaf.StorageRoot), leaf.Nonce), &felt.Zero)
+				assert.True(t, verifyProof(roots.ContractsTreeRoot, &contracts[i], commitment, proof.ContractsProof.Nodes))
+
+				for _, key := range storageKeys[i].Keys {
+					value := storage[contracts[i]][key]
+					assert.True(t, verifyProof(leaf.StorageRoot, &key, value, proof.ContractsStorageProofs[i]))
+				}
+			}
+		})
+	}
+}
+
+// movingHeadReader reports a head that moved on from its state the first moves times that it's read.
+type movingHeadReader struct {
+	*blockchain.Blockchain
+	moves int
+}
+
+func (r *movingHeadReader) HeadsHeader() (*core.Header, error) {
+	header, err := r.Blockchain.HeadsHeader()
+	if err != nil || r.moves == 0 {
+		return header, err
+	}
+	r.moves--
+	moved := *header
+	moved.Number++
+	moved.GlobalStateRoot = new(felt.Felt).SetUint64(1)
+	return &moved, nil
+}
+
+func TestStorageProofMovingHead(t *testing.T) {
+	n := utils.Ptr(utils.Sepolia)
+	chain := blockchain.New(pebble.NewMemTest(t), n)
+	gw := adaptfeeder.New(feeder.NewTestClient(t, n))
+	b, err := gw.BlockByNumber(context.Background(), 0)
+	require.NoError(t, err)
+	s, err := gw.StateUpdate(context.Background(), 0)
+	require.NoError(t, err)
+	require.NoError(t, chain.Store(b, &core.BlockCommitments{}, s, nil))
+
+	t.Run("the head moves once", func(t *testing.T) {
+		handler := rpc.New(&movingHeadReader{Blockchain: chain, moves: 1}, nil, nil, "", utils.NewNopZapLogger())
+		proof, rpcErr := handler.StorageProof(rpc.BlockID{Latest: true}, nil, nil, nil)
+		require.Nil(t, rpcErr)
+		assert.Equal(t, b.Hash, proof.GlobalRoots.BlockHash)
+	})
+
+	t.Run("the head keeps moving", func(t *testing.T) {
+		handler := rpc.New(&movingHeadReader{Blockchain: chain, moves: 3}, nil, nil, "", utils.NewNopZapLogger())
+		proof, rpcErr := handler.StorageProof(rpc.BlockID{Latest: true}, nil, nil, nil)
+		assert.Nil(t, proof)
+		require.NotNil(t, rpcErr)
+		assert.Equal(t, rpc.ErrInternal.Code, rpcErr.Code)
+	})
+}
+
+// verifyProof walks the proof of key from root, picking the nodes it needs out of the unordered set of nodes.
+func verifyProof(root, key, value *felt.Felt, nodes []*rpc.HashToNode) bool {
+	byHash := make(map[felt.Felt]rpc.MerkleNode, len(nodes))
+	for _, node := range nodes {
+		byHash[*node.Hash] = node.Node
+	}
+
+	keyBytes := key.Bytes()
+	path := trie.NewKey(core.ContractStorageTrieHeight, keyBytes[:])
+	var proof []trie.ProofNode
+	for hash := root; ; {
+		node, ok := byHash[*hash]
+		if !ok {
+			break
+		}
+		switch n := node.(type) {
+		case *rpc.BinaryNode:
+			proof = append(proof, &trie.Binary{LeftHash: n.Left, RightHash: n.Right})
+			if path.Test(path.Len() - 1) {
+				hash = n.Right
+			} else {
+				hash = n.Left
+			}
+			path.RemoveLastBit()
+		case *rpc.EdgeNode:
+			pathBytes := n.Path.Bytes()
+			edgePath := trie.NewKey(n.Length, pathBytes[:])
+			proof = append(proof, &trie.Edge{Path: &edgePath, Child: n.Child})
+			hash = n.Child
+			path.Truncate(path.Len() - n.Length)
+		}
+	}
+
+	keyPath := trie.NewKey(core.ContractStorageTrieHeight, keyBytes[:])
+	return trie.VerifyProof(root, &keyPath, value, proof, crypto.Pedersen)
+}
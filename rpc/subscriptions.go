@@ -0,0 +1,496 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	stdsync "sync"
+
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/jsonrpc"
+	"github.com/NethermindEth/juno/starknet"
+	"github.com/NethermindEth/juno/sync"
+)
+
+const subscribeEventsChunkSize = 1024
+
+// https://github.com/starkware-libs/starknet-specs/blob/c2e93098b9c2ca0423b7f4d15b201f52f22d8c36/api/starknet_ws_api.json#L292
+type ReorgEvent struct {
+	StartBlockHash *felt.Felt `json:"starting_block_hash"`
+	StartBlockNum  uint64     `json:"starting_block_number"`
+	EndBlockHash   *felt.Felt `json:"ending_block_hash"`
+	EndBlockNum    uint64     `json:"ending_block_number"`
+}
+
+// https://github.com/starkware-libs/starknet-specs/blob/c2e93098b9c2ca0423b7f4d15b201f52f22d8c36/api/starknet_ws_api.json#L257
+type NewTransactionStatus struct {
+	TransactionHash *felt.Felt         `json:"transaction_hash"`
+	Status          *TransactionStatus `json:"status"`
+}
+
+/****************************************************
+		Subscription Handlers
+*****************************************************/
+
+// SubscribeEvents creates a WebSocket stream which will fire events for new Starknet events with applied filters.
+// If blockID is set, matching events from that block up to the current head are sent first.
+//
+// It follows the specification defined here:
+// https://github.com/starkware-libs/starknet-specs/blob/c2e93098b9c2ca0423b7f4d15b201f52f22d8c36/api/starknet_ws_api.json#L59
+func (h *Handler) SubscribeEvents(ctx context.Context, fromAddr *felt.Felt, keys [][]felt.Felt,
+	blockID *BlockID,
+) (uint64, *jsonrpc.Error) {
+	w, ok := jsonrpc.ConnFromContext(ctx)
+	if !ok {
+		return 0, jsonrpc.Err(jsonrpc.MethodNotFound, nil)
+	}
+
+	lenKeys := len(keys)
+	for _, k := range keys {
+		lenKeys += len(k)
+	}
+	if lenKeys > maxEventFilterKeys {
+		return 0, ErrTooManyKeysInFilter
+	}
+
+	var fromHeader *core.Header
+	if blockID != nil {
+		if blockID.Pending {
+			return 0, ErrCallOnPending
+		}
+
+		var rpcErr *jsonrpc.Error
+		fromHeader, rpcErr = h.blockHeaderByID(blockID)
+		if rpcErr != nil {
+			return 0, rpcErr
+		}
+	}
+
+	id := h.idgen()
+	subscriptionCtx, subscriptionCtxCancel := context.WithCancel(ctx)
+	sub := &subscription{
+		cancel: subscriptionCtxCancel,
+		conn:   w,
+	}
+
+	// Subscribe before reading the head so that no block stored in between is missed.
+	headerSub := h.newHeads.Subscribe()
+	reorgSub := h.reorgs.Subscribe()
+	unsubscribeFeeds := func() {
+		headerSub.Unsubscribe()
+		reorgSub.Unsubscribe()
+	}
+
+	headHeader, err := h.bcReader.HeadsHeader()
+	if err != nil && !errors.Is(err, db.ErrKeyNotFound) {
+		unsubscribeFeeds()
+		subscriptionCtxCancel()
+		return 0, ErrInternal.CloneWithData(err.Error())
+	}
+	if fromHeader != nil && headHeader != nil && headHeader.Number > maxBlocksBack &&
+		fromHeader.Number < headHeader.Number-maxBlocksBack {
+		unsubscribeFeeds()
+		subscriptionCtxCancel()
+		return 0, ErrTooManyBlocksBack
+	}
+
+	h.mu.Lock()
+	h.subscriptions[id] = sub
+	h.mu.Unlock()
+	sub.wg.Go(func() {
+		defer func() {
+			unsubscribeFeeds()
+			h.unsubscribe(sub, id)
+		}()
+
+		// Events of blocks below nextBlock are either sent by the catch-up below or were never requested,
+		// the new heads feed may still announce some of these blocks. sentHeads are the latest heads whose events
+		// were sent, oldest first.
+		var nextBlock uint64
+		var sentHeads []*core.Header
+		if headHeader != nil {
+			nextBlock = headHeader.Number + 1
+			sentHeads = append(sentHeads, headHeader)
+			if fromHeader != nil {
+				if err := h.sendEvents(subscriptionCtx, w, id, fromAddr, keys, fromHeader.Number, headHeader.Number); err != nil {
+					h.log.Warnw("Error sending events", "err", err)
+					return
+				}
+			}
+		}
+
+		onReorg := func(reorg *sync.ReorgBlockRange) error {
+			if err := sendReorg(w, id, reorg); err != nil {
+				return err
+			}
+			nextBlock = min(nextBlock, reorg.StartBlockNum)
+			for len(sentHeads) > 0 && sentHeads[len(sentHeads)-1].Number >= reorg.StartBlockNum {
+				sentHeads = sentHeads[:len(sentHeads)-1]
+			}
+			return nil
+		}
+
+		for {
+			select {
+			case <-subscriptionCtx.Done():
+				return
+			case header := <-headerSub.Recv():
+				// The reorg that led to a head is sent before it, notify it before any event of the new chain.
+				select {
+				case reorg := <-reorgSub.Recv():
+					if err := onReorg(reorg); err != nil {
+						h.log.Warnw("Error sending reorg", "err", err)
+						return
+					}
+				default:
+				}
+				if header.Number < nextBlock {
+					continue
+				}
+				// The reorgs feed drops values too, so a head that doesn't extend the last one sent may follow a
+				// reorg that was never notified.
+				if reorg := h.missedReorg(sentHeads, header); reorg != nil {
+					if err := onReorg(reorg); err != nil {
+						h.log.Warnw("Error sending reorg", "err", err)
+						return
+					}
+				}
+
+				// The feed drops values, send the events of every block up to the new head.
+				if err := h.sendEvents(subscriptionCtx, w, id, fromAddr, keys, nextBlock, header.Number); err != nil {
+					h.log.Warnw("Error sending events", "err", err)
+					return
+				}
+				nextBlock = header.Number + 1
+				sentHeads = append(sentHeads, header)
+				if len(sentHeads) > maxBlocksBack {
+					sentHeads = sentHeads[1:]
+				}
+			case reorg := <-reorgSub.Recv():
+				if err := onReorg(reorg); err != nil {
+					h.log.Warnw("Error sending reorg", "err", err)
+					return
+				}
+			}
+		}
+	})
+	return id, nil
+}
+
+// SubscribeTransactionStatus creates a WebSocket stream which will fire events when the status of a transaction
+// changes. The current status is sent right away if the transaction is known. L2 transitions are checked on every
+// new head and reorg, the L1 transition on every new L1 head. The status of a transaction that the node doesn't have
+// is asked from the feeder at most once per head for all subscriptions to it. The subscription ends once the
+// transaction is either accepted on L1 or rejected.
+//
+// It follows the specification defined here:
+// https://github.com/starkware-libs/starknet-specs/blob/c2e93098b9c2ca0423b7f4d15b201f52f22d8c36/api/starknet_ws_api.json#L127
+func (h *Handler) SubscribeTransactionStatus(ctx context.Context, txHash felt.Felt) (uint64, *jsonrpc.Error) {
+	w, ok := jsonrpc.ConnFromContext(ctx)
+	if !ok {
+		return 0, jsonrpc.Err(jsonrpc.MethodNotFound, nil)
+	}
+
+	id := h.idgen()
+	subscriptionCtx, subscriptionCtxCancel := context.WithCancel(ctx)
+	sub := &subscription{
+		cancel: subscriptionCtxCancel,
+		conn:   w,
+	}
+	h.mu.Lock()
+	h.subscriptions[id] = sub
+	poll, ok := h.txStatusPolls[txHash]
+	if !ok {
+		poll = new(txStatusPoll)
+		h.txStatusPolls[txHash] = poll
+	}
+	poll.subscribers++
+	h.mu.Unlock()
+
+	headerSub := h.newHeads.Subscribe()
+	reorgSub := h.reorgs.Subscribe()
+	l1HeadSub := h.l1Heads.Subscribe()
+	sub.wg.Go(func() {
+		defer func() {
+			headerSub.Unsubscribe()
+			reorgSub.Unsubscribe()
+			l1HeadSub.Unsubscribe()
+			h.mu.Lock()
+			if poll.subscribers--; poll.subscribers == 0 {
+				delete(h.txStatusPolls, txHash)
+			}
+			h.mu.Unlock()
+			h.unsubscribe(sub, id)
+		}()
+
+		var lastStatus *TransactionStatus
+		for {
+			status, rpcErr := h.transactionStatus(subscriptionCtx, txHash, poll)
+			if rpcErr != nil && rpcErr != ErrTxnHashNotFound {
+				h.log.Debugw("Failed to get transaction status", "hash", txHash.ShortString(), "err", rpcErr)
+			}
+
+			if rpcErr == nil && (lastStatus == nil || *lastStatus != *status) {
+				lastStatus = status
+				if err := sendNotification(w, "starknet_subscriptionTransactionStatus", id, &NewTransactionStatus{
+					TransactionHash: &txHash,
+					Status:          status,
+				}); err != nil {
+					h.log.Warnw("Error sending transaction status", "err", err)
+					return
+				}
+
+				if status.Finality == TxnStatusAcceptedOnL1 || status.Finality == TxnStatusRejected {
+					return
+				}
+			}
+
+			select {
+			case <-subscriptionCtx.Done():
+				return
+			case <-headerSub.Recv():
+			case reorg := <-reorgSub.Recv():
+				if err := sendReorg(w, id, reorg); err != nil {
+					h.log.Warnw("Error sending reorg", "err", err)
+					return
+				}
+			case <-l1HeadSub.Recv():
+			}
+		}
+	})
+	return id, nil
+}
+
+// txStatusPoll shares the status of a transaction that the feeder returned between the subscriptions to it, so that
+// the feeder is asked at most once per head however many subscriptions and updates there are.
+type txStatusPoll struct {
+	subscribers int // protected by Handler.mu.
+
+	mu     stdsync.Mutex // protects the fields below.
+	head   *felt.Felt
+	status *starknet.TransactionStatus
+	err    error
+}
+
+// query returns the status of the transaction hash that the feeder returned at the current head, and asks the
+// feeder if it wasn't asked yet.
+func (p *txStatusPoll) query(ctx context.Context, h *Handler, hash *felt.Felt) (*starknet.TransactionStatus, error) {
+	head := &felt.Zero
+	if header, err := h.bcReader.HeadsHeader(); err == nil {
+		head = header.Hash
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.head == nil || !p.head.Equal(head) {
+		p.status, p.err = h.feederClient.Transaction(ctx, hash)
+		p.head = head
+	}
+	return p.status, p.err
+}
+
+// SubscribePendingTxs creates a WebSocket stream which will fire events when a transaction is added to the pending
+// block. Only transactions sent by one of senderAddr are streamed if it is not empty, and full transactions are
+// streamed instead of hashes if getDetails is set.
+//
+// It follows the specification defined here:
+// https://github.com/starkware-libs/starknet-specs/blob/c2e93098b9c2ca0423b7f4d15b201f52f22d8c36/api/starknet_ws_api.json#L186
+func (h *Handler) SubscribePendingTxs(ctx context.Context, getDetails *bool, senderAddr []felt.Felt) (uint64, *jsonrpc.Error) {
+	w, ok := jsonrpc.ConnFromContext(ctx)
+	if !ok {
+		return 0, jsonrpc.Err(jsonrpc.MethodNotFound, nil)
+	}
+
+	if len(senderAddr) > maxSenderAddresses {
+		return 0, ErrTooManyAddressesInFilter
+	}
+
+	id := h.idgen()
+	subscriptionCtx, subscriptionCtxCancel := context.WithCancel(ctx)
+	sub := &subscription{
+		cancel: subscriptionCtxCancel,
+		conn:   w,
+	}
+	h.mu.Lock()
+	h.subscriptions[id] = sub
+	h.mu.Unlock()
+
+	pendingTxsSub := h.pendingTxs.Subscribe()
+	reorgSub := h.reorgs.Subscribe()
+	sub.wg.Go(func() {
+		defer func() {
+			pendingTxsSub.Unsubscribe()
+			reorgSub.Unsubscribe()
+			h.unsubscribe(sub, id)
+		}()
+
+		for {
+			select {
+			case <-subscriptionCtx.Done():
+				return
+			case pendingTxs := <-pendingTxsSub.Recv():
+				for _, txn := range pendingTxs {
+					if !filterTxBySender(txn, senderAddr) {
+						continue
+					}
+
+					var result any = txn.Hash()
+					if getDetails != nil && *getDetails {
+						result = AdaptTransaction(txn)
+					}
+					if err := sendNotification(w, "starknet_subscriptionPendingTransactions", id, result); err != nil {
+						h.log.Warnw("Error sending pending transaction", "err", err)
+						return
+					}
+				}
+			case reorg := <-reorgSub.Recv():
+				if err := sendReorg(w, id, reorg); err != nil {
+					h.log.Warnw("Error sending reorg", "err", err)
+					return
+				}
+			}
+		}
+	})
+	return id, nil
+}
+
+// filterTxBySender reports whether txn was sent by one of senderAddr. An empty senderAddr matches every transaction.
+func filterTxBySender(txn core.Transaction, senderAddr []felt.Felt) bool {
+	if len(senderAddr) == 0 {
+		return true
+	}
+
+	var sender *felt.Felt
+	switch t := txn.(type) {
+	case *core.InvokeTransaction:
+		sender = t.SenderAddress
+		if sender == nil { // Version 0 invokes don't have a sender, the invoked contract is the account.
+			sender = t.ContractAddress
+		}
+	case *core.DeclareTransaction:
+		sender = t.SenderAddress
+	case *core.DeployAccountTransaction:
+		sender = t.ContractAddress
+	}
+	if sender == nil {
+		return false
+	}
+
+	for i := range senderAddr {
+		if sender.Equal(&senderAddr[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// missedReorg returns the blocks of sentHeads that aren't part of the chain anymore, if head doesn't extend the last
+// of them. A reorg deeper than sentHeads is reported from the oldest of them.
+func (h *Handler) missedReorg(sentHeads []*core.Header, head *core.Header) *sync.ReorgBlockRange {
+	if len(sentHeads) == 0 {
+		return nil
+	}
+	last := sentHeads[len(sentHeads)-1]
+	if head.Number == last.Number+1 && head.ParentHash.Equal(last.Hash) {
+		return nil
+	}
+
+	reverted := len(sentHeads)
+	for reverted > 0 {
+		sent := sentHeads[reverted-1]
+		header, err := h.bcReader.BlockHeaderByNumber(sent.Number)
+		if err == nil && header.Hash.Equal(sent.Hash) {
+			break
+		}
+		reverted--
+	}
+	if reverted == len(sentHeads) {
+		return nil
+	}
+	return &sync.ReorgBlockRange{
+		StartBlockHash: sentHeads[reverted].Hash,
+		StartBlockNum:  sentHeads[reverted].Number,
+		EndBlockHash:   last.Hash,
+		EndBlockNum:    last.Number,
+	}
+}
+
+// sendEvents sends all events in the [from, to] block range that match the filter.
+func (h *Handler) sendEvents(ctx context.Context, w jsonrpc.Conn, id uint64, fromAddr *felt.Felt, keys [][]felt.Felt,
+	from, to uint64,
+) error {
+	filter, err := h.bcReader.EventFilter(fromAddr, keys)
+	if err != nil {
+		return err
+	}
+	defer h.callAndLogErr(filter.Close, "Error closing event filter in events subscription")
+
+	if err = setEventFilterRange(filter, &BlockID{Number: from}, &BlockID{Number: to}, to); err != nil {
+		return err
+	}
+
+	var cToken *blockchain.ContinuationToken
+	for {
+		var filteredEvents []*blockchain.FilteredEvent
+		filteredEvents, cToken, err = filter.Events(cToken, subscribeEventsChunkSize)
+		if err != nil {
+			return err
+		}
+
+		for _, fEvent := range filteredEvents {
+			select {
+			case <-ctx.Done():
+				return nil
+			default:
+			}
+
+			emittedEvent := &EmittedEvent{
+				BlockNumber:     &fEvent.BlockNumber,
+				BlockHash:       fEvent.BlockHash,
+				TransactionHash: fEvent.TransactionHash,
+				Event: &Event{
+					From: fEvent.From,
+					Keys: fEvent.Keys,
+					Data: fEvent.Data,
+				},
+			}
+			if err = sendNotification(w, "starknet_subscriptionEvents", id, emittedEvent); err != nil {
+				return err
+			}
+		}
+
+		if cToken == nil {
+			return nil
+		}
+	}
+}
+
+// sendReorg notifies subscription id that the blocks in reorg were reverted.
+func sendReorg(w jsonrpc.Conn, id uint64, reorg *sync.ReorgBlockRange) error {
+	return sendNotification(w, "starknet_subscriptionReorg", id, &ReorgEvent{
+		StartBlockHash: reorg.StartBlockHash,
+		StartBlockNum:  reorg.StartBlockNum,
+		EndBlockHash:   reorg.EndBlockHash,
+		EndBlockNum:    reorg.EndBlockNum,
+	})
+}
+
+// sendNotification writes a single subscription notification to w.
+func sendNotification(w jsonrpc.Conn, method string, id uint64, result any) error {
+	resp, err := json.Marshal(jsonrpc.Request{
+		Version: "2.0",
+		Method:  method,
+		Params: map[string]any{
+			"result":       result,
+			"subscription": id,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(resp)
+	return err
+}
@@ -0,0 +1,591 @@
+package rpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/clients/feeder"
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/db/pebble"
+	"github.com/NethermindEth/juno/feed"
+	"github.com/NethermindEth/juno/jsonrpc"
+	"github.com/NethermindEth/juno/mocks"
+	"github.com/NethermindEth/juno/rpc"
+	adaptfeeder "github.com/NethermindEth/juno/starknetdata/feeder"
+	"github.com/NethermindEth/juno/sync"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// newTestSyncReader returns a sync reader whose feeds are controlled by the test.
+func newTestSyncReader(t *testing.T) (*mocks.MockSyncReader, *feed.Feed[*core.Header], *feed.Feed[*sync.ReorgBlockRange]) {
+	t.Helper()
+
+	mockCtrl := gomock.NewController(t)
+	t.Cleanup(mockCtrl.Finish)
+
+	newHeads := feed.New[*core.Header]()
+	reorgs := feed.New[*sync.ReorgBlockRange]()
+	syncReader := mocks.NewMockSyncReader(mockCtrl)
+	syncReader.EXPECT().SubscribeNewHeads().Return(sync.HeaderSubscription{Subscription: newHeads.Subscribe()}).AnyTimes()
+	syncReader.EXPECT().SubscribeReorg().Return(sync.ReorgSubscription{Subscription: reorgs.Subscribe()}).AnyTimes()
+	syncReader.EXPECT().SubscribePendingTxs().Return(sync.PendingTxSubscription{
+		Subscription: feed.New[[]core.Transaction]().Subscribe(),
+	}).AnyTimes()
+	return syncReader, newHeads, reorgs
+}
+
+func readNotification(t *testing.T, conn net.Conn) string {
+	t.Helper()
+
+	got := make([]byte, 4096)
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+	n, err := conn.Read(got)
+	require.NoError(t, err)
+	return string(got[:n])
+}
+
+func marshalNotification(t *testing.T, method string, id uint64, result any) string {
+	t.Helper()
+
+	want, err := json.Marshal(jsonrpc.Request{
+		Version: "2.0",
+		Method:  method,
+		Params: map[string]any{
+			"result":       result,
+			"subscription": id,
+		},
+	})
+	require.NoError(t, err)
+	return string(want)
+}
+
+func TestSubscribeEvents(t *testing.T) {
+	log := utils.NewNopZapLogger()
+
+	t.Run("Return error if called without a connection", func(t *testing.T) {
+		handler := rpc.New(nil, nil, nil, "", log)
+		id, rpcErr := handler.SubscribeEvents(context.Background(), nil, nil, nil)
+		assert.Zero(t, id)
+		assert.Equal(t, jsonrpc.MethodNotFound, rpcErr.Code)
+	})
+
+	serverConn, clientConn := net.Pipe()
+	t.Cleanup(func() {
+		require.NoError(t, serverConn.Close())
+		require.NoError(t, clientConn.Close())
+	})
+	subCtx := context.WithValue(context.Background(), jsonrpc.ConnKey{}, &fakeConn{w: serverConn})
+
+	t.Run("Return error if too many keys in filter", func(t *testing.T) {
+		handler := rpc.New(nil, nil, nil, "", log)
+		keys := make([][]felt.Felt, 1024+1)
+		id, rpcErr := handler.SubscribeEvents(subCtx, nil, keys, nil)
+		assert.Zero(t, id)
+		assert.Equal(t, rpc.ErrTooManyKeysInFilter, rpcErr)
+	})
+
+	t.Run("Return error if called on pending block", func(t *testing.T) {
+		handler := rpc.New(nil, nil, nil, "", log)
+		id, rpcErr := handler.SubscribeEvents(subCtx, nil, nil, &rpc.BlockID{Pending: true})
+		assert.Zero(t, id)
+		assert.Equal(t, rpc.ErrCallOnPending, rpcErr)
+	})
+
+	t.Run("Return error if block is too far back", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		t.Cleanup(mockCtrl.Finish)
+		mockChain := mocks.NewMockReader(mockCtrl)
+		handler := rpc.New(mockChain, nil, nil, "", log)
+
+		mockChain.EXPECT().BlockHeaderByNumber(uint64(0)).Return(&core.Header{Number: 0}, nil)
+		mockChain.EXPECT().HeadsHeader().Return(&core.Header{Number: 2000}, nil)
+		id, rpcErr := handler.SubscribeEvents(subCtx, nil, nil, &rpc.BlockID{Number: 0})
+		assert.Zero(t, id)
+		assert.Equal(t, rpc.ErrTooManyBlocksBack, rpcErr)
+	})
+
+	t.Run("Events from old and new blocks, then a reorg", func(t *testing.T) {
+		n := utils.Ptr(utils.Sepolia)
+		chain := blockchain.New(pebble.NewMemTest(t), n)
+		gw := adaptfeeder.New(feeder.NewTestClient(t, n))
+
+		var newBlock *core.Block
+		var newStateUpdate *core.StateUpdate
+		for i := range 6 {
+			b, err := gw.BlockByNumber(context.Background(), uint64(i))
+			require.NoError(t, err)
+			s, err := gw.StateUpdate(context.Background(), uint64(i))
+			require.NoError(t, err)
+			if i == 5 {
+				newBlock, newStateUpdate = b, s
+				break
+			}
+			require.NoError(t, chain.Store(b, &core.BlockCommitments{}, s, nil))
+		}
+
+		syncReader, newHeads, reorgs := newTestSyncReader(t)
+		handler := rpc.New(chain, syncReader, nil, "", log)
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+		go func() {
+			require.NoError(t, handler.Run(ctx))
+		}()
+		// Technically, there's a race between goroutine above and the SubscribeEvents call down below.
+		// Sleep for a moment just in case.
+		time.Sleep(50 * time.Millisecond)
+
+		fromAddr := utils.HexToFelt(t, "0x49d36570d4e46f48e99674bd3fcc84644ddd6b96f7c741b1562b82f9e004dc7")
+		id, rpcErr := handler.SubscribeEvents(subCtx, fromAddr, nil, &rpc.BlockID{Number: 0})
+		require.Nil(t, rpcErr)
+
+		require.NoError(t, chain.Store(newBlock, &core.BlockCommitments{}, newStateUpdate, nil))
+		newHeads.Send(newBlock.Header)
+
+		events, rpcErr := handler.Events(rpc.EventsArg{
+			EventFilter: rpc.EventFilter{
+				FromBlock: &rpc.BlockID{Number: 0},
+				ToBlock:   &rpc.BlockID{Latest: true},
+				Address:   fromAddr,
+			},
+			ResultPageRequest: rpc.ResultPageRequest{ChunkSize: 100},
+		})
+		require.Nil(t, rpcErr)
+		require.NotEmpty(t, events.Events)
+		for _, event := range events.Events {
+			assert.Equal(t, marshalNotification(t, "starknet_subscriptionEvents", id, event), readNotification(t, clientConn))
+		}
+
+		reorg := &sync.ReorgBlockRange{
+			StartBlockHash: newBlock.Hash,
+			StartBlockNum:  newBlock.Number,
+			EndBlockHash:   newBlock.Hash,
+			EndBlockNum:    newBlock.Number,
+		}
+		reorgs.Send(reorg)
+		assert.Equal(t, marshalNotification(t, "starknet_subscriptionReorg", id, &rpc.ReorgEvent{
+			StartBlockHash: reorg.StartBlockHash,
+			StartBlockNum:  reorg.StartBlockNum,
+			EndBlockHash:   reorg.EndBlockHash,
+			EndBlockNum:    reorg.EndBlockNum,
+		}), readNotification(t, clientConn))
+
+		ok, rpcErr := handler.Unsubscribe(subCtx, id)
+		require.Nil(t, rpcErr)
+		require.True(t, ok)
+	})
+
+	t.Run("Events of dropped heads and of a fork whose reorg was dropped", func(t *testing.T) {
+		n := utils.Ptr(utils.Sepolia)
+		chain := blockchain.New(pebble.NewMemTest(t), n)
+		gw := adaptfeeder.New(feeder.NewTestClient(t, n))
+
+		blocks := make([]*core.Block, 6)
+		stateUpdates := make([]*core.StateUpdate, 6)
+		for i := range blocks {
+			var err error
+			blocks[i], err = gw.BlockByNumber(context.Background(), uint64(i))
+			require.NoError(t, err)
+			stateUpdates[i], err = gw.StateUpdate(context.Background(), uint64(i))
+			require.NoError(t, err)
+		}
+		for i := range 3 {
+			require.NoError(t, chain.Store(blocks[i], &core.BlockCommitments{}, stateUpdates[i], nil))
+		}
+
+		syncReader, newHeads, _ := newTestSyncReader(t)
+		handler := rpc.New(chain, syncReader, nil, "", log)
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+		go func() {
+			require.NoError(t, handler.Run(ctx))
+		}()
+		// Technically, there's a race between goroutine above and the SubscribeEvents call down below.
+		// Sleep for a moment just in case.
+		time.Sleep(50 * time.Millisecond)
+
+		id, rpcErr := handler.SubscribeEvents(subCtx, nil, nil, nil)
+		require.Nil(t, rpcErr)
+
+		expectEvents := func(t *testing.T, from, to uint64) {
+			t.Helper()
+
+			events, rpcErr := handler.Events(rpc.EventsArg{
+				EventFilter:       rpc.EventFilter{FromBlock: &rpc.BlockID{Number: from}, ToBlock: &rpc.BlockID{Number: to}},
+				ResultPageRequest: rpc.ResultPageRequest{ChunkSize: 100},
+			})
+			require.Nil(t, rpcErr)
+			require.NotEmpty(t, events.Events)
+			for _, event := range events.Events {
+				assert.Equal(t, marshalNotification(t, "starknet_subscriptionEvents", id, event), readNotification(t, clientConn))
+			}
+		}
+
+		// The head of block 3 is dropped by the feed.
+		require.NoError(t, chain.Store(blocks[3], &core.BlockCommitments{}, stateUpdates[3], nil))
+		require.NoError(t, chain.Store(blocks[4], &core.BlockCommitments{}, stateUpdates[4], nil))
+		newHeads.Send(blocks[4].Header)
+		expectEvents(t, 3, 4)
+
+		// Block 4 is replaced by a fork, and the notice of the reorg is dropped by the feed.
+		require.NoError(t, chain.RevertHead())
+		forkHeader4 := *blocks[4].Header
+		forkHeader4.Hash = utils.HexToFelt(t, "0xf04")
+		forkHeader5 := *blocks[5].Header
+		forkHeader5.ParentHash = forkHeader4.Hash
+		forkBlock4 := &core.Block{Header: &forkHeader4, Transactions: blocks[4].Transactions, Receipts: blocks[4].Receipts}
+		forkBlock5 := &core.Block{Header: &forkHeader5, Transactions: blocks[5].Transactions, Receipts: blocks[5].Receipts}
+		require.NoError(t, chain.Store(forkBlock4, &core.BlockCommitments{}, stateUpdates[4], nil))
+		require.NoError(t, chain.Store(forkBlock5, &core.BlockCommitments{}, stateUpdates[5], nil))
+		newHeads.Send(forkBlock5.Header)
+
+		assert.Equal(t, marshalNotification(t, "starknet_subscriptionReorg", id, &rpc.ReorgEvent{
+			StartBlockHash: blocks[4].Hash,
+			StartBlockNum:  4,
+			EndBlockHash:   blocks[4].Hash,
+			EndBlockNum:    4,
+		}), readNotification(t, clientConn))
+		expectEvents(t, 4, 5)
+
+		ok, rpcErr := handler.Unsubscribe(subCtx, id)
+		require.Nil(t, rpcErr)
+		require.True(t, ok)
+	})
+}
+
+func TestSubscribeTransactionStatus(t *testing.T) {
+	log := utils.NewNopZapLogger()
+
+	t.Run("Return error if called without a connection", func(t *testing.T) {
+		handler := rpc.New(nil, nil, nil, "", log)
+		id, rpcErr := handler.SubscribeTransactionStatus(context.Background(), felt.Zero)
+		assert.Zero(t, id)
+		assert.Equal(t, jsonrpc.MethodNotFound, rpcErr.Code)
+	})
+
+	t.Run("Status changes are pushed until the transaction is accepted on L1", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		t.Cleanup(mockCtrl.Finish)
+
+		n := utils.Ptr(utils.Mainnet)
+		gw := adaptfeeder.New(feeder.NewTestClient(t, n))
+		block, err := gw.BlockLatest(context.Background())
+		require.NoError(t, err)
+		tx := block.Transactions[0]
+
+		l1Heads := feed.New[*core.L1Head]()
+		mockChain := mocks.NewMockReader(mockCtrl)
+		mockChain.EXPECT().SubscribeL1Head().Return(blockchain.L1HeadSubscription{Subscription: l1Heads.Subscribe()}).AnyTimes()
+		gomock.InOrder(
+			mockChain.EXPECT().TransactionByHash(tx.Hash()).Return(nil, db.ErrKeyNotFound),
+			mockChain.EXPECT().TransactionByHash(tx.Hash()).Return(tx, nil),
+			mockChain.EXPECT().Receipt(tx.Hash()).Return(block.Receipts[0], block.Hash, block.Number, nil),
+			mockChain.EXPECT().L1Head().Return(nil, db.ErrKeyNotFound),
+			mockChain.EXPECT().TransactionByHash(tx.Hash()).Return(tx, nil),
+			mockChain.EXPECT().Receipt(tx.Hash()).Return(block.Receipts[0], block.Hash, block.Number, nil),
+			mockChain.EXPECT().L1Head().Return(&core.L1Head{BlockNumber: block.Number}, nil),
+		)
+
+		syncReader, newHeads, _ := newTestSyncReader(t)
+		handler := rpc.New(mockChain, syncReader, nil, "", log)
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+		go func() {
+			require.NoError(t, handler.Run(ctx))
+		}()
+		// Technically, there's a race between goroutine above and the SubscribeTransactionStatus call down below.
+		// Sleep for a moment just in case.
+		time.Sleep(50 * time.Millisecond)
+
+		serverConn, clientConn := net.Pipe()
+		t.Cleanup(func() {
+			require.NoError(t, serverConn.Close())
+			require.NoError(t, clientConn.Close())
+		})
+		subCtx := context.WithValue(context.Background(), jsonrpc.ConnKey{}, &fakeConn{w: serverConn})
+
+		id, rpcErr := handler.SubscribeTransactionStatus(subCtx, *tx.Hash())
+		require.Nil(t, rpcErr)
+
+		newHeads.Send(block.Header)
+		assert.Equal(t, marshalNotification(t, "starknet_subscriptionTransactionStatus", id, &rpc.NewTransactionStatus{
+			TransactionHash: tx.Hash(),
+			Status:          &rpc.TransactionStatus{Finality: rpc.TxnStatusAcceptedOnL2, Execution: rpc.TxnSuccess},
+		}), readNotification(t, clientConn))
+
+		l1Heads.Send(&core.L1Head{BlockNumber: block.Number})
+		assert.Equal(t, marshalNotification(t, "starknet_subscriptionTransactionStatus", id, &rpc.NewTransactionStatus{
+			TransactionHash: tx.Hash(),
+			Status:          &rpc.TransactionStatus{Finality: rpc.TxnStatusAcceptedOnL1, Execution: rpc.TxnSuccess},
+		}), readNotification(t, clientConn))
+
+		// The subscription ends once the transaction reaches a final status.
+		require.Eventually(t, func() bool {
+			_, rpcErr := handler.Unsubscribe(subCtx, id)
+			return rpcErr == rpc.ErrSubscriptionNotFound
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("The feeder is asked once per head for all subscriptions", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		t.Cleanup(mockCtrl.Finish)
+
+		txHash := utils.HexToFelt(t, "0x6c40890743aa220b10e5ee68cef694c5c23cc2defd0dbdf5546e687f9982ab1")
+		var head atomic.Pointer[core.Header]
+		head.Store(&core.Header{Number: 1, Hash: utils.HexToFelt(t, "0x1")})
+
+		l1Heads := feed.New[*core.L1Head]()
+		mockChain := mocks.NewMockReader(mockCtrl)
+		mockChain.EXPECT().SubscribeL1Head().Return(blockchain.L1HeadSubscription{Subscription: l1Heads.Subscribe()}).AnyTimes()
+		mockChain.EXPECT().TransactionByHash(txHash).Return(nil, db.ErrKeyNotFound).AnyTimes()
+		mockChain.EXPECT().HeadsHeader().DoAndReturn(func() (*core.Header, error) {
+			return head.Load(), nil
+		}).AnyTimes()
+
+		var queries atomic.Int32
+		client := feeder.NewTestClient(t, utils.Ptr(utils.Mainnet)).WithListener(&feeder.SelectiveListener{
+			OnResponseCb: func(urlPath string, _ int, _ time.Duration) {
+				if strings.Contains(urlPath, "get_transaction") {
+					queries.Add(1)
+				}
+			},
+		})
+
+		syncReader, newHeads, _ := newTestSyncReader(t)
+		handler := rpc.New(mockChain, syncReader, nil, "", log).WithFeeder(client)
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+		go func() {
+			require.NoError(t, handler.Run(ctx))
+		}()
+		// Technically, there's a race between goroutine above and the SubscribeTransactionStatus calls down below.
+		// Sleep for a moment just in case.
+		time.Sleep(50 * time.Millisecond)
+
+		for range 2 {
+			serverConn, clientConn := net.Pipe()
+			t.Cleanup(func() {
+				require.NoError(t, serverConn.Close())
+				require.NoError(t, clientConn.Close())
+			})
+			subCtx := context.WithValue(context.Background(), jsonrpc.ConnKey{}, &fakeConn{w: serverConn})
+
+			id, rpcErr := handler.SubscribeTransactionStatus(subCtx, *txHash)
+			require.Nil(t, rpcErr)
+			assert.Equal(t, marshalNotification(t, "starknet_subscriptionTransactionStatus", id, &rpc.NewTransactionStatus{
+				TransactionHash: txHash,
+				Status:          &rpc.TransactionStatus{Finality: rpc.TxnStatusAcceptedOnL2, Execution: rpc.TxnSuccess},
+			}), readNotification(t, clientConn))
+		}
+		assert.Equal(t, int32(1), queries.Load())
+
+		// An L1 head doesn't change the head, a new head does.
+		l1Heads.Send(&core.L1Head{BlockNumber: 1})
+		head.Store(&core.Header{Number: 2, Hash: utils.HexToFelt(t, "0x2")})
+		newHeads.Send(head.Load())
+		require.Eventually(t, func() bool {
+			return queries.Load() == 2
+		}, time.Second, 10*time.Millisecond)
+		time.Sleep(50 * time.Millisecond)
+		assert.Equal(t, int32(2), queries.Load())
+	})
+}
+
+func TestSubscribePendingTxs(t *testing.T) {
+	log := utils.NewNopZapLogger()
+
+	t.Run("Return error if called without a connection", func(t *testing.T) {
+		handler := rpc.New(nil, nil, nil, "", log)
+		id, rpcErr := handler.SubscribePendingTxs(context.Background(), nil, nil)
+		assert.Zero(t, id)
+		assert.Equal(t, jsonrpc.MethodNotFound, rpcErr.Code)
+	})
+
+	serverConn, clientConn := net.Pipe()
+	t.Cleanup(func() {
+		require.NoError(t, serverConn.Close())
+		require.NoError(t, clientConn.Close())
+	})
+	subCtx := context.WithValue(context.Background(), jsonrpc.ConnKey{}, &fakeConn{w: serverConn})
+
+	t.Run("Return error if too many addresses in filter", func(t *testing.T) {
+		handler := rpc.New(nil, nil, nil, "", log)
+		addrs := make([]felt.Felt, 1024+1)
+		id, rpcErr := handler.SubscribePendingTxs(subCtx, nil, addrs)
+		assert.Zero(t, id)
+		assert.Equal(t, rpc.ErrTooManyAddressesInFilter, rpcErr)
+	})
+
+	t.Run("Hashes and details of filtered pending transactions", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		t.Cleanup(mockCtrl.Finish)
+
+		pendingTxs := feed.New[[]core.Transaction]()
+		syncReader := mocks.NewMockSyncReader(mockCtrl)
+		syncReader.EXPECT().SubscribeNewHeads().Return(sync.HeaderSubscription{
+			Subscription: feed.New[*core.Header]().Subscribe(),
+		}).AnyTimes()
+		syncReader.EXPECT().SubscribeReorg().Return(sync.ReorgSubscription{
+			Subscription: feed.New[*sync.ReorgBlockRange]().Subscribe(),
+		}).AnyTimes()
+		syncReader.EXPECT().SubscribePendingTxs().Return(sync.PendingTxSubscription{Subscription: pendingTxs.Subscribe()}).AnyTimes()
+		mockChain := mocks.NewMockReader(mockCtrl)
+		mockChain.EXPECT().SubscribeL1Head().Return(blockchain.L1HeadSubscription{
+			Subscription: feed.New[*core.L1Head]().Subscribe(),
+		}).AnyTimes()
+
+		handler := rpc.New(mockChain, syncReader, nil, "", log)
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+		go func() {
+			require.NoError(t, handler.Run(ctx))
+		}()
+		// Technically, there's a race between goroutine above and the SubscribePendingTxs call down below.
+		// Sleep for a moment just in case.
+		time.Sleep(50 * time.Millisecond)
+
+		sender := utils.HexToFelt(t, "0x1")
+		invoke := &core.InvokeTransaction{TransactionHash: utils.HexToFelt(t, "0x10"), SenderAddress: sender}
+		otherInvoke := &core.InvokeTransaction{
+			TransactionHash: utils.HexToFelt(t, "0x11"),
+			SenderAddress:   utils.HexToFelt(t, "0x2"),
+			Version:         new(core.TransactionVersion).SetUint64(1),
+		}
+		deployAccount := &core.DeployAccountTransaction{
+			DeployTransaction: core.DeployTransaction{
+				TransactionHash: utils.HexToFelt(t, "0x12"),
+				ContractAddress: sender,
+			},
+		}
+
+		hashesID, rpcErr := handler.SubscribePendingTxs(subCtx, nil, []felt.Felt{*sender})
+		require.Nil(t, rpcErr)
+
+		pendingTxs.Send([]core.Transaction{invoke, otherInvoke, deployAccount})
+		assert.Equal(t, marshalNotification(t, "starknet_subscriptionPendingTransactions", hashesID, invoke.Hash()),
+			readNotification(t, clientConn))
+		assert.Equal(t, marshalNotification(t, "starknet_subscriptionPendingTransactions", hashesID, deployAccount.Hash()),
+			readNotification(t, clientConn))
+
+		ok, rpcErr := handler.Unsubscribe(subCtx, hashesID)
+		require.Nil(t, rpcErr)
+		require.True(t, ok)
+
+		detailsID, rpcErr := handler.SubscribePendingTxs(subCtx, utils.Ptr(true), nil)
+		require.Nil(t, rpcErr)
+
+		pendingTxs.Send([]core.Transaction{otherInvoke})
+		assert.Equal(t, marshalNotification(t, "starknet_subscriptionPendingTransactions", detailsID, rpc.AdaptTransaction(otherInvoke)),
+			readNotification(t, clientConn))
+
+		ok, rpcErr = handler.Unsubscribe(subCtx, detailsID)
+		require.Nil(t, rpcErr)
+		require.True(t, ok)
+	})
+}
+
+func TestSubscriptionReorg(t *testing.T) {
+	log := utils.NewNopZapLogger()
+
+	mockCtrl := gomock.NewController(t)
+	t.Cleanup(mockCtrl.Finish)
+	mockChain := mocks.NewMockReader(mockCtrl)
+	mockChain.EXPECT().SubscribeL1Head().Return(blockchain.L1HeadSubscription{
+		Subscription: feed.New[*core.L1Head]().Subscribe(),
+	}).AnyTimes()
+
+	syncReader, newHeads, reorgs := newTestSyncReader(t)
+	handler := rpc.New(mockChain, syncReader, nil, "", log)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go func() {
+		require.NoError(t, handler.Run(ctx))
+	}()
+	// Technically, there's a race between goroutine above and the subscribe calls down below.
+	// Sleep for a moment just in case.
+	time.Sleep(50 * time.Millisecond)
+
+	reorg := &sync.ReorgBlockRange{
+		StartBlockHash: utils.HexToFelt(t, "0x1"),
+		StartBlockNum:  1,
+		EndBlockHash:   utils.HexToFelt(t, "0x2"),
+		EndBlockNum:    2,
+	}
+	reorgEvent := &rpc.ReorgEvent{
+		StartBlockHash: reorg.StartBlockHash,
+		StartBlockNum:  reorg.StartBlockNum,
+		EndBlockHash:   reorg.EndBlockHash,
+		EndBlockNum:    reorg.EndBlockNum,
+	}
+
+	tests := map[string]func(context.Context) (uint64, *jsonrpc.Error){
+		"juno_subscribeNewHeads": handler.SubscribeNewHeads,
+		"starknet_subscribePendingTransactions": func(ctx context.Context) (uint64, *jsonrpc.Error) {
+			return handler.SubscribePendingTxs(ctx, nil, nil)
+		},
+	}
+	for name, subscribe := range tests {
+		t.Run(name, func(t *testing.T) {
+			serverConn, clientConn := net.Pipe()
+			t.Cleanup(func() {
+				require.NoError(t, serverConn.Close())
+				require.NoError(t, clientConn.Close())
+			})
+			subCtx := context.WithValue(context.Background(), jsonrpc.ConnKey{}, &fakeConn{w: serverConn})
+
+			id, rpcErr := subscribe(subCtx)
+			require.Nil(t, rpcErr)
+
+			reorgs.Send(reorg)
+			assert.Equal(t, marshalNotification(t, "starknet_subscriptionReorg", id, reorgEvent), readNotification(t, clientConn))
+
+			ok, rpcErr := handler.Unsubscribe(subCtx, id)
+			require.Nil(t, rpcErr)
+			require.True(t, ok)
+		})
+	}
+
+	t.Run("juno_subscribeNewHeads notifies a reorg before the heads that follow it", func(t *testing.T) {
+		gw := adaptfeeder.New(feeder.NewTestClient(t, utils.Ptr(utils.Mainnet)))
+		block0, err := gw.BlockByNumber(context.Background(), 0)
+		require.NoError(t, err)
+		block1, err := gw.BlockByNumber(context.Background(), 1)
+		require.NoError(t, err)
+
+		serverConn, clientConn := net.Pipe()
+		t.Cleanup(func() {
+			require.NoError(t, serverConn.Close())
+			require.NoError(t, clientConn.Close())
+		})
+		subCtx := context.WithValue(context.Background(), jsonrpc.ConnKey{}, &fakeConn{w: serverConn})
+
+		id, rpcErr := handler.SubscribeNewHeads(subCtx)
+		require.Nil(t, rpcErr)
+
+		// The subscription blocks on writing the first head until it's read, so that the reorg and the next head
+		// are both pending.
+		newHeads.Send(block0.Header)
+		time.Sleep(50 * time.Millisecond)
+		reorgs.Send(reorg)
+		newHeads.Send(block1.Header)
+		time.Sleep(50 * time.Millisecond)
+
+		assert.Contains(t, readNotification(t, clientConn), "juno_subscribeNewHeads")
+		assert.Equal(t, marshalNotification(t, "starknet_subscriptionReorg", id, reorgEvent), readNotification(t, clientConn))
+		assert.Contains(t, readNotification(t, clientConn), "juno_subscribeNewHeads")
+
+		ok, rpcErr := handler.Unsubscribe(subCtx, id)
+		require.Nil(t, rpcErr)
+		require.True(t, ok)
+	})
+}
@@ -131,11 +131,17 @@ func adaptFeederExecutionResources(resources *starknet.ExecutionResources) *vm.E
 //
 // It follows the specification defined here:
 // https://github.com/starkware-libs/starknet-specs/blob/1ae810e0137cc5d175ace4554892a4f43052be56/api/starknet_trace_api_openrpc.json#L11
-func (h *Handler) TraceTransaction(ctx context.Context, hash felt.Felt) (*vm.TransactionTrace, http.Header, *jsonrpc.Error) {
-	return h.traceTransaction(ctx, &hash)
+//
+// The optional options add outputs that aren't part of the specification, see [TraceOptions].
+func (h *Handler) TraceTransaction(ctx context.Context, hash felt.Felt,
+	options *TraceOptions,
+) (*vm.TransactionTrace, http.Header, *jsonrpc.Error) {
+	return h.traceTransaction(ctx, &hash, options)
 }
 
-func (h *Handler) traceTransaction(ctx context.Context, hash *felt.Felt) (*vm.TransactionTrace, http.Header, *jsonrpc.Error) {
+func (h *Handler) traceTransaction(ctx context.Context, hash *felt.Felt,
+	options *TraceOptions,
+) (*vm.TransactionTrace, http.Header, *jsonrpc.Error) {
 	_, blockHash, _, err := h.bcReader.Receipt(hash)
 	httpHeader := http.Header{}
 	httpHeader.Set(ExecutionStepsHeader, "0")
@@ -174,10 +180,17 @@ func (h *Handler) traceTransaction(ctx context.Context, hash *felt.Felt) (*vm.Tr
 		return nil, header, traceBlockErr
 	}
 
+	traceResults, traceBlockErr = h.applyTraceOptions(block, traceResults, options)
+	if traceBlockErr != nil {
+		return nil, header, traceBlockErr
+	}
+
 	return traceResults[txIndex].TraceRoot, header, nil
 }
 
-func (h *Handler) TraceBlockTransactions(ctx context.Context, id BlockID) ([]TracedBlockTransaction, http.Header, *jsonrpc.Error) {
+func (h *Handler) TraceBlockTransactions(ctx context.Context, id BlockID,
+	options *TraceOptions,
+) ([]TracedBlockTransaction, http.Header, *jsonrpc.Error) {
 	block, rpcErr := h.blockByID(&id)
 	if rpcErr != nil {
 		httpHeader := http.Header{}
@@ -185,7 +198,16 @@ func (h *Handler) TraceBlockTransactions(ctx context.Context, id BlockID) ([]Tra
 		return nil, httpHeader, rpcErr
 	}
 
-	return h.traceBlockTransactions(ctx, block)
+	traces, httpHeader, rpcErr := h.traceBlockTransactions(ctx, block)
+	if rpcErr != nil {
+		return nil, httpHeader, rpcErr
+	}
+
+	traces, rpcErr = h.applyTraceOptions(block, traces, options)
+	if rpcErr != nil {
+		return nil, httpHeader, rpcErr
+	}
+	return traces, httpHeader, nil
 }
 
 //nolint:funlen,gocyclo
@@ -195,6 +217,10 @@ func (h *Handler) traceBlockTransactions(ctx context.Context, block *core.Block)
 
 	isPending := block.Hash == nil
 	if !isPending {
+		if trace, stored := h.storedBlockTraces(block); stored {
+			return trace, httpHeader, nil
+		}
+
 		if blockVer, err := core.ParseBlockVersion(block.ProtocolVersion); err != nil {
 			return nil, httpHeader, ErrUnexpectedError.CloneWithData(err.Error())
 		} else if blockVer.LessThanEqual(traceFallbackVersion) && block.ProtocolVersion != excludedVersion {
@@ -228,6 +254,7 @@ func (h *Handler) traceBlockTransactions(ctx context.Context, block *core.Block)
 				result[index].TraceRoot.ExecutionResources = executionResources
 			}
 
+			h.storeBlockTraces(block, result)
 			return result, httpHeader, err
 		}
 
@@ -240,6 +267,9 @@ func (h *Handler) traceBlockTransactions(ctx context.Context, block *core.Block)
 
 	state, closer, err := h.bcReader.StateAtBlockHash(block.ParentHash)
 	if err != nil {
+		if errors.Is(err, blockchain.ErrHistoryPruned) {
+			return nil, httpHeader, ErrHistoricalStatePruned
+		}
 		return nil, httpHeader, ErrBlockNotFound
 	}
 	defer h.callAndLogErr(closer, "Failed to close state in traceBlockTransactions")
@@ -318,6 +348,7 @@ func (h *Handler) traceBlockTransactions(ctx context.Context, block *core.Block)
 		h.blockTraceCache.Add(traceCacheKey{
 			blockHash: *block.Hash,
 		}, result)
+		h.storeBlockTraces(block, result)
 	}
 
 	return result, httpHeader, nil
@@ -349,13 +380,18 @@ func (h *Handler) fetchTraces(ctx context.Context, blockHash *felt.Felt) ([]Trac
 }
 
 // https://github.com/starkware-libs/starknet-specs/blob/e0b76ed0d8d8eba405e182371f9edac8b2bcbc5a/api/starknet_api_openrpc.json#L401-L445
-func (h *Handler) Call(funcCall FunctionCall, id BlockID) ([]*felt.Felt, *jsonrpc.Error) { //nolint:gocritic
+func (h *Handler) Call(funcCall FunctionCall, id BlockID, stateOverride *StateOverride) ([]*felt.Felt, *jsonrpc.Error) { //nolint:gocritic
 	state, closer, rpcErr := h.stateByBlockID(&id)
 	if rpcErr != nil {
 		return nil, rpcErr
 	}
 	defer h.callAndLogErr(closer, "Failed to close state in starknet_call")
 
+	state, rpcErr = applyStateOverride(state, stateOverride)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
 	header, rpcErr := h.blockHeaderByID(&id)
 	if rpcErr != nil {
 		return nil, rpcErr
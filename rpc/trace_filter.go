@@ -0,0 +1,163 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/jsonrpc"
+	"github.com/NethermindEth/juno/vm"
+)
+
+type TraceFilterArg struct {
+	TraceFilter
+	ResultPageRequest
+}
+
+// TraceFilter matches the transactions with an invocation in their call trees, including internal calls, that
+// satisfies all the criteria set.
+type TraceFilter struct {
+	FromBlock          *BlockID   `json:"from_block"`
+	ToBlock            *BlockID   `json:"to_block"`
+	Address            *felt.Felt `json:"address"`
+	ClassHash          *felt.Felt `json:"class_hash"`
+	EntryPointSelector *felt.Felt `json:"entry_point_selector"`
+}
+
+type FilteredTrace struct {
+	BlockNumber     uint64               `json:"block_number"`
+	BlockHash       *felt.Felt           `json:"block_hash"`
+	TransactionHash *felt.Felt           `json:"transaction_hash"`
+	TraceRoot       *vm.TransactionTrace `json:"trace_root"`
+}
+
+type TracesChunk struct {
+	Traces            []*FilteredTrace `json:"traces"`
+	ContinuationToken string           `json:"continuation_token,omitempty"`
+}
+
+func (f *TraceFilter) matches(invocation *vm.FunctionInvocation) bool {
+	if f.Address != nil && !f.Address.Equal(&invocation.ContractAddress) {
+		return false
+	}
+	if f.ClassHash != nil && (invocation.ClassHash == nil || !f.ClassHash.Equal(invocation.ClassHash)) {
+		return false
+	}
+	if f.EntryPointSelector != nil && (invocation.EntryPointSelector == nil || !f.EntryPointSelector.Equal(invocation.EntryPointSelector)) {
+		return false
+	}
+	return true
+}
+
+// TraceFilter returns the traces of the transactions in a range of blocks that match the filter. Blocks are traced
+// as they are scanned, unless their traces are in the trace cache or store. At most filterLimit blocks are scanned
+// and at most traceFilterLimit of them are traced per request.
+func (h *Handler) TraceFilter(ctx context.Context, args TraceFilterArg) (*TracesChunk, *jsonrpc.Error) {
+	if args.ChunkSize > maxTraceFilterChunkSize {
+		return nil, ErrPageSizeTooBig
+	}
+
+	height, err := h.bcReader.Height()
+	if err != nil {
+		return nil, ErrNoBlock
+	}
+	fromBlock, rpcErr := h.traceFilterBlockNumber(args.FromBlock, 0, height)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	toBlock, rpcErr := h.traceFilterBlockNumber(args.ToBlock, height, height)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	// The continuation token is the position of the next transaction to scan.
+	var fromTxn uint64
+	if args.ContinuationToken != "" {
+		var tokenBlock uint64
+		if _, err = fmt.Sscanf(args.ContinuationToken, "%d-%d", &tokenBlock, &fromTxn); err != nil || tokenBlock < fromBlock {
+			return nil, ErrInvalidContinuationToken
+		}
+		fromBlock = tokenBlock
+	}
+
+	chunk := &TracesChunk{Traces: []*FilteredTrace{}}
+	var scannedBlocks, tracedBlocks uint
+	for number := fromBlock; number <= toBlock; number++ {
+		if scannedBlocks == h.filterLimit {
+			chunk.ContinuationToken = fmt.Sprintf("%d-%d", number, 0)
+			break
+		}
+		scannedBlocks++
+
+		block, err := h.bcReader.BlockByNumber(number)
+		if err != nil {
+			return nil, ErrInternal.CloneWithData(err)
+		}
+		traces, known := h.knownBlockTraces(block)
+		if !known {
+			// only the blocks that have to be traced count towards the trace limit
+			if tracedBlocks == h.traceFilterLimit {
+				chunk.ContinuationToken = fmt.Sprintf("%d-%d", number, fromTxn)
+				break
+			}
+			tracedBlocks++
+
+			if traces, _, rpcErr = h.traceBlockTransactions(ctx, block); rpcErr != nil {
+				return nil, rpcErr
+			}
+		}
+
+		for index := fromTxn; index < uint64(len(traces)); index++ {
+			if uint64(len(chunk.Traces)) == args.ChunkSize {
+				chunk.ContinuationToken = fmt.Sprintf("%d-%d", number, index)
+				return chunk, nil
+			}
+			if traces[index].TraceRoot.AnyInvocation(args.TraceFilter.matches) {
+				chunk.Traces = append(chunk.Traces, &FilteredTrace{
+					BlockNumber:     number,
+					BlockHash:       block.Hash,
+					TransactionHash: traces[index].TransactionHash,
+					TraceRoot:       traces[index].TraceRoot,
+				})
+			}
+		}
+		fromTxn = 0
+	}
+	return chunk, nil
+}
+
+// traceFilterBlockNumber returns the number of the block identified by id, or defaultNumber if id is nil. Only
+// canonical blocks can be traced, so pending is treated as latest.
+func (h *Handler) traceFilterBlockNumber(id *BlockID, defaultNumber, height uint64) (uint64, *jsonrpc.Error) {
+	switch {
+	case id == nil:
+		return defaultNumber, nil
+	case id.Latest, id.Pending:
+		return height, nil
+	case id.Hash != nil:
+		header, err := h.bcReader.BlockHeaderByHash(id.Hash)
+		if err != nil {
+			if errors.Is(err, db.ErrKeyNotFound) {
+				return 0, ErrBlockNotFound
+			}
+			return 0, ErrInternal.CloneWithData(err)
+		}
+		return header.Number, nil
+	default:
+		if id.Number > height {
+			return 0, ErrBlockNotFound
+		}
+		return id.Number, nil
+	}
+}
+
+// knownBlockTraces returns the traces of block from the trace store or cache, if they are there.
+func (h *Handler) knownBlockTraces(block *core.Block) ([]TracedBlockTransaction, bool) {
+	if traces, stored := h.storedBlockTraces(block); stored {
+		return traces, true
+	}
+	return h.blockTraceCache.Get(traceCacheKey{blockHash: *block.Hash})
+}
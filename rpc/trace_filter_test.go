@@ -0,0 +1,154 @@
+package rpc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/mocks"
+	"github.com/NethermindEth/juno/rpc"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/NethermindEth/juno/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type memTraceStore map[felt.Felt][]vm.TransactionTrace
+
+func (s memTraceStore) BlockTraces(blockHash *felt.Felt) ([]vm.TransactionTrace, error) {
+	traces, ok := s[*blockHash]
+	if !ok {
+		return nil, db.ErrKeyNotFound
+	}
+	return traces, nil
+}
+
+func (s memTraceStore) StoreBlockTraces(blockHash *felt.Felt, traces []vm.TransactionTrace) error {
+	s[*blockHash] = traces
+	return nil
+}
+
+func TestTraceFilter(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	t.Cleanup(mockCtrl.Finish)
+
+	target := utils.HexToFelt(t, "0xabc")
+	selector := utils.HexToFelt(t, "0x5e1")
+	invokeTrace := func(calls ...vm.FunctionInvocation) vm.TransactionTrace {
+		return vm.TransactionTrace{
+			Type: vm.TxnInvoke,
+			ExecuteInvocation: &vm.ExecuteInvocation{
+				FunctionInvocation: &vm.FunctionInvocation{ContractAddress: *utils.HexToFelt(t, "0x1"), Calls: calls},
+			},
+		}
+	}
+
+	// The first transaction of block 0 and the transaction of block 1 call the target contract internally.
+	store := memTraceStore{}
+	blocks := make([]*core.Block, 2)
+	for i := range blocks {
+		blocks[i] = &core.Block{Header: &core.Header{
+			Hash:   new(felt.Felt).SetUint64(uint64(100 + i)),
+			Number: uint64(i),
+		}}
+	}
+	blocks[0].Transactions = []core.Transaction{
+		&core.InvokeTransaction{TransactionHash: utils.HexToFelt(t, "0x10")},
+		&core.InvokeTransaction{TransactionHash: utils.HexToFelt(t, "0x11")},
+	}
+	store[*blocks[0].Hash] = []vm.TransactionTrace{
+		invokeTrace(vm.FunctionInvocation{Calls: []vm.FunctionInvocation{{ContractAddress: *target, EntryPointSelector: selector}}}),
+		invokeTrace(),
+	}
+	blocks[1].Transactions = []core.Transaction{&core.InvokeTransaction{TransactionHash: utils.HexToFelt(t, "0x20")}}
+	store[*blocks[1].Hash] = []vm.TransactionTrace{invokeTrace(vm.FunctionInvocation{ContractAddress: *target})}
+
+	mockReader := mocks.NewMockReader(mockCtrl)
+	mockReader.EXPECT().Height().Return(uint64(1), nil).AnyTimes()
+	for _, block := range blocks {
+		mockReader.EXPECT().BlockByNumber(block.Number).Return(block, nil).AnyTimes()
+	}
+	handler := rpc.New(mockReader, nil, nil, "", utils.NewNopZapLogger()).WithTraceStore(store)
+
+	t.Run("page size too big", func(t *testing.T) {
+		_, rpcErr := handler.TraceFilter(context.Background(), rpc.TraceFilterArg{
+			ResultPageRequest: rpc.ResultPageRequest{ChunkSize: 1025},
+		})
+		assert.Equal(t, rpc.ErrPageSizeTooBig, rpcErr)
+	})
+
+	t.Run("invalid continuation token", func(t *testing.T) {
+		_, rpcErr := handler.TraceFilter(context.Background(), rpc.TraceFilterArg{
+			ResultPageRequest: rpc.ResultPageRequest{ChunkSize: 1, ContinuationToken: "invalid"},
+		})
+		assert.Equal(t, rpc.ErrInvalidContinuationToken, rpcErr)
+	})
+
+	t.Run("paginates over internal calls", func(t *testing.T) {
+		args := rpc.TraceFilterArg{
+			TraceFilter:       rpc.TraceFilter{Address: target},
+			ResultPageRequest: rpc.ResultPageRequest{ChunkSize: 1},
+		}
+		chunk, rpcErr := handler.TraceFilter(context.Background(), args)
+		require.Nil(t, rpcErr)
+		require.Len(t, chunk.Traces, 1)
+		assert.Equal(t, utils.HexToFelt(t, "0x10"), chunk.Traces[0].TransactionHash)
+		assert.Equal(t, uint64(0), chunk.Traces[0].BlockNumber)
+		assert.Equal(t, "0-1", chunk.ContinuationToken)
+
+		args.ContinuationToken = chunk.ContinuationToken
+		chunk, rpcErr = handler.TraceFilter(context.Background(), args)
+		require.Nil(t, rpcErr)
+		require.Len(t, chunk.Traces, 1)
+		assert.Equal(t, utils.HexToFelt(t, "0x20"), chunk.Traces[0].TransactionHash)
+		assert.Equal(t, blocks[1].Hash, chunk.Traces[0].BlockHash)
+		assert.Empty(t, chunk.ContinuationToken)
+	})
+
+	t.Run("all criteria must match the same invocation", func(t *testing.T) {
+		chunk, rpcErr := handler.TraceFilter(context.Background(), rpc.TraceFilterArg{
+			TraceFilter:       rpc.TraceFilter{Address: target, EntryPointSelector: selector},
+			ResultPageRequest: rpc.ResultPageRequest{ChunkSize: 10},
+		})
+		require.Nil(t, rpcErr)
+		require.Len(t, chunk.Traces, 1)
+		assert.Equal(t, utils.HexToFelt(t, "0x10"), chunk.Traces[0].TransactionHash)
+	})
+
+	t.Run("block scan limit", func(t *testing.T) {
+		limited := rpc.New(mockReader, nil, nil, "", utils.NewNopZapLogger()).WithTraceStore(store).WithFilterLimit(1)
+		chunk, rpcErr := limited.TraceFilter(context.Background(), rpc.TraceFilterArg{
+			TraceFilter:       rpc.TraceFilter{Address: target},
+			ResultPageRequest: rpc.ResultPageRequest{ChunkSize: 10},
+		})
+		require.Nil(t, rpcErr)
+		require.Len(t, chunk.Traces, 1)
+		assert.Equal(t, "1-0", chunk.ContinuationToken)
+	})
+
+	t.Run("stored blocks don't count towards the trace limit", func(t *testing.T) {
+		limited := rpc.New(mockReader, nil, nil, "", utils.NewNopZapLogger()).WithTraceStore(store).WithTraceFilterLimit(0)
+		chunk, rpcErr := limited.TraceFilter(context.Background(), rpc.TraceFilterArg{
+			TraceFilter:       rpc.TraceFilter{Address: target},
+			ResultPageRequest: rpc.ResultPageRequest{ChunkSize: 10},
+		})
+		require.Nil(t, rpcErr)
+		require.Len(t, chunk.Traces, 2)
+		assert.Empty(t, chunk.ContinuationToken)
+	})
+
+	t.Run("trace limit", func(t *testing.T) {
+		partialStore := memTraceStore{*blocks[0].Hash: store[*blocks[0].Hash]}
+		limited := rpc.New(mockReader, nil, nil, "", utils.NewNopZapLogger()).WithTraceStore(partialStore).WithTraceFilterLimit(0)
+		chunk, rpcErr := limited.TraceFilter(context.Background(), rpc.TraceFilterArg{
+			TraceFilter:       rpc.TraceFilter{Address: target},
+			ResultPageRequest: rpc.ResultPageRequest{ChunkSize: 10},
+		})
+		require.Nil(t, rpcErr)
+		require.Len(t, chunk.Traces, 1)
+		assert.Equal(t, "1-0", chunk.ContinuationToken)
+	})
+}
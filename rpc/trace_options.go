@@ -0,0 +1,127 @@
+package rpc
+
+import (
+	"errors"
+
+	"github.com/NethermindEth/juno/blockchain"
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/jsonrpc"
+	"github.com/NethermindEth/juno/vm"
+)
+
+// TraceOptions adds optional outputs to the traces returned by starknet_traceTransaction and
+// starknet_traceBlockTransactions.
+type TraceOptions struct {
+	// Prestate adds the values that each transaction overwrote, read from the state before it was executed.
+	Prestate bool `json:"prestate"`
+	// CallList adds the invocations of each transaction as a flat list.
+	CallList bool `json:"call_list"`
+}
+
+// applyTraceOptions returns copies of the traces of block with the outputs requested by options added. The traces
+// themselves are left untouched, as they may be cached.
+func (h *Handler) applyTraceOptions(block *core.Block, traces []TracedBlockTransaction,
+	options *TraceOptions,
+) ([]TracedBlockTransaction, *jsonrpc.Error) {
+	if options == nil || (!options.Prestate && !options.CallList) {
+		return traces, nil
+	}
+
+	// The state before each transaction is the parent state with the state diffs of the transactions before it.
+	var (
+		state     core.StateReader
+		stateDiff *core.StateDiff
+	)
+	if options.Prestate {
+		parentState, closer, err := h.bcReader.StateAtBlockHash(block.ParentHash)
+		if err != nil {
+			if errors.Is(err, blockchain.ErrHistoryPruned) {
+				return nil, ErrHistoricalStatePruned
+			}
+			return nil, ErrBlockNotFound
+		}
+		defer h.callAndLogErr(closer, "Failed to close state in applyTraceOptions")
+
+		stateDiff = core.EmptyStateDiff()
+		state = blockchain.NewPendingState(stateDiff, nil, parentState)
+	}
+
+	result := make([]TracedBlockTransaction, 0, len(traces))
+	for _, traced := range traces {
+		trace := *traced.TraceRoot
+		if options.Prestate {
+			if trace.StateDiff == nil {
+				return nil, ErrUnexpectedError.CloneWithData("prestate requires state diffs, which aren't available for this block")
+			}
+			prestate, err := readPrestate(state, trace.StateDiff)
+			if err != nil {
+				return nil, ErrInternal.CloneWithData(err)
+			}
+			trace.Prestate = prestate
+			mergeTraceStateDiff(stateDiff, nil, trace.StateDiff, nil)
+		}
+		if options.CallList {
+			trace.CallList = trace.Flatten()
+		}
+		result = append(result, TracedBlockTransaction{
+			TraceRoot:       &trace,
+			TransactionHash: traced.TransactionHash,
+		})
+	}
+	return result, nil
+}
+
+// readPrestate reads the values in state of everything stateDiff changes. The class hashes of contracts deployed
+// by stateDiff are included, so that they are zero.
+func readPrestate(state core.StateReader, stateDiff *vm.StateDiff) (*vm.Prestate, error) {
+	prestate := &vm.Prestate{
+		StorageDiffs: make([]vm.StorageDiff, 0, len(stateDiff.StorageDiffs)),
+		Nonces:       make([]vm.Nonce, 0, len(stateDiff.Nonces)),
+		ClassHashes:  make([]vm.ReplacedClass, 0, len(stateDiff.DeployedContracts)+len(stateDiff.ReplacedClasses)),
+	}
+
+	for _, diff := range stateDiff.StorageDiffs {
+		entries := make([]vm.Entry, 0, len(diff.StorageEntries))
+		for _, entry := range diff.StorageEntries {
+			value, err := orZeroIfNotFound(state.ContractStorage(&diff.Address, &entry.Key))
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, vm.Entry{Key: entry.Key, Value: *value})
+		}
+		prestate.StorageDiffs = append(prestate.StorageDiffs, vm.StorageDiff{Address: diff.Address, StorageEntries: entries})
+	}
+	for _, nonce := range stateDiff.Nonces {
+		value, err := orZeroIfNotFound(state.ContractNonce(&nonce.ContractAddress))
+		if err != nil {
+			return nil, err
+		}
+		prestate.Nonces = append(prestate.Nonces, vm.Nonce{ContractAddress: nonce.ContractAddress, Nonce: *value})
+	}
+
+	addresses := make([]felt.Felt, 0, cap(prestate.ClassHashes))
+	for _, deployed := range stateDiff.DeployedContracts {
+		addresses = append(addresses, deployed.Address)
+	}
+	for _, replaced := range stateDiff.ReplacedClasses {
+		addresses = append(addresses, replaced.ContractAddress)
+	}
+	for i := range addresses {
+		classHash, err := orZeroIfNotFound(state.ContractClassHash(&addresses[i]))
+		if err != nil {
+			return nil, err
+		}
+		prestate.ClassHashes = append(prestate.ClassHashes, vm.ReplacedClass{ContractAddress: addresses[i], ClassHash: *classHash})
+	}
+	return prestate, nil
+}
+
+// orZeroIfNotFound maps the errors state readers return for values that were never set to zero.
+func orZeroIfNotFound(value *felt.Felt, err error) (*felt.Felt, error) {
+	if errors.Is(err, db.ErrKeyNotFound) || errors.Is(err, core.ErrContractNotDeployed) {
+		return &felt.Zero, nil
+	}
+	return value, err
+}
@@ -0,0 +1,161 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/service"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/NethermindEth/juno/vm"
+	"github.com/sourcegraph/conc"
+)
+
+// TraceStore persists the traces of canonical blocks, so that they survive restarts.
+type TraceStore interface {
+	BlockTraces(blockHash *felt.Felt) ([]vm.TransactionTrace, error)
+	StoreBlockTraces(blockHash *felt.Felt, traces []vm.TransactionTrace) error
+}
+
+// WithTraceStore makes the handler serve block traces from store, and save the traces it computes to it.
+func (h *Handler) WithTraceStore(store TraceStore) *Handler {
+	h.traceStore = store
+	return h
+}
+
+// storedBlockTraces returns the traces of block from the trace store, if they are there.
+func (h *Handler) storedBlockTraces(block *core.Block) ([]TracedBlockTransaction, bool) {
+	if h.traceStore == nil {
+		return nil, false
+	}
+
+	traces, err := h.traceStore.BlockTraces(block.Hash)
+	if err != nil {
+		if !errors.Is(err, db.ErrKeyNotFound) {
+			h.log.Warnw("Failed to read block traces", "number", block.Number, "err", err)
+		}
+		return nil, false
+	}
+	if len(traces) != len(block.Transactions) {
+		return nil, false
+	}
+
+	result := make([]TracedBlockTransaction, 0, len(traces))
+	for index := range traces {
+		result = append(result, TracedBlockTransaction{
+			TraceRoot:       &traces[index],
+			TransactionHash: block.Transactions[index].Hash(),
+		})
+	}
+	return result, true
+}
+
+func (h *Handler) storeBlockTraces(block *core.Block, result []TracedBlockTransaction) {
+	if h.traceStore == nil {
+		return
+	}
+
+	traces := make([]vm.TransactionTrace, 0, len(result))
+	for _, trace := range result {
+		traces = append(traces, *trace.TraceRoot)
+	}
+	if err := h.traceStore.StoreBlockTraces(block.Hash, traces); err != nil {
+		h.log.Warnw("Failed to store block traces", "number", block.Number, "err", err)
+	}
+}
+
+// BlockTracer traces blocks as they are stored, and optionally a range of older blocks, so that the traces are in
+// the trace store of the handler by the time they are requested.
+type BlockTracer struct {
+	handler *Handler
+	log     utils.SimpleLogger
+
+	backfill     bool
+	backfillFrom uint64
+	backfillTo   uint64
+}
+
+var _ service.Service = (*BlockTracer)(nil)
+
+func NewBlockTracer(h *Handler, log utils.SimpleLogger) *BlockTracer {
+	return &BlockTracer{
+		handler: h,
+		log:     log,
+	}
+}
+
+// WithBackfill makes the tracer also trace the blocks in [from, to] that aren't in the trace store yet, up to
+// the head at the time the tracer starts.
+func (t *BlockTracer) WithBackfill(from, to uint64) *BlockTracer {
+	t.backfill = true
+	t.backfillFrom = from
+	t.backfillTo = to
+	return t
+}
+
+func (t *BlockTracer) Run(ctx context.Context) error {
+	if t.handler.traceStore == nil {
+		return errors.New("block tracer requires a trace store")
+	}
+
+	newHeadsSub := t.handler.syncReader.SubscribeNewHeads()
+	defer newHeadsSub.Unsubscribe()
+
+	var wg conc.WaitGroup
+	defer wg.Wait()
+	if t.backfill {
+		wg.Go(func() {
+			// Blocks above the head are traced as they are stored, backfilling stops at the head.
+			height, err := t.handler.bcReader.Height()
+			if err != nil {
+				t.log.Warnw("Failed to get the head to backfill block traces up to", "err", err)
+				return
+			}
+			to := min(t.backfillTo, height)
+			for number := t.backfillFrom; number <= to && ctx.Err() == nil; number++ {
+				t.traceBlock(ctx, number)
+			}
+			t.log.Infow("Finished backfilling block traces", "from", t.backfillFrom, "to", to)
+		})
+	}
+
+	// New heads can be dropped while a block is being traced, so the blocks in between are traced as well.
+	var next uint64
+	first := true
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case header, ok := <-newHeadsSub.Recv():
+			if !ok {
+				return nil
+			}
+			if first || header.Number < next {
+				next = header.Number
+				first = false
+			}
+			for ; next <= header.Number && ctx.Err() == nil; next++ {
+				t.traceBlock(ctx, next)
+			}
+		}
+	}
+}
+
+// traceBlock traces the block with the given number, unless its traces are stored already.
+func (t *BlockTracer) traceBlock(ctx context.Context, number uint64) {
+	block, err := t.handler.bcReader.BlockByNumber(number)
+	if err != nil {
+		t.log.Debugw("Failed to get block to trace", "number", number, "err", err)
+		return
+	}
+	if _, err = t.handler.traceStore.BlockTraces(block.Hash); err == nil {
+		return
+	}
+
+	// traceBlockTransactions saves the traces to the trace store.
+	if _, _, rpcErr := t.handler.traceBlockTransactions(ctx, block); rpcErr != nil {
+		t.log.Warnw("Failed to trace block", "number", number, "err", rpcErr)
+	}
+}
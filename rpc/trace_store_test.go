@@ -0,0 +1,290 @@
+package rpc_test
+
+import (
+	"context"
+	"errors"
+	stdsync "sync"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/feed"
+	"github.com/NethermindEth/juno/mocks"
+	"github.com/NethermindEth/juno/rpc"
+	"github.com/NethermindEth/juno/sync"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/NethermindEth/juno/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// lockedTraceStore keeps block traces in memory for concurrent use, counts the attempts to store them and fails
+// them if storeErr is set.
+type lockedTraceStore struct {
+	mu       stdsync.Mutex
+	traces   map[felt.Felt][]vm.TransactionTrace
+	stores   int
+	storeErr error
+}
+
+func newLockedTraceStore() *lockedTraceStore {
+	return &lockedTraceStore{traces: make(map[felt.Felt][]vm.TransactionTrace)}
+}
+
+func (s *lockedTraceStore) BlockTraces(blockHash *felt.Felt) ([]vm.TransactionTrace, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	traces, ok := s.traces[*blockHash]
+	if !ok {
+		return nil, db.ErrKeyNotFound
+	}
+	return traces, nil
+}
+
+func (s *lockedTraceStore) StoreBlockTraces(blockHash *felt.Felt, traces []vm.TransactionTrace) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stores++
+	if s.storeErr != nil {
+		return s.storeErr
+	}
+	s.traces[*blockHash] = traces
+	return nil
+}
+
+func (s *lockedTraceStore) storedBlocks() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.traces)
+}
+
+func (s *lockedTraceStore) storeAttempts() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stores
+}
+
+// traceStoreBlock returns a block with a single invoke transaction that is traced by the VM.
+func traceStoreBlock(number uint64) *core.Block {
+	return &core.Block{
+		Header: &core.Header{
+			Hash:            new(felt.Felt).SetUint64(number + 1),
+			ParentHash:      new(felt.Felt).SetUint64(number),
+			Number:          number,
+			ProtocolVersion: "99.12.3",
+		},
+		Transactions: []core.Transaction{&core.InvokeTransaction{
+			TransactionHash: new(felt.Felt).SetUint64(0x100 + number),
+		}},
+	}
+}
+
+func TestTraceStore(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	t.Cleanup(mockCtrl.Finish)
+
+	n := utils.Ptr(utils.Mainnet)
+	mockReader := mocks.NewMockReader(mockCtrl)
+	mockReader.EXPECT().Network().Return(n).AnyTimes()
+	mockVM := mocks.NewMockVM(mockCtrl)
+	trace := vm.TransactionTrace{Type: vm.TxnInvoke}
+
+	t.Run("stored traces are served without running the VM", func(t *testing.T) {
+		block := traceStoreBlock(0)
+		store := newLockedTraceStore()
+		require.NoError(t, store.StoreBlockTraces(block.Hash, []vm.TransactionTrace{trace}))
+		handler := rpc.New(mockReader, nil, mockVM, "", utils.NewNopZapLogger()).WithTraceStore(store)
+
+		mockReader.EXPECT().BlockByHash(block.Hash).Return(block, nil)
+		result, _, rpcErr := handler.TraceBlockTransactions(context.Background(), rpc.BlockID{Hash: block.Hash}, nil)
+		require.Nil(t, rpcErr)
+		assert.Equal(t, []rpc.TracedBlockTransaction{{
+			TraceRoot:       &trace,
+			TransactionHash: block.Transactions[0].Hash(),
+		}}, result)
+	})
+
+	t.Run("traces run by the VM are stored", func(t *testing.T) {
+		block := traceStoreBlock(1)
+		store := newLockedTraceStore()
+		handler := rpc.New(mockReader, nil, mockVM, "", utils.NewNopZapLogger()).WithTraceStore(store)
+
+		mockReader.EXPECT().BlockByHash(block.Hash).Return(block, nil)
+		mockReader.EXPECT().StateAtBlockHash(block.ParentHash).Return(nil, nopCloser, nil)
+		mockReader.EXPECT().HeadState().Return(nil, nopCloser, nil)
+		mockVM.EXPECT().Execute(block.Transactions, nil, []*felt.Felt{}, gomock.Any(), gomock.Any(), n, false, false, false).
+			Return(nil, []core.GasConsumed{{}}, []vm.TransactionTrace{trace}, uint64(1), nil)
+
+		_, _, rpcErr := handler.TraceBlockTransactions(context.Background(), rpc.BlockID{Hash: block.Hash}, nil)
+		require.Nil(t, rpcErr)
+		stored, err := store.BlockTraces(block.Hash)
+		require.NoError(t, err)
+		assert.Len(t, stored, 1)
+	})
+
+	t.Run("stored traces that don't match the block are traced again", func(t *testing.T) {
+		block := traceStoreBlock(2)
+		store := newLockedTraceStore()
+		require.NoError(t, store.StoreBlockTraces(block.Hash, []vm.TransactionTrace{trace, trace}))
+		handler := rpc.New(mockReader, nil, mockVM, "", utils.NewNopZapLogger()).WithTraceStore(store)
+
+		mockReader.EXPECT().BlockByHash(block.Hash).Return(block, nil)
+		mockReader.EXPECT().StateAtBlockHash(block.ParentHash).Return(nil, nopCloser, nil)
+		mockReader.EXPECT().HeadState().Return(nil, nopCloser, nil)
+		mockVM.EXPECT().Execute(block.Transactions, nil, []*felt.Felt{}, gomock.Any(), gomock.Any(), n, false, false, false).
+			Return(nil, []core.GasConsumed{{}}, []vm.TransactionTrace{trace}, uint64(1), nil)
+
+		result, _, rpcErr := handler.TraceBlockTransactions(context.Background(), rpc.BlockID{Hash: block.Hash}, nil)
+		require.Nil(t, rpcErr)
+		assert.Len(t, result, 1)
+		stored, err := store.BlockTraces(block.Hash)
+		require.NoError(t, err)
+		assert.Len(t, stored, 1)
+	})
+
+	t.Run("failing to store the traces doesn't fail the request", func(t *testing.T) {
+		block := traceStoreBlock(3)
+		store := newLockedTraceStore()
+		store.storeErr = errors.New("disk full")
+		handler := rpc.New(mockReader, nil, mockVM, "", utils.NewNopZapLogger()).WithTraceStore(store)
+
+		mockReader.EXPECT().BlockByHash(block.Hash).Return(block, nil)
+		mockReader.EXPECT().StateAtBlockHash(block.ParentHash).Return(nil, nopCloser, nil)
+		mockReader.EXPECT().HeadState().Return(nil, nopCloser, nil)
+		mockVM.EXPECT().Execute(block.Transactions, nil, []*felt.Felt{}, gomock.Any(), gomock.Any(), n, false, false, false).
+			Return(nil, []core.GasConsumed{{}}, []vm.TransactionTrace{trace}, uint64(1), nil)
+
+		result, _, rpcErr := handler.TraceBlockTransactions(context.Background(), rpc.BlockID{Hash: block.Hash}, nil)
+		require.Nil(t, rpcErr)
+		assert.Len(t, result, 1)
+		assert.Equal(t, 1, store.storeAttempts())
+		assert.Zero(t, store.storedBlocks())
+	})
+}
+
+func TestBlockTracer(t *testing.T) {
+	n := utils.Ptr(utils.Mainnet)
+	trace := vm.TransactionTrace{Type: vm.TxnInvoke}
+
+	// newTracer returns a tracer over a chain whose head is the given block, and the feed of its new heads.
+	newTracer := func(t *testing.T, head uint64, store rpc.TraceStore) (*rpc.BlockTracer, *feed.Feed[*core.Header]) {
+		mockCtrl := gomock.NewController(t)
+		t.Cleanup(mockCtrl.Finish)
+
+		mockReader := mocks.NewMockReader(mockCtrl)
+		mockReader.EXPECT().Network().Return(n).AnyTimes()
+		mockReader.EXPECT().Height().DoAndReturn(func() (uint64, error) {
+			return head, nil
+		}).AnyTimes()
+		mockReader.EXPECT().BlockByNumber(gomock.Any()).DoAndReturn(func(number uint64) (*core.Block, error) {
+			if number > head {
+				t.Errorf("block %d above the head is read", number)
+				return nil, db.ErrKeyNotFound
+			}
+			return traceStoreBlock(number), nil
+		}).AnyTimes()
+		mockReader.EXPECT().StateAtBlockHash(gomock.Any()).Return(nil, nopCloser, nil).AnyTimes()
+		mockReader.EXPECT().HeadState().Return(nil, nopCloser, nil).AnyTimes()
+		mockReader.EXPECT().BlockHeaderByNumber(gomock.Any()).DoAndReturn(func(number uint64) (*core.Header, error) {
+			return traceStoreBlock(number).Header, nil
+		}).AnyTimes()
+
+		mockVM := mocks.NewMockVM(mockCtrl)
+		mockVM.EXPECT().Execute(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), n, false, false, false).
+			Return(nil, []core.GasConsumed{{}}, []vm.TransactionTrace{trace}, uint64(1), nil).AnyTimes()
+
+		newHeads := feed.New[*core.Header]()
+		mockSyncReader := mocks.NewMockSyncReader(mockCtrl)
+		mockSyncReader.EXPECT().SubscribeNewHeads().Return(sync.HeaderSubscription{Subscription: newHeads.Subscribe()})
+
+		handler := rpc.New(mockReader, mockSyncReader, mockVM, "", utils.NewNopZapLogger()).WithTraceStore(store)
+		return rpc.NewBlockTracer(handler, utils.NewNopZapLogger()), newHeads
+	}
+
+	run := func(t *testing.T, tracer *rpc.BlockTracer) {
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() {
+			done <- tracer.Run(ctx)
+		}()
+		t.Cleanup(func() {
+			cancel()
+			require.NoError(t, <-done)
+		})
+	}
+
+	t.Run("a trace store is required", func(t *testing.T) {
+		handler := rpc.New(nil, nil, nil, "", utils.NewNopZapLogger())
+		require.Error(t, rpc.NewBlockTracer(handler, utils.NewNopZapLogger()).Run(context.Background()))
+	})
+
+	t.Run("backfills the range up to the head", func(t *testing.T) {
+		store := newLockedTraceStore()
+		tracer, _ := newTracer(t, 5, store)
+		run(t, tracer.WithBackfill(2, 100))
+
+		require.Eventually(t, func() bool {
+			return store.storedBlocks() == 4
+		}, 5*time.Second, 10*time.Millisecond)
+		for number := range uint64(6) {
+			_, err := store.BlockTraces(traceStoreBlock(number).Hash)
+			if number < 2 {
+				assert.ErrorIs(t, err, db.ErrKeyNotFound)
+			} else {
+				assert.NoError(t, err)
+			}
+		}
+	})
+
+	t.Run("blocks that are stored already are not traced again", func(t *testing.T) {
+		store := newLockedTraceStore()
+		require.NoError(t, store.StoreBlockTraces(traceStoreBlock(1).Hash, []vm.TransactionTrace{trace}))
+		tracer, _ := newTracer(t, 2, store)
+		run(t, tracer.WithBackfill(0, 2))
+
+		require.Eventually(t, func() bool {
+			return store.storedBlocks() == 3
+		}, 5*time.Second, 10*time.Millisecond)
+		assert.Equal(t, 3, store.storeAttempts())
+	})
+
+	t.Run("new heads and the blocks dropped in between are traced", func(t *testing.T) {
+		store := newLockedTraceStore()
+		tracer, newHeads := newTracer(t, 6, store)
+		run(t, tracer)
+
+		require.Eventually(t, func() bool {
+			newHeads.Send(traceStoreBlock(3).Header)
+			return store.storedBlocks() > 0
+		}, 5*time.Second, 10*time.Millisecond)
+		newHeads.Send(traceStoreBlock(6).Header)
+
+		require.Eventually(t, func() bool {
+			return store.storedBlocks() == 4
+		}, 5*time.Second, 10*time.Millisecond)
+		for number := uint64(3); number <= 6; number++ {
+			_, err := store.BlockTraces(traceStoreBlock(number).Hash)
+			assert.NoError(t, err)
+		}
+	})
+
+	t.Run("store errors don't stop the tracer", func(t *testing.T) {
+		store := newLockedTraceStore()
+		store.storeErr = errors.New("disk full")
+		tracer, newHeads := newTracer(t, 4, store)
+		run(t, tracer.WithBackfill(0, 2))
+
+		require.Eventually(t, func() bool {
+			return store.storeAttempts() == 3
+		}, 5*time.Second, 10*time.Millisecond)
+
+		require.Eventually(t, func() bool {
+			newHeads.Send(traceStoreBlock(4).Header)
+			return store.storeAttempts() >= 4
+		}, 5*time.Second, 10*time.Millisecond)
+		assert.Zero(t, store.storedBlocks())
+	})
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/NethermindEth/juno/core/felt"
 	"github.com/NethermindEth/juno/db"
 	"github.com/NethermindEth/juno/db/pebble"
+	"github.com/NethermindEth/juno/jsonrpc"
 	"github.com/NethermindEth/juno/mocks"
 	"github.com/NethermindEth/juno/rpc"
 	adaptfeeder "github.com/NethermindEth/juno/starknetdata/feeder"
@@ -59,12 +60,12 @@ func TestTraceFallback(t *testing.T) {
 				return mockReader.BlockByNumber(test.blockNumber)
 			}).Times(2)
 			handler := rpc.New(mockReader, nil, nil, "", nil)
-			_, httpHeader, jErr := handler.TraceBlockTransactions(context.Background(), rpc.BlockID{Number: test.blockNumber})
+			_, httpHeader, jErr := handler.TraceBlockTransactions(context.Background(), rpc.BlockID{Number: test.blockNumber}, nil)
 			require.Equal(t, rpc.ErrInternal.Code, jErr.Code)
 			assert.Equal(t, httpHeader.Get(rpc.ExecutionStepsHeader), "0")
 
 			handler = handler.WithFeeder(client)
-			trace, httpHeader, jErr := handler.TraceBlockTransactions(context.Background(), rpc.BlockID{Number: test.blockNumber})
+			trace, httpHeader, jErr := handler.TraceBlockTransactions(context.Background(), rpc.BlockID{Number: test.blockNumber}, nil)
 			require.Nil(t, jErr)
 			assert.Equal(t, httpHeader.Get(rpc.ExecutionStepsHeader), "0")
 			jsonStr, err := json.Marshal(trace)
@@ -88,7 +89,7 @@ func TestTraceTransaction(t *testing.T) {
 		// Receipt() returns error related to db
 		mockReader.EXPECT().Receipt(hash).Return(nil, nil, uint64(0), db.ErrKeyNotFound)
 
-		trace, httpHeader, err := handler.TraceTransaction(context.Background(), *hash)
+		trace, httpHeader, err := handler.TraceTransaction(context.Background(), *hash, nil)
 		assert.Nil(t, trace)
 		assert.Equal(t, rpc.ErrTxnHashNotFound, err)
 		assert.Equal(t, httpHeader.Get(rpc.ExecutionStepsHeader), "0")
@@ -166,7 +167,7 @@ func TestTraceTransaction(t *testing.T) {
 			&vm.BlockInfo{Header: header}, gomock.Any(), &utils.Mainnet, false, false,
 			false).Return(overallFee, consumedGas, []vm.TransactionTrace{*vmTrace}, stepsUsed, nil)
 
-		trace, httpHeader, err := handler.TraceTransaction(context.Background(), *hash)
+		trace, httpHeader, err := handler.TraceTransaction(context.Background(), *hash, nil)
 		require.Nil(t, err)
 		assert.Equal(t, httpHeader.Get(rpc.ExecutionStepsHeader), stepsUsedStr)
 
@@ -257,7 +258,7 @@ func TestTraceTransaction(t *testing.T) {
 			&vm.BlockInfo{Header: header}, gomock.Any(), &utils.Mainnet, false, false, false).
 			Return(overallFee, consumedGas, []vm.TransactionTrace{*vmTrace}, stepsUsed, nil)
 
-		trace, httpHeader, err := handler.TraceTransaction(context.Background(), *hash)
+		trace, httpHeader, err := handler.TraceTransaction(context.Background(), *hash, nil)
 		require.Nil(t, err)
 		assert.Equal(t, httpHeader.Get(rpc.ExecutionStepsHeader), stepsUsedStr)
 
@@ -286,7 +287,7 @@ func TestTraceBlockTransactions(t *testing.T) {
 			chain := blockchain.New(pebble.NewMemTest(t), n)
 			handler := rpc.New(chain, nil, nil, "", log)
 
-			update, httpHeader, rpcErr := handler.TraceBlockTransactions(context.Background(), id)
+			update, httpHeader, rpcErr := handler.TraceBlockTransactions(context.Background(), id, nil)
 			assert.Nil(t, update)
 			assert.Equal(t, httpHeader.Get(rpc.ExecutionStepsHeader), "0")
 			assert.Equal(t, rpc.ErrBlockNotFound, rpcErr)
@@ -358,7 +359,7 @@ func TestTraceBlockTransactions(t *testing.T) {
 			gomock.Any(), n, false, false, false).
 			Return(nil, []core.GasConsumed{{}, {}}, []vm.TransactionTrace{vmTrace, vmTrace}, stepsUsed, nil)
 
-		result, httpHeader, err := handler.TraceBlockTransactions(context.Background(), rpc.BlockID{Hash: blockHash})
+		result, httpHeader, err := handler.TraceBlockTransactions(context.Background(), rpc.BlockID{Hash: blockHash}, nil)
 		require.Nil(t, err)
 		assert.Equal(t, httpHeader.Get(rpc.ExecutionStepsHeader), stepsUsedStr)
 		assert.Equal(t, &vm.TransactionTrace{
@@ -440,7 +441,7 @@ func TestTraceBlockTransactions(t *testing.T) {
 				TraceRoot:       &vmTrace,
 			},
 		}
-		result, httpHeader, err := handler.TraceBlockTransactions(context.Background(), rpc.BlockID{Hash: blockHash})
+		result, httpHeader, err := handler.TraceBlockTransactions(context.Background(), rpc.BlockID{Hash: blockHash}, nil)
 		require.Nil(t, err)
 		assert.Equal(t, httpHeader.Get(rpc.ExecutionStepsHeader), stepsUsedStr)
 		assert.Equal(t, expectedResult, result)
@@ -459,7 +460,7 @@ func TestCall(t *testing.T) {
 	t.Run("empty blockchain", func(t *testing.T) {
 		mockReader.EXPECT().HeadState().Return(nil, nil, db.ErrKeyNotFound)
 
-		res, rpcErr := handler.Call(rpc.FunctionCall{}, rpc.BlockID{Latest: true})
+		res, rpcErr := handler.Call(rpc.FunctionCall{}, rpc.BlockID{Latest: true}, nil)
 		require.Nil(t, res)
 		assert.Equal(t, rpc.ErrBlockNotFound, rpcErr)
 	})
@@ -467,7 +468,7 @@ func TestCall(t *testing.T) {
 	t.Run("non-existent block hash", func(t *testing.T) {
 		mockReader.EXPECT().StateAtBlockHash(&felt.Zero).Return(nil, nil, db.ErrKeyNotFound)
 
-		res, rpcErr := handler.Call(rpc.FunctionCall{}, rpc.BlockID{Hash: &felt.Zero})
+		res, rpcErr := handler.Call(rpc.FunctionCall{}, rpc.BlockID{Hash: &felt.Zero}, nil)
 		require.Nil(t, res)
 		assert.Equal(t, rpc.ErrBlockNotFound, rpcErr)
 	})
@@ -475,7 +476,7 @@ func TestCall(t *testing.T) {
 	t.Run("non-existent block number", func(t *testing.T) {
 		mockReader.EXPECT().StateAtBlockNumber(uint64(0)).Return(nil, nil, db.ErrKeyNotFound)
 
-		res, rpcErr := handler.Call(rpc.FunctionCall{}, rpc.BlockID{Number: 0})
+		res, rpcErr := handler.Call(rpc.FunctionCall{}, rpc.BlockID{Number: 0}, nil)
 		require.Nil(t, res)
 		assert.Equal(t, rpc.ErrBlockNotFound, rpcErr)
 	})
@@ -487,7 +488,7 @@ func TestCall(t *testing.T) {
 		mockReader.EXPECT().HeadsHeader().Return(new(core.Header), nil)
 		mockState.EXPECT().ContractClassHash(&felt.Zero).Return(nil, errors.New("unknown contract"))
 
-		res, rpcErr := handler.Call(rpc.FunctionCall{}, rpc.BlockID{Latest: true})
+		res, rpcErr := handler.Call(rpc.FunctionCall{}, rpc.BlockID{Latest: true}, nil)
 		require.Nil(t, res)
 		assert.Equal(t, rpc.ErrContractNotFound, rpcErr)
 	})
@@ -526,8 +527,153 @@ func TestCall(t *testing.T) {
 			ContractAddress:    *contractAddr,
 			EntryPointSelector: *selector,
 			Calldata:           calldata,
-		}, rpc.BlockID{Latest: true})
+		}, rpc.BlockID{Latest: true}, nil)
 		require.Nil(t, rpcErr)
 		require.Equal(t, expectedRes, res)
 	})
+
+	t.Run("state override", func(t *testing.T) {
+		contractAddr := new(felt.Felt).SetUint64(1)
+		newContractAddr := new(felt.Felt).SetUint64(2)
+		overriddenClassHash := new(felt.Felt).SetUint64(3)
+		storageKey := new(felt.Felt).SetUint64(4)
+		storageValue := new(felt.Felt).SetUint64(5)
+		nonce := new(felt.Felt).SetUint64(6)
+		expectedRes := []*felt.Felt{new(felt.Felt).SetUint64(7)}
+
+		headsHeader := &core.Header{Number: 9}
+		mockReader.EXPECT().HeadState().Return(mockState, nopCloser, nil)
+		mockReader.EXPECT().HeadsHeader().Return(headsHeader, nil)
+		mockReader.EXPECT().Network().Return(n)
+		mockState.EXPECT().ContractClassHash(contractAddr).Return(new(felt.Felt).SetUint64(8), nil)
+		mockState.EXPECT().ContractClassHash(newContractAddr).Return(nil, db.ErrKeyNotFound)
+		mockVM.EXPECT().Call(&vm.CallInfo{
+			ContractAddress: contractAddr,
+			ClassHash:       overriddenClassHash,
+			Selector:        &felt.Zero,
+		}, &vm.BlockInfo{Header: headsHeader}, gomock.Any(), n, uint64(1337)).DoAndReturn(
+			func(_ *vm.CallInfo, _ *vm.BlockInfo, state core.StateReader, _ *utils.Network, _ uint64) ([]*felt.Felt, error) {
+				value, err := state.ContractStorage(contractAddr, storageKey)
+				require.NoError(t, err)
+				assert.Equal(t, storageValue, value)
+
+				contractNonce, err := state.ContractNonce(contractAddr)
+				require.NoError(t, err)
+				assert.Equal(t, nonce, contractNonce)
+
+				// The class hash of an address without a contract is overridden by deploying one there.
+				classHash, err := state.ContractClassHash(newContractAddr)
+				require.NoError(t, err)
+				assert.Equal(t, overriddenClassHash, classHash)
+				contractNonce, err = state.ContractNonce(newContractAddr)
+				require.NoError(t, err)
+				assert.Equal(t, &felt.Zero, contractNonce)
+				return expectedRes, nil
+			})
+
+		res, rpcErr := handler.Call(rpc.FunctionCall{ContractAddress: *contractAddr}, rpc.BlockID{Latest: true}, &rpc.StateOverride{
+			Contracts: []rpc.ContractOverride{
+				{
+					Address:   *contractAddr,
+					ClassHash: overriddenClassHash,
+					Nonce:     nonce,
+					Storage:   []rpc.Entry{{Key: *storageKey, Value: *storageValue}},
+				},
+				{Address: *newContractAddr, ClassHash: overriddenClassHash},
+			},
+		})
+		require.Nil(t, rpcErr)
+		assert.Equal(t, expectedRes, res)
+	})
+
+	t.Run("state override with invalid class", func(t *testing.T) {
+		mockReader.EXPECT().HeadState().Return(mockState, nopCloser, nil)
+
+		res, rpcErr := handler.Call(rpc.FunctionCall{}, rpc.BlockID{Latest: true}, &rpc.StateOverride{
+			Classes: []json.RawMessage{json.RawMessage(`{}`)},
+		})
+		require.Nil(t, res)
+		assert.Equal(t, jsonrpc.InvalidParams, rpcErr.Code)
+	})
+}
+
+func TestTraceOptions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	t.Cleanup(mockCtrl.Finish)
+
+	contract := utils.HexToFelt(t, "0xc")
+	deployed := utils.HexToFelt(t, "0xd")
+	key := utils.HexToFelt(t, "0x1")
+	block := &core.Block{
+		Header: &core.Header{
+			Hash:       utils.HexToFelt(t, "0xb"),
+			ParentHash: utils.HexToFelt(t, "0xa"),
+			Number:     1,
+		},
+		Transactions: []core.Transaction{
+			&core.InvokeTransaction{TransactionHash: utils.HexToFelt(t, "0x10")},
+			&core.InvokeTransaction{TransactionHash: utils.HexToFelt(t, "0x11")},
+		},
+	}
+
+	// The second transaction overwrites what the first one wrote, and deploys a contract.
+	store := memTraceStore{*block.Hash: {
+		{
+			Type: vm.TxnInvoke,
+			ExecuteInvocation: &vm.ExecuteInvocation{FunctionInvocation: &vm.FunctionInvocation{
+				ContractAddress: *contract,
+				Calls:           []vm.FunctionInvocation{{ContractAddress: *deployed}, {ContractAddress: *key}},
+			}},
+			StateDiff: &vm.StateDiff{
+				StorageDiffs: []vm.StorageDiff{{Address: *contract, StorageEntries: []vm.Entry{{Key: *key, Value: *utils.HexToFelt(t, "0x6")}}}},
+				Nonces:       []vm.Nonce{{ContractAddress: *contract, Nonce: *utils.HexToFelt(t, "0x1")}},
+			},
+		},
+		{
+			Type:               vm.TxnInvoke,
+			ValidateInvocation: &vm.FunctionInvocation{ContractAddress: *contract},
+			StateDiff: &vm.StateDiff{
+				StorageDiffs:      []vm.StorageDiff{{Address: *contract, StorageEntries: []vm.Entry{{Key: *key, Value: *utils.HexToFelt(t, "0x7")}}}},
+				Nonces:            []vm.Nonce{{ContractAddress: *contract, Nonce: *utils.HexToFelt(t, "0x2")}},
+				DeployedContracts: []vm.DeployedContract{{Address: *deployed, ClassHash: *utils.HexToFelt(t, "0xcc")}},
+			},
+		},
+	}}
+
+	mockReader := mocks.NewMockReader(mockCtrl)
+	mockReader.EXPECT().BlockByNumber(block.Number).Return(block, nil).AnyTimes()
+	mockState := mocks.NewMockStateHistoryReader(mockCtrl)
+	mockReader.EXPECT().StateAtBlockHash(block.ParentHash).Return(mockState, nopCloser, nil)
+	mockState.EXPECT().ContractStorage(contract, key).Return(utils.HexToFelt(t, "0x5"), nil)
+	mockState.EXPECT().ContractNonce(contract).Return(nil, db.ErrKeyNotFound)
+	mockState.EXPECT().ContractClassHash(deployed).Return(nil, db.ErrKeyNotFound)
+	handler := rpc.New(mockReader, nil, nil, "", utils.NewNopZapLogger()).WithTraceStore(store)
+
+	traces, _, rpcErr := handler.TraceBlockTransactions(context.Background(), rpc.BlockID{Number: block.Number},
+		&rpc.TraceOptions{Prestate: true, CallList: true})
+	require.Nil(t, rpcErr)
+	require.Len(t, traces, 2)
+
+	assert.Equal(t, &vm.Prestate{
+		StorageDiffs: []vm.StorageDiff{{Address: *contract, StorageEntries: []vm.Entry{{Key: *key, Value: *utils.HexToFelt(t, "0x5")}}}},
+		Nonces:       []vm.Nonce{{ContractAddress: *contract, Nonce: felt.Zero}},
+		ClassHashes:  []vm.ReplacedClass{},
+	}, traces[0].TraceRoot.Prestate)
+	assert.Equal(t, &vm.Prestate{
+		StorageDiffs: []vm.StorageDiff{{Address: *contract, StorageEntries: []vm.Entry{{Key: *key, Value: *utils.HexToFelt(t, "0x6")}}}},
+		Nonces:       []vm.Nonce{{ContractAddress: *contract, Nonce: *utils.HexToFelt(t, "0x1")}},
+		ClassHashes:  []vm.ReplacedClass{{ContractAddress: *deployed, ClassHash: felt.Zero}},
+	}, traces[1].TraceRoot.Prestate)
+
+	callList := traces[0].TraceRoot.CallList
+	require.Len(t, callList, 3)
+	assert.Equal(t, []int{}, callList[0].TraceAddress)
+	assert.Equal(t, []int{1}, callList[2].TraceAddress)
+	assert.Equal(t, *key, callList[2].ContractAddress)
+	assert.Equal(t, "execute_invocation", callList[2].Root)
+	assert.Equal(t, "validate_invocation", traces[1].TraceRoot.CallList[0].Root)
+
+	// The stored traces are left untouched.
+	assert.Nil(t, store[*block.Hash][0].Prestate)
+	assert.Nil(t, store[*block.Hash][0].CallList)
 }
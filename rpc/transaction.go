@@ -12,8 +12,10 @@ import (
 	"github.com/NethermindEth/juno/core"
 	"github.com/NethermindEth/juno/core/felt"
 	"github.com/NethermindEth/juno/jsonrpc"
+	"github.com/NethermindEth/juno/mempool"
 	"github.com/NethermindEth/juno/starknet"
 	"github.com/NethermindEth/juno/utils"
+	"github.com/NethermindEth/juno/vm"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/jinzhu/copier"
 )
@@ -473,6 +475,12 @@ func (h *Handler) TransactionByBlockIDAndIndex(id BlockID, txIndex int) (*Transa
 // It follows the specification defined here:
 // https://github.com/starkware-libs/starknet-specs/blob/master/api/starknet_api_openrpc.json#L222
 func (h *Handler) TransactionReceiptByHash(hash felt.Felt) (*TransactionReceipt, *jsonrpc.Error) {
+	return cachedResponse(h, &responseCacheKey{method: "starknet_getTransactionReceipt", hash: hash},
+		func() (*TransactionReceipt, *jsonrpc.Error) { return h.transactionReceiptByHash(hash) },
+		func(receipt *TransactionReceipt) bool { return receipt.FinalityStatus == TxnAcceptedOnL1 })
+}
+
+func (h *Handler) transactionReceiptByHash(hash felt.Felt) (*TransactionReceipt, *jsonrpc.Error) {
 	txn, err := h.bcReader.TransactionByHash(&hash)
 	if err != nil {
 		return nil, ErrTxnHashNotFound
@@ -499,8 +507,22 @@ func (h *Handler) TransactionReceiptByHash(hash felt.Felt) (*TransactionReceipt,
 	return AdaptReceipt(receipt, txn, status, blockHash, blockNumber), nil
 }
 
-// AddTransaction relays a transaction to the gateway.
+// AddTransaction relays a transaction to the gateway. If a mempool is configured the transaction is
+// validated against the pending state first, and relayed by the mempool.
 func (h *Handler) AddTransaction(ctx context.Context, tx BroadcastedTransaction) (*AddTxResponse, *jsonrpc.Error) { //nolint:gocritic
+	var pooledTxn *mempool.BroadcastedTransaction
+	if h.mempool != nil {
+		// Adapt before the contract class is rewritten into the gateway format below.
+		txn, declaredClass, _, err := adaptBroadcastedTransaction(&tx, h.bcReader.Network())
+		if err != nil {
+			return nil, jsonrpc.Err(jsonrpc.InvalidParams, err.Error())
+		}
+		pooledTxn = &mempool.BroadcastedTransaction{
+			Transaction:   txn,
+			DeclaredClass: declaredClass,
+		}
+	}
+
 	if tx.Type == TxnDeclare && tx.Version.Cmp(new(felt.Felt).SetUint64(2)) != -1 {
 		contractClass := make(map[string]any)
 		if err := json.Unmarshal(tx.ContractClass, &contractClass); err != nil {
@@ -540,6 +562,11 @@ func (h *Handler) AddTransaction(ctx context.Context, tx BroadcastedTransaction)
 		return nil, ErrInternal.CloneWithData(fmt.Sprintf("marshal transaction: %v", err))
 	}
 
+	if pooledTxn != nil {
+		pooledTxn.Payload = txJSON
+		return h.addToMempool(ctx, pooledTxn)
+	}
+
 	if h.gatewayClient == nil {
 		return nil, ErrInternal.CloneWithData("no gateway client configured")
 	}
@@ -565,7 +592,41 @@ func (h *Handler) AddTransaction(ctx context.Context, tx BroadcastedTransaction)
 	}, nil
 }
 
+func (h *Handler) addToMempool(ctx context.Context, txn *mempool.BroadcastedTransaction) (*AddTxResponse, *jsonrpc.Error) {
+	if err := h.mempool.Add(ctx, txn); err != nil {
+		var txnExecutionError vm.TransactionExecutionError
+		switch {
+		case errors.Is(err, mempool.ErrDuplicateTransaction):
+			return nil, ErrDuplicateTx
+		case errors.Is(err, mempool.ErrInvalidNonce):
+			return nil, ErrInvalidTransactionNonce
+		case errors.Is(err, mempool.ErrPoolFull):
+			return nil, ErrUnexpectedError.CloneWithData(err.Error())
+		case errors.As(err, &txnExecutionError):
+			return nil, ErrValidationFailure.CloneWithData(txnExecutionError.Cause.Error())
+		case errors.Is(err, utils.ErrResourceBusy):
+			return nil, ErrInternal.CloneWithData(throttledVMErr)
+		default:
+			return nil, makeJSONErrorFromGatewayError(err)
+		}
+	}
+
+	response := &AddTxResponse{TransactionHash: txn.Transaction.Hash()}
+	switch t := txn.Transaction.(type) {
+	case *core.DeclareTransaction:
+		response.ClassHash = t.ClassHash
+	case *core.DeployAccountTransaction:
+		response.ContractAddress = t.ContractAddress
+	}
+	return response, nil
+}
+
 func (h *Handler) TransactionStatus(ctx context.Context, hash felt.Felt) (*TransactionStatus, *jsonrpc.Error) {
+	return h.transactionStatus(ctx, hash, nil)
+}
+
+// transactionStatus gets the status of the transaction hash, and asks the feeder through poll if it's not nil.
+func (h *Handler) transactionStatus(ctx context.Context, hash felt.Felt, poll *txStatusPoll) (*TransactionStatus, *jsonrpc.Error) {
 	receipt, txErr := h.TransactionReceiptByHash(hash)
 	switch txErr {
 	case nil:
@@ -574,22 +635,32 @@ func (h *Handler) TransactionStatus(ctx context.Context, hash felt.Felt) (*Trans
 			Execution: receipt.ExecutionStatus,
 		}, nil
 	case ErrTxnHashNotFound:
-		if h.feederClient == nil {
-			break
-		}
-
-		txStatus, err := h.feederClient.Transaction(ctx, &hash)
-		if err != nil {
-			return nil, jsonrpc.Err(jsonrpc.InternalError, err.Error())
+		inMempool := h.mempool != nil && h.mempool.Contains(&hash)
+		if h.feederClient != nil {
+			var txStatus *starknet.TransactionStatus
+			var err error
+			if poll != nil {
+				txStatus, err = poll.query(ctx, h, &hash)
+			} else {
+				txStatus, err = h.feederClient.Transaction(ctx, &hash)
+			}
+			if err != nil {
+				if !inMempool {
+					return nil, jsonrpc.Err(jsonrpc.InternalError, err.Error())
+				}
+				h.log.Debugw("Failed to get transaction status from feeder", "err", err)
+			} else if status, err := adaptTransactionStatus(txStatus); err == nil {
+				return status, nil
+			} else if !inMempool {
+				h.log.Errorw("Failed to adapt transaction status", "err", err)
+				return nil, ErrTxnHashNotFound
+			}
 		}
 
-		status, err := adaptTransactionStatus(txStatus)
-		if err != nil {
-			h.log.Errorw("Failed to adapt transaction status", "err", err)
-			return nil, ErrTxnHashNotFound
+		// The feeder doesn't know about the transaction yet, but it was accepted by the local mempool.
+		if inMempool {
+			return &TransactionStatus{Finality: TxnStatusReceived}, nil
 		}
-
-		return status, nil
 	}
 	return nil, txErr
 }
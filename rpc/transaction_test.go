@@ -12,11 +12,13 @@ import (
 	"github.com/NethermindEth/juno/core"
 	"github.com/NethermindEth/juno/core/felt"
 	"github.com/NethermindEth/juno/db"
+	"github.com/NethermindEth/juno/mempool"
 	"github.com/NethermindEth/juno/mocks"
 	"github.com/NethermindEth/juno/rpc"
 	"github.com/NethermindEth/juno/starknet"
 	adaptfeeder "github.com/NethermindEth/juno/starknetdata/feeder"
 	"github.com/NethermindEth/juno/utils"
+	"github.com/NethermindEth/juno/vm"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -1162,6 +1164,64 @@ func TestAddTransaction(t *testing.T) {
 	}
 }
 
+func TestAddTransactionWithMempool(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	t.Cleanup(mockCtrl.Finish)
+
+	n := utils.Ptr(utils.Integration)
+	gw := adaptfeeder.New(feeder.NewTestClient(t, n))
+	txn, err := gw.Transaction(context.Background(),
+		utils.HexToFelt(t, "0x45d9c2c8e01bacae6dec3438874576a4a1ce65f1d4247f4e9748f0e7216838"))
+	require.NoError(t, err)
+	broadcastedTxn := rpc.BroadcastedTransaction{Transaction: *rpc.AdaptTransaction(txn)}
+
+	mockReader := mocks.NewMockReader(mockCtrl)
+	mockState := mocks.NewMockStateHistoryReader(mockCtrl)
+	mockVM := mocks.NewMockVM(mockCtrl)
+	mockGateway := mocks.NewMockGateway(mockCtrl)
+
+	mockReader.EXPECT().Network().Return(n).AnyTimes()
+	mockReader.EXPECT().TransactionByHash(txn.Hash()).Return(nil, db.ErrKeyNotFound).AnyTimes()
+	mockReader.EXPECT().Receipt(txn.Hash()).Return(nil, nil, uint64(0), db.ErrKeyNotFound).AnyTimes()
+	mockReader.EXPECT().Pending().Return(blockchain.Pending{}, db.ErrKeyNotFound).AnyTimes()
+	mockReader.EXPECT().HeadsHeader().Return(&core.Header{Number: 1}, nil).AnyTimes()
+	mockReader.EXPECT().HeadState().Return(mockState, func() error { return nil }, nil).AnyTimes()
+
+	sender := utils.HexToFelt(t, "0x219937256cd88844f9fdc9c33a2d6d492e253ae13814c2dc0ecab7f26919d46")
+	mockState.EXPECT().ContractNonce(sender).Return(utils.HexToFelt(t, "0x99d"), nil).AnyTimes()
+
+	pool := mempool.New(mockReader, mockVM, mockGateway, utils.NewNopZapLogger())
+	handler := rpc.New(mockReader, nil, nil, "", utils.NewNopZapLogger()).WithMempool(pool)
+
+	t.Run("validation failure is returned before relaying", func(t *testing.T) {
+		mockVM.EXPECT().Execute(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), mockState, n, false, false, false).
+			Return(nil, nil, nil, uint64(0), vm.TransactionExecutionError{Cause: errors.New("invalid signature")})
+
+		resp, rpcErr := handler.AddTransaction(context.Background(), broadcastedTxn)
+		assert.Nil(t, resp)
+		assert.Equal(t, rpc.ErrValidationFailure.CloneWithData("invalid signature"), rpcErr)
+	})
+
+	t.Run("transaction is received while the gateway is unreachable", func(t *testing.T) {
+		mockVM.EXPECT().Execute(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), mockState, n, false, false, false).
+			Return(nil, nil, nil, uint64(0), nil)
+		mockGateway.EXPECT().AddTransaction(gomock.Any(), gomock.Any()).Return(nil, errors.New("connection refused"))
+
+		resp, rpcErr := handler.AddTransaction(context.Background(), broadcastedTxn)
+		require.Nil(t, rpcErr)
+		assert.Equal(t, &rpc.AddTxResponse{TransactionHash: txn.Hash()}, resp)
+
+		status, rpcErr := handler.TransactionStatus(context.Background(), *txn.Hash())
+		require.Nil(t, rpcErr)
+		assert.Equal(t, &rpc.TransactionStatus{Finality: rpc.TxnStatusReceived}, status)
+	})
+
+	t.Run("duplicate transaction", func(t *testing.T) {
+		_, rpcErr := handler.AddTransaction(context.Background(), broadcastedTxn)
+		assert.Equal(t, rpc.ErrDuplicateTx, rpcErr)
+	})
+}
+
 func TestTransactionStatus(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	t.Cleanup(mockCtrl.Finish)
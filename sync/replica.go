@@ -0,0 +1,108 @@
+package sync
+
+import (
+	"context"
+	"time"
+
+	"github.com/NethermindEth/juno/core"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/grpc/gen"
+)
+
+// headsRetryInterval is how long a read-only Synchronizer waits before reconnecting to a lost heads stream.
+const headsRetryInterval = 5 * time.Second
+
+// HeadsSource streams the changes of the chain head of the node that writes the database a read-only Synchronizer
+// reads, e.g. a remote database.
+type HeadsSource interface {
+	Heads(ctx context.Context) (gen.KV_HeadsClient, error)
+}
+
+// WithHeadsSource makes a read-only Synchronizer notify its subscribers of the new heads, reorgs and pending
+// transactions source streams.
+func (s *Synchronizer) WithHeadsSource(source HeadsSource) *Synchronizer {
+	s.headsSource = source
+	return s
+}
+
+// followHeads notifies the subscribers of the head changes of the node that writes the database, reconnecting
+// until ctx is cancelled.
+func (s *Synchronizer) followHeads(ctx context.Context) {
+	for {
+		err := s.receiveHeads(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		s.log.Warnw("Lost the heads stream, reconnecting", "err", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(headsRetryInterval):
+		}
+	}
+}
+
+func (s *Synchronizer) receiveHeads(ctx context.Context) error {
+	stream, err := s.headsSource.Heads(ctx)
+	if err != nil {
+		return err
+	}
+
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		switch e := event.Event.(type) {
+		case *gen.HeadEvent_NewHead:
+			s.replicateNewHead(e.NewHead)
+		case *gen.HeadEvent_Reorg:
+			s.reorgFeed.Send(&ReorgBlockRange{
+				StartBlockHash: new(felt.Felt).SetBytes(e.Reorg.StartBlockHash),
+				StartBlockNum:  e.Reorg.StartBlockNum,
+				EndBlockHash:   new(felt.Felt).SetBytes(e.Reorg.EndBlockHash),
+				EndBlockNum:    e.Reorg.EndBlockNum,
+			})
+		case *gen.HeadEvent_PendingTxs:
+			s.replicatePendingTxs(e.PendingTxs)
+		}
+	}
+}
+
+// replicateNewHead reads the header of a new head from the database, unless it was reverted already.
+func (s *Synchronizer) replicateNewHead(newHead *gen.NewHead) {
+	header, err := s.blockchain.BlockHeaderByNumber(newHead.Number)
+	if err != nil {
+		s.log.Debugw("Failed reading new head", "number", newHead.Number, "err", err)
+		return
+	}
+	if !header.Hash.Equal(new(felt.Felt).SetBytes(newHead.Hash)) {
+		return
+	}
+	s.newHeads.Send(header)
+}
+
+// replicatePendingTxs reads the transactions added to the pending block from the database.
+func (s *Synchronizer) replicatePendingTxs(pendingTxs *gen.PendingTxs) {
+	pending, err := s.blockchain.Pending()
+	if err != nil {
+		s.log.Debugw("Failed reading pending block", "err", err)
+		return
+	}
+
+	added := make(map[felt.Felt]struct{}, len(pendingTxs.TransactionHashes))
+	for _, hash := range pendingTxs.TransactionHashes {
+		added[*new(felt.Felt).SetBytes(hash)] = struct{}{}
+	}
+	var newTxs []core.Transaction
+	for _, txn := range pending.Block.Transactions {
+		if _, ok := added[*txn.Hash()]; ok {
+			newTxs = append(newTxs, txn)
+		}
+	}
+	if len(newTxs) > 0 {
+		s.pendingTxsFeed.Send(newTxs)
+	}
+}
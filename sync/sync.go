@@ -35,6 +35,26 @@ type HeaderSubscription struct {
 	*feed.Subscription[*core.Header]
 }
 
+type PendingTxSubscription struct {
+	*feed.Subscription[[]core.Transaction]
+}
+
+type ReorgSubscription struct {
+	*feed.Subscription[*ReorgBlockRange]
+}
+
+// ReorgBlockRange represents data about reorganised blocks, starting and ending block number and hash
+type ReorgBlockRange struct {
+	// StartBlockHash is the hash of the first known block of the orphaned chain
+	StartBlockHash *felt.Felt
+	// StartBlockNum is the number of the first known block of the orphaned chain
+	StartBlockNum uint64
+	// The last known block of the orphaned chain
+	EndBlockHash *felt.Felt
+	// Number of the last known block of the orphaned chain
+	EndBlockNum uint64
+}
+
 // Todo: Since this is also going to be implemented by p2p package we should move this interface to node package
 //
 //go:generate mockgen -destination=../mocks/mock_synchronizer.go -package=mocks -mock_names Reader=MockSyncReader github.com/NethermindEth/juno/sync Reader
@@ -42,6 +62,8 @@ type Reader interface {
 	StartingBlockNumber() (uint64, error)
 	HighestBlockHeader() *core.Header
 	SubscribeNewHeads() HeaderSubscription
+	SubscribeReorg() ReorgSubscription
+	SubscribePendingTxs() PendingTxSubscription
 }
 
 // This is temporary and will be removed once the p2p synchronizer implements this interface.
@@ -59,6 +81,14 @@ func (n *NoopSynchronizer) SubscribeNewHeads() HeaderSubscription {
 	return HeaderSubscription{feed.New[*core.Header]().Subscribe()}
 }
 
+func (n *NoopSynchronizer) SubscribeReorg() ReorgSubscription {
+	return ReorgSubscription{feed.New[*ReorgBlockRange]().Subscribe()}
+}
+
+func (n *NoopSynchronizer) SubscribePendingTxs() PendingTxSubscription {
+	return PendingTxSubscription{feed.New[[]core.Transaction]().Subscribe()}
+}
+
 // Synchronizer manages a list of StarknetData to fetch the latest blockchain updates
 type Synchronizer struct {
 	blockchain          *blockchain.Blockchain
@@ -67,6 +97,8 @@ type Synchronizer struct {
 	startingBlockNumber *uint64
 	highestBlockHeader  atomic.Pointer[core.Header]
 	newHeads            *feed.Feed[*core.Header]
+	reorgFeed           *feed.Feed[*ReorgBlockRange]
+	pendingTxsFeed      *feed.Feed[[]core.Transaction]
 
 	log      utils.SimpleLogger
 	listener EventListener
@@ -74,6 +106,9 @@ type Synchronizer struct {
 	pendingPollInterval time.Duration
 	catchUpMode         bool
 	plugin              junoplugin.JunoPlugin
+	headsSource         HeadsSource
+
+	currReorg *ReorgBlockRange // If nil, no reorg is happening
 }
 
 func New(bc *blockchain.Blockchain, starkNetData starknetdata.StarknetData,
@@ -84,6 +119,8 @@ func New(bc *blockchain.Blockchain, starkNetData starknetdata.StarknetData,
 		starknetData:        starkNetData,
 		log:                 log,
 		newHeads:            feed.New[*core.Header](),
+		reorgFeed:           feed.New[*ReorgBlockRange](),
+		pendingTxsFeed:      feed.New[[]core.Transaction](),
 		pendingPollInterval: pendingPollInterval,
 		listener:            &SelectiveListener{},
 		readOnlyBlockchain:  readOnlyBlockchain,
@@ -189,46 +226,7 @@ func (s *Synchronizer) fetchUnknownClasses(ctx context.Context, stateUpdate *cor
 }
 
 func (s *Synchronizer) handlePluginRevertBlock() {
-	fromBlock, err := s.blockchain.Head()
-	if err != nil {
-		s.log.Warnw("Failed to retrieve the reverted blockchain head block for the plugin", "err", err)
-		return
-	}
-	fromSU, err := s.blockchain.StateUpdateByNumber(fromBlock.Number)
-	if err != nil {
-		s.log.Warnw("Failed to retrieve the reverted blockchain head state-update for the plugin", "err", err)
-		return
-	}
-	reverseStateDiff, err := s.blockchain.GetReverseStateDiff()
-	if err != nil {
-		s.log.Warnw("Failed to retrieve reverse state diff", "head", fromBlock.Number, "hash", fromBlock.Hash.ShortString(), "err", err)
-		return
-	}
-
-	var toBlockAndStateUpdate *junoplugin.BlockAndStateUpdate
-	if fromBlock.Number != 0 {
-		toBlock, err := s.blockchain.BlockByHash(fromBlock.ParentHash)
-		if err != nil {
-			s.log.Warnw("Failed to retrieve the parent block for the plugin", "err", err)
-			return
-		}
-		toSU, err := s.blockchain.StateUpdateByNumber(toBlock.Number)
-		if err != nil {
-			s.log.Warnw("Failed to retrieve the parents state-update for the plugin", "err", err)
-			return
-		}
-		toBlockAndStateUpdate = &junoplugin.BlockAndStateUpdate{
-			Block:       toBlock,
-			StateUpdate: toSU,
-		}
-	}
-	err = s.plugin.RevertBlock(
-		&junoplugin.BlockAndStateUpdate{Block: fromBlock, StateUpdate: fromSU},
-		toBlockAndStateUpdate,
-		reverseStateDiff)
-	if err != nil {
-		s.log.Errorw("Plugin RevertBlock failure:", "err", err)
-	}
+	NotifyPluginRevert(s.blockchain, s.plugin, s.log)
 }
 
 func (s *Synchronizer) verifierTask(ctx context.Context, block *core.Block, stateUpdate *core.StateUpdate,
@@ -282,6 +280,11 @@ func (s *Synchronizer) verifierTask(ctx context.Context, block *core.Block, stat
 				s.highestBlockHeader.CompareAndSwap(highestBlockHeader, block.Header)
 			}
 
+			if s.currReorg != nil {
+				s.reorgFeed.Send(s.currReorg)
+				s.currReorg = nil // reset the reorg data
+			}
+
 			s.newHeads.Send(block.Header)
 			s.log.Infow("Stored Block", "number", block.Number, "hash",
 				block.Hash.ShortString(), "root", block.GlobalStateRoot.ShortString())
@@ -315,6 +318,9 @@ func (s *Synchronizer) syncBlocks(syncCtx context.Context) {
 
 	latestSem := make(chan struct{}, 1)
 	if s.readOnlyBlockchain {
+		if s.headsSource != nil {
+			go s.followHeads(syncCtx)
+		}
 		s.pollLatest(syncCtx, latestSem)
 		return
 	}
@@ -381,6 +387,18 @@ func (s *Synchronizer) revertHead(forkBlock *core.Block) {
 	} else {
 		s.log.Infow("Reverted HEAD", "reverted", localHead)
 	}
+
+	if s.currReorg == nil { // first block of the reorg
+		s.currReorg = &ReorgBlockRange{
+			StartBlockHash: localHead,
+			StartBlockNum:  head.Number,
+			EndBlockHash:   localHead,
+			EndBlockNum:    head.Number,
+		}
+	} else { // not the first block of the reorg, adjust the starting block
+		s.currReorg.StartBlockHash = localHead
+		s.currReorg.StartBlockNum = head.Number
+	}
 	s.listener.OnReorg(head.Number)
 }
 
@@ -472,12 +490,51 @@ func (s *Synchronizer) fetchAndStorePending(ctx context.Context) error {
 		return err
 	}
 
+	oldPending, err := s.blockchain.Pending()
+	if err != nil && !errors.Is(err, db.ErrKeyNotFound) {
+		return err
+	}
+
 	s.log.Debugw("Found pending block", "txns", pendingBlock.TransactionCount)
-	return s.blockchain.StorePending(&blockchain.Pending{
+	err = s.blockchain.StorePending(&blockchain.Pending{
 		Block:       pendingBlock,
 		StateUpdate: pendingStateUpdate,
 		NewClasses:  newClasses,
 	})
+	if err != nil {
+		return err
+	}
+
+	// StorePending may keep the existing pending block, so diff against what was actually stored.
+	newPending, err := s.blockchain.Pending()
+	if err != nil {
+		return err
+	}
+	if newTxs := newPendingTxs(&oldPending, &newPending); len(newTxs) > 0 {
+		s.pendingTxsFeed.Send(newTxs)
+	}
+	return nil
+}
+
+// newPendingTxs returns the transactions of next that were not part of prev.
+// All transactions are new if next is built on top of a different block than prev.
+func newPendingTxs(prev, next *blockchain.Pending) []core.Transaction {
+	if prev.Block == nil || !prev.Block.ParentHash.Equal(next.Block.ParentHash) {
+		return next.Block.Transactions
+	}
+
+	seen := make(map[felt.Felt]struct{}, len(prev.Block.Transactions))
+	for _, txn := range prev.Block.Transactions {
+		seen[*txn.Hash()] = struct{}{}
+	}
+
+	var newTxs []core.Transaction
+	for _, txn := range next.Block.Transactions {
+		if _, ok := seen[*txn.Hash()]; !ok {
+			newTxs = append(newTxs, txn)
+		}
+	}
+	return newTxs
 }
 
 func (s *Synchronizer) StartingBlockNumber() (uint64, error) {
@@ -496,3 +553,62 @@ func (s *Synchronizer) SubscribeNewHeads() HeaderSubscription {
 		Subscription: s.newHeads.Subscribe(),
 	}
 }
+
+func (s *Synchronizer) SubscribeReorg() ReorgSubscription {
+	return ReorgSubscription{
+		Subscription: s.reorgFeed.Subscribe(),
+	}
+}
+
+// SubscribePendingTxs returns a subscription that receives the transactions newly added to the pending block
+// every time it is updated.
+func (s *Synchronizer) SubscribePendingTxs() PendingTxSubscription {
+	return PendingTxSubscription{
+		Subscription: s.pendingTxsFeed.Subscribe(),
+	}
+}
+
+// NotifyPluginRevert notifies plugin that the head of bc is about to be reverted. It is called before reverting, as
+// the reverse state diff is read from the head.
+func NotifyPluginRevert(bc *blockchain.Blockchain, plugin junoplugin.JunoPlugin, log utils.SimpleLogger) {
+	fromBlock, err := bc.Head()
+	if err != nil {
+		log.Warnw("Failed to retrieve the reverted blockchain head block for the plugin", "err", err)
+		return
+	}
+	fromSU, err := bc.StateUpdateByNumber(fromBlock.Number)
+	if err != nil {
+		log.Warnw("Failed to retrieve the reverted blockchain head state-update for the plugin", "err", err)
+		return
+	}
+	reverseStateDiff, err := bc.GetReverseStateDiff()
+	if err != nil {
+		log.Warnw("Failed to retrieve reverse state diff", "head", fromBlock.Number, "hash", fromBlock.Hash.ShortString(), "err", err)
+		return
+	}
+
+	var toBlockAndStateUpdate *junoplugin.BlockAndStateUpdate
+	if fromBlock.Number != 0 {
+		toBlock, err := bc.BlockByHash(fromBlock.ParentHash)
+		if err != nil {
+			log.Warnw("Failed to retrieve the parent block for the plugin", "err", err)
+			return
+		}
+		toSU, err := bc.StateUpdateByNumber(toBlock.Number)
+		if err != nil {
+			log.Warnw("Failed to retrieve the parents state-update for the plugin", "err", err)
+			return
+		}
+		toBlockAndStateUpdate = &junoplugin.BlockAndStateUpdate{
+			Block:       toBlock,
+			StateUpdate: toSU,
+		}
+	}
+	err = plugin.RevertBlock(
+		&junoplugin.BlockAndStateUpdate{Block: fromBlock, StateUpdate: fromSU},
+		toBlockAndStateUpdate,
+		reverseStateDiff)
+	if err != nil {
+		log.Errorw("Plugin RevertBlock failure:", "err", err)
+	}
+}
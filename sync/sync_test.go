@@ -161,7 +161,11 @@ func TestReorg(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, utils.HexToFelt(t, "0x34e815552e42c5eb5233b99de2d3d7fd396e575df2719bf98e7ed2794494f86"), head.Hash)
 
+		integGenesis, err := bc.BlockHeaderByNumber(0)
+		require.NoError(t, err)
+
 		synchronizer = sync.New(bc, mainGw, utils.NewNopZapLogger(), 0, false)
+		sub := synchronizer.SubscribeReorg()
 		ctx, cancel = context.WithTimeout(context.Background(), timeout)
 		require.NoError(t, synchronizer.Run(ctx))
 		cancel()
@@ -170,6 +174,17 @@ func TestReorg(t *testing.T) {
 		head, err = bc.HeadsHeader()
 		require.NoError(t, err)
 		require.Equal(t, utils.HexToFelt(t, "0x4e1f77f39545afe866ac151ac908bd1a347a2a8a7d58bef1276db4f06fdf2f6"), head.Hash)
+
+		// The whole orphaned integration chain should be reported as a single reorg
+		got, ok := <-sub.Recv()
+		require.True(t, ok)
+		assert.Equal(t, &sync.ReorgBlockRange{
+			StartBlockHash: integGenesis.Hash,
+			StartBlockNum:  0,
+			EndBlockHash:   utils.HexToFelt(t, "0x34e815552e42c5eb5233b99de2d3d7fd396e575df2719bf98e7ed2794494f86"),
+			EndBlockNum:    1,
+		}, got)
+		sub.Unsubscribe()
 	})
 }
 
@@ -195,6 +210,38 @@ func TestPending(t *testing.T) {
 	assert.Equal(t, head.Hash, pending.Block.ParentHash)
 }
 
+func TestSubscribePendingTxs(t *testing.T) {
+	t.Parallel()
+
+	client := feeder.NewTestClient(t, &utils.Mainnet)
+	gw := adaptfeeder.New(client)
+
+	testDB := pebble.NewMemTest(t)
+	log := utils.NewNopZapLogger()
+	bc := blockchain.New(testDB, &utils.Mainnet)
+	synchronizer := sync.New(bc, gw, log, time.Millisecond*100, false)
+	sub := synchronizer.SubscribePendingTxs()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	require.NoError(t, synchronizer.Run(ctx))
+	cancel()
+
+	pending, err := bc.Pending()
+	require.NoError(t, err)
+	require.NotEmpty(t, pending.Block.Transactions)
+
+	// Every pending transaction is announced once, polling the same pending block again yields nothing new.
+	got, ok := <-sub.Recv()
+	require.True(t, ok)
+	assert.Equal(t, pending.Block.Transactions, got)
+	select {
+	case <-sub.Recv():
+		require.Fail(t, "pending transactions should only be announced once")
+	default:
+	}
+	sub.Unsubscribe()
+}
+
 func TestSubscribeNewHeads(t *testing.T) {
 	t.Parallel()
 	testDB := pebble.NewMemTest(t)
@@ -23,6 +23,8 @@ type Network struct {
 	L2ChainID           string             `json:"l2_chain_id" validate:"required"`
 	CoreContractAddress common.Address     `json:"core_contract_address" validate:"required"`
 	BlockHashMetaInfo   *BlockHashMetaInfo `json:"block_hash_meta_info"`
+	// The key the sequencer signs blocks with, if not set it is fetched from the feeder gateway as a fallback
+	SequencerPublicKey *felt.Felt `json:"sequencer_public_key"`
 }
 
 type BlockHashMetaInfo struct {
@@ -37,6 +39,9 @@ type BlockHashMetaInfo struct {
 var (
 	fallBackSequencerAddressMainnet, _ = new(felt.Felt).SetString("0x021f4b90b0377c82bf330b7b5295820769e72d79d8acd0effa0ebde6e9988bc5")
 	fallBackSequencerAddress, _        = new(felt.Felt).SetString("0x046a89ae102987331d369645031b49c27738ed096f2789c24449966da4c6de6b")
+	sequencerPublicKeyMainnet, _       = new(felt.Felt).SetString("0x48253ff2c3bed7af18bde0b611b083b39445959102d4947c51c4db6aa4f4e58")
+	sequencerPublicKeySepolia, _       = new(felt.Felt).SetString("0x1252b6bce1351844c677869c6327e80eae1535755b611c66b8f46e595b40eea")
+	sequencerPublicKeyIntegration, _   = new(felt.Felt).SetString("0x4e4856eb36dbd5f4a7dca29f7bb5232974ef1fb7eb5b597c58077174c294da1")
 	// The following are necessary for Cobra and Viper, respectively, to unmarshal log level CLI/config parameters properly.
 	_ pflag.Value              = (*Network)(nil)
 	_ encoding.TextUnmarshaler = (*Network)(nil)
@@ -53,6 +58,7 @@ var (
 			First07Block:             833,
 			FallBackSequencerAddress: fallBackSequencerAddressMainnet,
 		},
+		SequencerPublicKey: sequencerPublicKeyMainnet,
 	}
 	Goerli = Network{
 		Name:       "goerli",
@@ -107,6 +113,7 @@ var (
 			First07Block:             0,
 			FallBackSequencerAddress: fallBackSequencerAddress,
 		},
+		SequencerPublicKey: sequencerPublicKeySepolia,
 	}
 	SepoliaIntegration = Network{
 		Name:       "sepolia-integration",
@@ -120,6 +127,7 @@ var (
 			First07Block:             0,
 			FallBackSequencerAddress: fallBackSequencerAddress,
 		},
+		SequencerPublicKey: sequencerPublicKeyIntegration,
 	}
 )
 
@@ -46,6 +46,30 @@ type DeclaredClass struct {
 	ClassHash         felt.Felt `json:"class_hash"`
 	CompiledClassHash felt.Felt `json:"compiled_class_hash"`
 }
+
+// Prestate holds the values that the state diff of a transaction overwrote. Values that weren't set before the
+// transaction, such as the class hash of a contract it deployed, are zero.
+type Prestate struct {
+	StorageDiffs []StorageDiff   `json:"storage_diffs"`
+	Nonces       []Nonce         `json:"nonces"`
+	ClassHashes  []ReplacedClass `json:"class_hashes"`
+}
+
+// FlatCall is an invocation without its internal calls. TraceAddress is the path to the invocation from the
+// top-level invocation named by Root, as the indices of the internal calls taken at each depth.
+type FlatCall struct {
+	Root               string      `json:"root"`
+	TraceAddress       []int       `json:"trace_address"`
+	ContractAddress    felt.Felt   `json:"contract_address"`
+	EntryPointSelector *felt.Felt  `json:"entry_point_selector,omitempty"`
+	Calldata           []felt.Felt `json:"calldata"`
+	CallerAddress      felt.Felt   `json:"caller_address"`
+	ClassHash          *felt.Felt  `json:"class_hash,omitempty"`
+	EntryPointType     string      `json:"entry_point_type,omitempty"`
+	CallType           string      `json:"call_type,omitempty"`
+	Result             []felt.Felt `json:"result"`
+}
+
 type TransactionType uint8
 
 const (
@@ -105,6 +129,9 @@ type TransactionTrace struct {
 	FunctionInvocation    *FunctionInvocation `json:"function_invocation,omitempty"`
 	StateDiff             *StateDiff          `json:"state_diff,omitempty"`
 	ExecutionResources    *ExecutionResources `json:"execution_resources,omitempty"`
+	// Prestate and CallList are only set when requested from the trace RPC methods.
+	Prestate *Prestate  `json:"prestate,omitempty"`
+	CallList []FlatCall `json:"call_list,omitempty"`
 }
 
 func (t *TransactionTrace) allInvocations() []*FunctionInvocation {
@@ -162,6 +189,44 @@ func (t *TransactionTrace) AllMessages() []OrderedL2toL1Message {
 	return messages
 }
 
+// AnyInvocation reports whether match returns true for any invocation in the call trees of the transaction.
+func (t *TransactionTrace) AnyInvocation(match func(*FunctionInvocation) bool) bool {
+	return slices.ContainsFunc(t.allInvocations(), func(invocation *FunctionInvocation) bool {
+		return invocation.anyInvocation(match)
+	})
+}
+
+// Flatten lists the invocations of the transaction in the order they were executed in, each followed by its
+// internal calls. Accounts are validated before execution, except that a deployed account runs its constructor
+// before its validation.
+func (t *TransactionTrace) Flatten() []FlatCall {
+	var executeInvocation *FunctionInvocation
+	if t.ExecuteInvocation != nil {
+		executeInvocation = t.ExecuteInvocation.FunctionInvocation
+	}
+	roots := []struct {
+		name       string
+		invocation *FunctionInvocation
+	}{
+		{"validate_invocation", t.ValidateInvocation},
+		{"constructor_invocation", t.ConstructorInvocation},
+		{"execute_invocation", executeInvocation},
+		{"function_invocation", t.FunctionInvocation},
+		{"fee_transfer_invocation", t.FeeTransferInvocation},
+	}
+	if t.Type == TxnDeployAccount {
+		roots[0], roots[1] = roots[1], roots[0]
+	}
+
+	calls := make([]FlatCall, 0)
+	for _, root := range roots {
+		if root.invocation != nil {
+			calls = root.invocation.flatten(root.name, []int{}, calls)
+		}
+	}
+	return calls
+}
+
 type FunctionInvocation struct {
 	ContractAddress    felt.Felt              `json:"contract_address"`
 	EntryPointSelector *felt.Felt             `json:"entry_point_selector,omitempty"`
@@ -177,6 +242,37 @@ type FunctionInvocation struct {
 	ExecutionResources *ExecutionResources    `json:"execution_resources,omitempty"`
 }
 
+func (invocation *FunctionInvocation) anyInvocation(match func(*FunctionInvocation) bool) bool {
+	if match(invocation) {
+		return true
+	}
+	for i := range invocation.Calls {
+		if invocation.Calls[i].anyInvocation(match) {
+			return true
+		}
+	}
+	return false
+}
+
+func (invocation *FunctionInvocation) flatten(root string, traceAddress []int, calls []FlatCall) []FlatCall {
+	calls = append(calls, FlatCall{
+		Root:               root,
+		TraceAddress:       traceAddress,
+		ContractAddress:    invocation.ContractAddress,
+		EntryPointSelector: invocation.EntryPointSelector,
+		Calldata:           invocation.Calldata,
+		CallerAddress:      invocation.CallerAddress,
+		ClassHash:          invocation.ClassHash,
+		EntryPointType:     invocation.EntryPointType,
+		CallType:           invocation.CallType,
+		Result:             invocation.Result,
+	})
+	for i := range invocation.Calls {
+		calls = invocation.Calls[i].flatten(root, append(slices.Clone(traceAddress), i), calls)
+	}
+	return calls
+}
+
 func (invocation *FunctionInvocation) allEvents() []OrderedEvent {
 	events := make([]OrderedEvent, 0)
 	for i := range invocation.Calls {
@@ -271,3 +271,46 @@ func TestExecuteInvocation(t *testing.T) {
 		})
 	}
 }
+
+func TestFlatten(t *testing.T) {
+	validate := &vm.FunctionInvocation{
+		EntryPointType: "EXTERNAL",
+		Calls:          []vm.FunctionInvocation{{EntryPointType: "EXTERNAL"}},
+	}
+	constructor := &vm.FunctionInvocation{EntryPointType: "CONSTRUCTOR"}
+	feeTransfer := &vm.FunctionInvocation{EntryPointType: "EXTERNAL"}
+
+	roots := func(calls []vm.FlatCall) []string {
+		names := make([]string, 0, len(calls))
+		for _, call := range calls {
+			names = append(names, call.Root)
+		}
+		return names
+	}
+
+	t.Run("invoke", func(t *testing.T) {
+		calls := (&vm.TransactionTrace{
+			Type:                  vm.TxnInvoke,
+			ValidateInvocation:    validate,
+			ExecuteInvocation:     &vm.ExecuteInvocation{FunctionInvocation: &vm.FunctionInvocation{}},
+			FeeTransferInvocation: feeTransfer,
+		}).Flatten()
+		require.Equal(t, []string{
+			"validate_invocation", "validate_invocation", "execute_invocation", "fee_transfer_invocation",
+		}, roots(calls))
+		require.Equal(t, []int{}, calls[0].TraceAddress)
+		require.Equal(t, []int{0}, calls[1].TraceAddress)
+	})
+
+	t.Run("deploy account runs the constructor before validation", func(t *testing.T) {
+		calls := (&vm.TransactionTrace{
+			Type:                  vm.TxnDeployAccount,
+			ValidateInvocation:    validate,
+			ConstructorInvocation: constructor,
+			FeeTransferInvocation: feeTransfer,
+		}).Flatten()
+		require.Equal(t, []string{
+			"constructor_invocation", "validate_invocation", "validate_invocation", "fee_transfer_invocation",
+		}, roots(calls))
+	})
+}